@@ -35,6 +35,8 @@ func TestEnsureTemplatesLoaded(t *testing.T) {
 			path.Join(rootDir, "metrics_test.go.tmpl"):         {},
 			path.Join(rootDir, "metrics.go.tmpl"):              {},
 			path.Join(rootDir, "readme.md.tmpl"):               {},
+			path.Join(rootDir, "resource.go.tmpl"):             {},
+			path.Join(rootDir, "resource_test.go.tmpl"):        {},
 			path.Join(rootDir, "status.go.tmpl"):               {},
 			path.Join(rootDir, "testdata", "config.yaml.tmpl"): {},
 		}
@@ -75,20 +75,32 @@ func run(ymlPath string) error {
 			return err
 		}
 	}
-	if len(md.Metrics) == 0 {
+	if len(md.Metrics) == 0 && len(md.ResourceAttributes) == 0 {
 		return nil
 	}
 	if err = os.MkdirAll(filepath.Join(codeDir, "testdata"), 0700); err != nil {
 		return fmt.Errorf("unable to create output directory %q: %w", filepath.Join(codeDir, "testdata"), err)
 	}
-	if err = generateFile(filepath.Join(tmplDir, "metrics.go.tmpl"),
-		filepath.Join(codeDir, "generated_metrics.go"), md); err != nil {
-		return err
-	}
 	if err = generateFile(filepath.Join(tmplDir, "testdata", "config.yaml.tmpl"),
 		filepath.Join(codeDir, "testdata", "config.yaml"), md); err != nil {
 		return err
 	}
+	if len(md.Metrics) == 0 {
+		// Components that only describe resource attributes get a resource builder instead of a metrics builder.
+		if err = generateFile(filepath.Join(tmplDir, "resource.go.tmpl"),
+			filepath.Join(codeDir, "generated_resource.go"), md); err != nil {
+			return err
+		}
+		if err = generateFile(filepath.Join(tmplDir, "resource_test.go.tmpl"),
+			filepath.Join(codeDir, "generated_resource_test.go"), md); err != nil {
+			return err
+		}
+		return generateFile(filepath.Join(tmplDir, "documentation.md.tmpl"), filepath.Join(ymlDir, "documentation.md"), md)
+	}
+	if err = generateFile(filepath.Join(tmplDir, "metrics.go.tmpl"),
+		filepath.Join(codeDir, "generated_metrics.go"), md); err != nil {
+		return err
+	}
 	if err = generateFile(filepath.Join(tmplDir, "metrics_test.go.tmpl"),
 		filepath.Join(codeDir, "generated_metrics_test.go"), md); err != nil {
 		return err
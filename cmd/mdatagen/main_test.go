@@ -28,11 +28,12 @@ import (
 
 func Test_runContents(t *testing.T) {
 	tests := []struct {
-		name                 string
-		yml                  string
-		wantMetricsGenerated bool
-		wantStatusGenerated  bool
-		wantErr              bool
+		name                  string
+		yml                   string
+		wantMetricsGenerated  bool
+		wantResourceGenerated bool
+		wantStatusGenerated   bool
+		wantErr               bool
 	}{
 		{
 			name: "valid metadata",
@@ -47,6 +48,21 @@ metrics:
       value_type: double`,
 			wantMetricsGenerated: true,
 		},
+		{
+			name: "resource attributes only",
+			yml: `
+type: metricreceiver
+resource_attributes:
+  string.attr:
+    description: String attribute.
+    enabled: true
+    type: string
+  slice.attr:
+    description: Slice attribute.
+    enabled: false
+    type: slice`,
+			wantResourceGenerated: true,
+		},
 		{
 			name:    "invalid yaml",
 			yml:     "invalid",
@@ -88,6 +104,15 @@ foo
 				require.FileExists(t, filepath.Join(tmpdir, "documentation.md"))
 			} else {
 				require.NoFileExists(t, filepath.Join(tmpdir, "internal/metadata/generated_metrics.go"))
+			}
+			if tt.wantResourceGenerated {
+				require.FileExists(t, filepath.Join(tmpdir, "internal/metadata/generated_resource.go"))
+				require.FileExists(t, filepath.Join(tmpdir, "internal/metadata/generated_resource_test.go"))
+				require.FileExists(t, filepath.Join(tmpdir, "documentation.md"))
+			} else {
+				require.NoFileExists(t, filepath.Join(tmpdir, "internal/metadata/generated_resource.go"))
+			}
+			if !tt.wantMetricsGenerated && !tt.wantResourceGenerated {
 				require.NoFileExists(t, filepath.Join(tmpdir, "documentation.md"))
 			}
 			if tt.wantStatusGenerated {
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // registers the gzip gRPC compressor
+)
+
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+// GRPCCompressor returns the name of the gRPC compressor to use, or an empty
+// string when the data is not compressed.
+func (c *Config) GRPCCompressor() string {
+	if c.Compression == CompressionNone {
+		return ""
+	}
+	return c.Compression
+}
+
+// zstdCompressor is a gRPC compressor using zstd, which grpc-go doesn't provide.
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+var _ encoding.Compressor = (*zstdCompressor)(nil)
+
+func (z *zstdCompressor) Name() string {
+	return CompressionZstd
+}
+
+func (z *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	enc, ok := z.encoders.Get().(*zstd.Encoder)
+	if !ok {
+		var err error
+		if enc, err = zstd.NewWriter(nil); err != nil {
+			return nil, err
+		}
+	}
+	enc.Reset(w)
+	return &pooledEncoder{Encoder: enc, pool: &z.encoders}, nil
+}
+
+func (z *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec, ok := z.decoders.Get().(*zstd.Decoder)
+	if !ok {
+		var err error
+		if dec, err = zstd.NewReader(nil); err != nil {
+			return nil, err
+		}
+	}
+	defer z.decoders.Put(dec)
+	if err := dec.Reset(r); err != nil {
+		return nil, err
+	}
+	b, err := io.ReadAll(dec)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(b), nil
+}
+
+// pooledEncoder returns the encoder to the pool once the message is written.
+type pooledEncoder struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (e *pooledEncoder) Close() error {
+	err := e.Encoder.Close()
+	e.pool.Put(e.Encoder)
+	return err
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestZstdCompressor(t *testing.T) {
+	compressor := encoding.GetCompressor(CompressionZstd)
+	require.NotNil(t, compressor)
+
+	payload := bytes.Repeat([]byte("telemetrygen "), 100)
+	for i := 0; i < 2; i++ { // the second round reuses pooled encoders and decoders
+		var buf bytes.Buffer
+		w, err := compressor.Compress(&buf)
+		require.NoError(t, err)
+		_, err = w.Write(payload)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		assert.Less(t, buf.Len(), len(payload))
+
+		r, err := compressor.Decompress(&buf)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, payload, got)
+	}
+}
+
+func TestGRPCCompressor(t *testing.T) {
+	assert.Equal(t, "", (&Config{Compression: CompressionNone}).GRPCCompressor())
+	assert.Equal(t, "gzip", (&Config{Compression: CompressionGzip}).GRPCCompressor())
+	assert.Equal(t, "zstd", (&Config{Compression: CompressionZstd}).GRPCCompressor())
+}
@@ -15,7 +15,11 @@
 package common
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -59,6 +63,7 @@ type Config struct {
 	Rate              int64
 	TotalDuration     time.Duration
 	ReportingInterval time.Duration
+	ScenarioFile      string
 
 	// OTLP config
 	Endpoint           string
@@ -66,6 +71,69 @@ type Config struct {
 	UseHTTP            bool
 	Headers            KeyValue
 	ResourceAttributes KeyValue
+	Compression        string
+
+	// TLS config, ignored when Insecure is set
+	CaFile             string
+	ClientCertFile     string
+	ClientKeyFile      string
+	ServerName         string
+	InsecureSkipVerify bool
+
+	// MaxErrorRate is the ratio of items failing to be exported above which the test fails.
+	MaxErrorRate float64
+}
+
+// Validate checks the common flags are consistent.
+func (c *Config) Validate() error {
+	switch c.Compression {
+	case "", CompressionNone, CompressionGzip:
+	case CompressionZstd:
+		if c.UseHTTP {
+			return errors.New("zstd compression is only supported by the gRPC exporter")
+		}
+	default:
+		return fmt.Errorf("unsupported compression %q", c.Compression)
+	}
+	if (c.ClientCertFile == "") != (c.ClientKeyFile == "") {
+		return errors.New("both `otlp-client-cert` and `otlp-client-key` must be provided for mTLS")
+	}
+	if c.MaxErrorRate < 0 || c.MaxErrorRate > 1 {
+		return errors.New("`max-error-rate` must be between 0 and 1")
+	}
+	return nil
+}
+
+// TLSConfig returns the TLS settings used to connect to the endpoint. It
+// returns nil when the connection is insecure.
+func (c *Config) TLSConfig() (*tls.Config, error) {
+	if c.Insecure {
+		return nil, nil
+	}
+	tlsCfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify, // nolint:gosec
+	}
+	if c.CaFile != "" {
+		pem, err := os.ReadFile(c.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse CA certificate %q", c.CaFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if c.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
 }
 
 func (c *Config) GetAttributes() []attribute.KeyValue {
@@ -85,10 +153,20 @@ func (c *Config) CommonFlags(fs *pflag.FlagSet) {
 	fs.Int64Var(&c.Rate, "rate", 0, "Approximately how many metrics per second each worker should generate. Zero means no throttling.")
 	fs.DurationVar(&c.TotalDuration, "duration", 0, "For how long to run the test")
 	fs.DurationVar(&c.ReportingInterval, "interval", 1*time.Second, "Reporting interval (default 1 second)")
+	fs.StringVar(&c.ScenarioFile, "scenario", "", "Path to a scenario file describing the telemetry to generate, replacing the built-in telemetry shape")
 
 	fs.StringVar(&c.Endpoint, "otlp-endpoint", "localhost:4317", "Target to which the exporter is going to send metrics. This MAY be configured to include a path (e.g. example.com/v1/metrics)")
 	fs.BoolVar(&c.Insecure, "otlp-insecure", false, "Whether to enable client transport security for the exporter's grpc or http connection")
 	fs.BoolVar(&c.UseHTTP, "otlp-http", false, "Whether to use HTTP exporter rather than a gRPC one")
+	fs.StringVar(&c.Compression, "otlp-compression", CompressionNone, "Compression of the exported data: none, gzip or zstd (gRPC only)")
+
+	fs.StringVar(&c.CaFile, "otlp-ca-cert", "", "Path to the CA certificate used to verify the server, the system certificates are used when not set")
+	fs.StringVar(&c.ClientCertFile, "otlp-client-cert", "", "Path to the client certificate used for mTLS")
+	fs.StringVar(&c.ClientKeyFile, "otlp-client-key", "", "Path to the client key used for mTLS")
+	fs.StringVar(&c.ServerName, "otlp-server-name", "", "Server name used to verify the server certificate, defaults to the endpoint host")
+	fs.BoolVar(&c.InsecureSkipVerify, "otlp-insecure-skip-verify", false, "Whether to skip verifying the server certificate")
+
+	fs.Float64Var(&c.MaxErrorRate, "max-error-rate", 1, "Ratio of items failing to be exported, between 0 and 1, above which telemetrygen exits with an error")
 
 	// custom headers
 	c.Headers = make(map[string]string)
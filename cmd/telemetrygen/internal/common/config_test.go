@@ -15,9 +15,20 @@
 package common
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestKeyValueSet(t *testing.T) {
@@ -60,3 +71,103 @@ func TestKeyValueSet(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  string
+	}{
+		{
+			name: "defaults",
+			cfg:  Config{Compression: CompressionNone, MaxErrorRate: 1},
+		},
+		{
+			name: "zstd over gRPC",
+			cfg:  Config{Compression: CompressionZstd},
+		},
+		{
+			name: "zstd over HTTP",
+			cfg:  Config{Compression: CompressionZstd, UseHTTP: true},
+			err:  "zstd compression is only supported by the gRPC exporter",
+		},
+		{
+			name: "unknown compression",
+			cfg:  Config{Compression: "lz4"},
+			err:  `unsupported compression "lz4"`,
+		},
+		{
+			name: "client cert without key",
+			cfg:  Config{ClientCertFile: "cert.pem"},
+			err:  "both `otlp-client-cert` and `otlp-client-key` must be provided for mTLS",
+		},
+		{
+			name: "error rate out of range",
+			cfg:  Config{MaxErrorRate: 1.5},
+			err:  "`max-error-rate` must be between 0 and 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir)
+
+	tlsCfg, err := (&Config{Insecure: true, CaFile: certFile}).TLSConfig()
+	require.NoError(t, err)
+	assert.Nil(t, tlsCfg)
+
+	tlsCfg, err = (&Config{
+		CaFile:         certFile,
+		ClientCertFile: certFile,
+		ClientKeyFile:  keyFile,
+		ServerName:     "gateway.example.com",
+	}).TLSConfig()
+	require.NoError(t, err)
+	assert.NotNil(t, tlsCfg.RootCAs)
+	assert.Len(t, tlsCfg.Certificates, 1)
+	assert.Equal(t, "gateway.example.com", tlsCfg.ServerName)
+
+	_, err = (&Config{CaFile: filepath.Join(dir, "missing.pem")}).TLSConfig()
+	assert.ErrorContains(t, err, "failed to read CA certificate")
+
+	_, err = (&Config{CaFile: keyFile}).TLSConfig()
+	assert.ErrorContains(t, err, "failed to parse CA certificate")
+
+	_, err = (&Config{ClientCertFile: certFile, ClientKeyFile: certFile}).TLSConfig()
+	assert.ErrorContains(t, err, "failed to load client certificate")
+}
+
+// writeCertificate writes a self-signed certificate and its key to dir.
+func writeCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "telemetrygen"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxLatencySamples bounds the memory used to compute the export latency percentiles.
+const maxLatencySamples = 10000
+
+// Stats records the outcome of the export requests made during a test.
+type Stats struct {
+	mu        sync.Mutex
+	start     time.Time
+	sent      int64
+	failed    int64
+	requests  int64
+	latencies []time.Duration
+	rand      *rand.Rand
+}
+
+// NewStats returns stats whose rate is computed from now on.
+func NewStats() *Stats {
+	return &Stats{
+		start: time.Now(),
+		rand:  rand.New(rand.NewSource(time.Now().UnixNano())), // nolint:gosec
+	}
+}
+
+// Record records an export request of the given number of items.
+func (s *Stats) Record(items int, latency time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.failed += int64(items)
+	} else {
+		s.sent += int64(items)
+	}
+
+	// reservoir sampling keeps the percentiles accurate without storing every latency
+	s.requests++
+	if len(s.latencies) < maxLatencySamples {
+		s.latencies = append(s.latencies, latency)
+	} else if i := s.rand.Int63n(s.requests); i < maxLatencySamples {
+		s.latencies[i] = latency
+	}
+}
+
+// RecordExport calls export with the data of a request and records its outcome
+// in the stats, count giving the number of items of the request.
+func RecordExport[T any](s *Stats, data T, count func(T) int, export func(T) error) error {
+	start := time.Now()
+	err := export(data)
+	s.Record(count(data), time.Since(start), err)
+	return err
+}
+
+// Summary describes the outcome of a test.
+type Summary struct {
+	Sent     int64
+	Failed   int64
+	Duration time.Duration
+	// Rate is the number of items successfully sent per second.
+	Rate float64
+	P50  time.Duration
+	P90  time.Duration
+	P99  time.Duration
+}
+
+// ErrorRate returns the ratio of items that failed to be exported.
+func (s Summary) ErrorRate() float64 {
+	if s.Sent+s.Failed == 0 {
+		return 0
+	}
+	return float64(s.Failed) / float64(s.Sent+s.Failed)
+}
+
+// Summary returns the outcome of the export requests recorded so far.
+func (s *Stats) Summary() Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sum := Summary{
+		Sent:     s.sent,
+		Failed:   s.failed,
+		Duration: time.Since(s.start),
+	}
+	if sum.Duration > 0 {
+		sum.Rate = float64(s.sent) / sum.Duration.Seconds()
+	}
+	if len(s.latencies) > 0 {
+		latencies := make([]time.Duration, len(s.latencies))
+		copy(latencies, s.latencies)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		sum.P50 = percentile(latencies, 0.5)
+		sum.P90 = percentile(latencies, 0.9)
+		sum.P99 = percentile(latencies, 0.99)
+	}
+	return sum
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	idx := int(math.Ceil(float64(len(sorted))*p)) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// Report logs the summary of the test, returning an error when the ratio of
+// failed items exceeds the configured maximum error rate.
+func (c *Config) Report(logger *zap.Logger, signal string, s Summary) error {
+	logger.Info("test summary",
+		zap.String("signal", signal),
+		zap.Int64("sent", s.Sent),
+		zap.Int64("failed", s.Failed),
+		zap.Duration("duration", s.Duration),
+		zap.Float64("per-second", s.Rate),
+		zap.Duration("export-latency-p50", s.P50),
+		zap.Duration("export-latency-p90", s.P90),
+		zap.Duration("export-latency-p99", s.P99),
+	)
+	if s.ErrorRate() > c.MaxErrorRate {
+		return fmt.Errorf("%.2f%% of the %s failed to be exported, above the maximum error rate of %.2f%%", 100*s.ErrorRate(), signal, 100*c.MaxErrorRate)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestStatsSummary(t *testing.T) {
+	stats := NewStats()
+	for i := 1; i <= 100; i++ {
+		stats.Record(10, time.Duration(i)*time.Millisecond, nil)
+	}
+	stats.Record(5, time.Second, errors.New("unavailable"))
+
+	summary := stats.Summary()
+	assert.EqualValues(t, 1000, summary.Sent)
+	assert.EqualValues(t, 5, summary.Failed)
+	assert.Greater(t, summary.Rate, 0.0)
+	assert.Equal(t, 51*time.Millisecond, summary.P50)
+	assert.Equal(t, 91*time.Millisecond, summary.P90)
+	assert.Equal(t, 100*time.Millisecond, summary.P99)
+	assert.InDelta(t, 5.0/1005, summary.ErrorRate(), 1e-9)
+}
+
+func TestStatsBoundsLatencySamples(t *testing.T) {
+	stats := NewStats()
+	for i := 0; i < 2*maxLatencySamples; i++ {
+		stats.Record(1, time.Millisecond, nil)
+	}
+	assert.Len(t, stats.latencies, maxLatencySamples)
+	assert.EqualValues(t, 2*maxLatencySamples, stats.Summary().Sent)
+}
+
+func TestRecordExport(t *testing.T) {
+	stats := NewStats()
+	count := func(items []string) int { return len(items) }
+
+	assert.NoError(t, RecordExport(stats, []string{"a", "b"}, count, func([]string) error { return nil }))
+	assert.EqualError(t, RecordExport(stats, []string{"c"}, count, func([]string) error { return errors.New("unavailable") }), "unavailable")
+
+	summary := stats.Summary()
+	assert.EqualValues(t, 2, summary.Sent)
+	assert.EqualValues(t, 1, summary.Failed)
+}
+
+func TestReport(t *testing.T) {
+	summary := Summary{Sent: 90, Failed: 10}
+
+	cfg := &Config{MaxErrorRate: 1}
+	assert.NoError(t, cfg.Report(zap.NewNop(), "spans", summary))
+
+	cfg.MaxErrorRate = 0.2
+	assert.NoError(t, cfg.Report(zap.NewNop(), "spans", summary))
+
+	cfg.MaxErrorRate = 0.05
+	assert.EqualError(t, cfg.Report(zap.NewNop(), "spans", summary), "10.00% of the spans failed to be exported, above the maximum error rate of 5.00%")
+
+	cfg.MaxErrorRate = 0
+	assert.NoError(t, cfg.Report(zap.NewNop(), "spans", Summary{}))
+}
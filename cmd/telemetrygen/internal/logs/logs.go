@@ -28,9 +28,12 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
 )
 
 type exporter interface {
@@ -38,17 +41,29 @@ type exporter interface {
 }
 
 type gRPCClientExporter struct {
-	client plogotlp.GRPCClient
+	client  plogotlp.GRPCClient
+	headers metadata.MD
 }
 
 func (e *gRPCClientExporter) export(logs plog.Logs) error {
+	ctx := metadata.NewOutgoingContext(context.Background(), e.headers)
 	req := plogotlp.NewExportRequestFromLogs(logs)
-	if _, err := e.client.Export(context.Background(), req); err != nil {
+	if _, err := e.client.Export(ctx, req); err != nil {
 		return err
 	}
 	return nil
 }
 
+// recordingExporter records the outcome of each export in the stats of the test.
+type recordingExporter struct {
+	exporter
+	stats *common.Stats
+}
+
+func (e *recordingExporter) export(logs plog.Logs) error {
+	return common.RecordExport(e.stats, logs, plog.Logs.LogRecordCount, e.exporter.export)
+}
+
 // Start starts the log telemetry generator
 func Start(cfg *Config) error {
 	logger, err := common.CreateLogger()
@@ -60,29 +75,71 @@ func Start(cfg *Config) error {
 		return fmt.Errorf("http is not supported by 'telemetrygen logs'")
 	}
 
-	if !cfg.Insecure {
-		return fmt.Errorf("'telemetrygen logs' only supports insecure gRPC")
+	if err = cfg.Validate(); err != nil {
+		return err
+	}
+	tlsCfg, err := cfg.TLSConfig()
+	if err != nil {
+		return err
+	}
+
+	var s *scenario.Scenario
+	if cfg.ScenarioFile != "" {
+		if s, err = scenario.Load(cfg.ScenarioFile); err != nil {
+			return err
+		}
+		if s.Logs == nil {
+			return fmt.Errorf("scenario file %q has no logs section", cfg.ScenarioFile)
+		}
 	}
 
-	// only support grpc in insecure mode
-	clientConn, err := grpc.DialContext(context.TODO(), cfg.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds := insecure.NewCredentials()
+	if tlsCfg != nil {
+		creds = credentials.NewTLS(tlsCfg)
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if compressor := cfg.GRPCCompressor(); compressor != "" {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(compressor)))
+	}
+	clientConn, err := grpc.DialContext(context.TODO(), cfg.Endpoint, opts...)
 	if err != nil {
 		return err
 	}
-	exporter := &gRPCClientExporter{
-		client: plogotlp.NewGRPCClient(clientConn),
+	stats := common.NewStats()
+	exporter := &recordingExporter{
+		exporter: &gRPCClientExporter{
+			client:  plogotlp.NewGRPCClient(clientConn),
+			headers: metadata.New(cfg.Headers),
+		},
+		stats: stats,
 	}
 
-	if err = Run(cfg, exporter, logger); err != nil {
+	if s != nil {
+		err = RunScenario(cfg, s, exporter, logger)
+	} else {
+		err = Run(cfg, exporter, logger)
+	}
+	if err != nil {
 		logger.Error("failed to stop the exporter", zap.Error(err))
 		return err
 	}
 
-	return nil
+	return cfg.Report(logger, "logs", stats.Summary())
 }
 
 // Run executes the test scenario.
 func Run(c *Config, exp exporter, logger *zap.Logger) error {
+	return run(c, exp, nil, logger)
+}
+
+// RunScenario executes the logs section of a scenario file.
+func RunScenario(c *Config, s *scenario.Scenario, exp exporter, logger *zap.Logger) error {
+	return run(c, exp, func(i int) *scenarioGenerator {
+		return newScenarioGenerator(s.Logs, s.NewRand(i))
+	}, logger)
+}
+
+func run(c *Config, exp exporter, newGenerator func(int) *scenarioGenerator, logger *zap.Logger) error {
 	if c.TotalDuration > 0 {
 		c.NumLogs = 0
 	} else if c.NumLogs <= 0 {
@@ -114,6 +171,9 @@ func Run(c *Config, exp exporter, logger *zap.Logger) error {
 			logger:         logger.With(zap.Int("worker", i)),
 			index:          i,
 		}
+		if newGenerator != nil {
+			w.scenario = newGenerator(i)
+		}
 
 		go w.simulateLogs(res, exp)
 	}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
+
+import (
+	"math/rand"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
+)
+
+// scenarioGenerator builds log records following a scenario file.
+type scenarioGenerator struct {
+	logs    *scenario.Logs
+	weights []int
+	rand    *rand.Rand
+}
+
+func newScenarioGenerator(logs *scenario.Logs, r *rand.Rand) *scenarioGenerator {
+	weights := make([]int, len(logs.Records))
+	for i, rec := range logs.Records {
+		weights[i] = rec.Weight
+	}
+	return &scenarioGenerator{
+		logs:    logs,
+		weights: weights,
+		rand:    r,
+	}
+}
+
+// generate builds a log record from one of the records of the scenario.
+func (g *scenarioGenerator) generate(res *resource.Resource) plog.Logs {
+	rec := g.logs.Records[scenario.PickWeighted(g.rand, g.weights)]
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	for _, attr := range res.Attributes() {
+		rl.Resource().Attributes().PutStr(string(attr.Key), attr.Value.AsString())
+	}
+	log := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	log.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	log.Body().SetStr(rec.Body)
+	log.SetSeverityNumber(rec.SeverityNumber())
+	log.SetSeverityText(rec.Severity)
+	for _, attr := range rec.Attributes {
+		log.Attributes().PutStr(attr.Key, attr.Value(g.rand.Intn(attr.Len())))
+	}
+	return logs
+}
@@ -28,13 +28,14 @@ import (
 )
 
 type worker struct {
-	running        *atomic.Bool    // pointer to shared flag that indicates it's time to stop the test
-	numLogs        int             // how many logs the worker has to generate (only when duration==0)
-	totalDuration  time.Duration   // how long to run the test for (overrides `numLogs`)
-	limitPerSecond rate.Limit      // how many logs per second to generate
-	wg             *sync.WaitGroup // notify when done
-	logger         *zap.Logger     // logger
-	index          int             // worker index
+	running        *atomic.Bool       // pointer to shared flag that indicates it's time to stop the test
+	numLogs        int                // how many logs the worker has to generate (only when duration==0)
+	totalDuration  time.Duration      // how long to run the test for (overrides `numLogs`)
+	limitPerSecond rate.Limit         // how many logs per second to generate
+	wg             *sync.WaitGroup    // notify when done
+	logger         *zap.Logger        // logger
+	index          int                // worker index
+	scenario       *scenarioGenerator // generates the logs of a scenario file instead of the built-in record
 }
 
 func (w worker) simulateLogs(res *resource.Resource, exporter exporter) {
@@ -42,22 +43,27 @@ func (w worker) simulateLogs(res *resource.Resource, exporter exporter) {
 	var i int64
 
 	for w.running.Load() {
-		logs := plog.NewLogs()
-		nRes := logs.ResourceLogs().AppendEmpty().Resource()
-		attrs := res.Attributes()
-		for _, attr := range attrs {
-			nRes.Attributes().PutStr(string(attr.Key), attr.Value.AsString())
+		var logs plog.Logs
+		if w.scenario != nil {
+			logs = w.scenario.generate(res)
+		} else {
+			logs = plog.NewLogs()
+			nRes := logs.ResourceLogs().AppendEmpty().Resource()
+			attrs := res.Attributes()
+			for _, attr := range attrs {
+				nRes.Attributes().PutStr(string(attr.Key), attr.Value.AsString())
+			}
+			log := logs.ResourceLogs().At(0).ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			log.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+			log.SetDroppedAttributesCount(1)
+			log.SetSeverityNumber(plog.SeverityNumberInfo)
+			log.SetSeverityText("Info")
+			lattrs := log.Attributes()
+			lattrs.PutStr("app", "server")
 		}
-		log := logs.ResourceLogs().At(0).ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
-		log.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
-		log.SetDroppedAttributesCount(1)
-		log.SetSeverityNumber(plog.SeverityNumberInfo)
-		log.SetSeverityText("Info")
-		lattrs := log.Attributes()
-		lattrs.PutStr("app", "server")
 
 		if err := exporter.export(logs); err != nil {
-			w.logger.Fatal("exporter failed", zap.Error(err))
+			w.logger.Error("exporter failed", zap.Error(err))
 		}
 		if err := limiter.Wait(context.Background()); err != nil {
 			w.logger.Fatal("limiter wait failed, retry", zap.Error(err))
@@ -24,6 +24,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
 )
 
 type mockExporter struct {
@@ -90,3 +91,44 @@ func TestUnthrottled(t *testing.T) {
 
 	assert.True(t, len(exp.logs) > 100, "there should have been more than 100 logs, had %d", len(exp.logs))
 }
+
+func TestScenario(t *testing.T) {
+	s := &scenario.Scenario{
+		Seed: 1,
+		Logs: &scenario.Logs{
+			Records: []scenario.Record{
+				{Body: "never picked", Severity: "DEBUG"},
+				{
+					Body:       "payment declined",
+					Severity:   "ERROR",
+					Weight:     1,
+					Attributes: []scenario.Attribute{{Key: "customer.id", Cardinality: 10}},
+				},
+			},
+		},
+	}
+	require.NoError(t, s.Validate())
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		NumLogs: 3,
+	}
+	exp := &mockExporter{}
+
+	// test
+	require.NoError(t, RunScenario(cfg, s, exp, zap.NewNop()))
+
+	// verify
+	require.Len(t, exp.logs, 3)
+	for _, logs := range exp.logs {
+		log := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+		assert.Equal(t, "payment declined", log.Body().Str())
+		assert.Equal(t, plog.SeverityNumberError, log.SeverityNumber())
+		assert.Equal(t, "ERROR", log.SeverityText())
+		customer, ok := log.Attributes().Get("customer.id")
+		require.True(t, ok)
+		assert.Contains(t, customer.Str(), "customer.id-")
+	}
+}
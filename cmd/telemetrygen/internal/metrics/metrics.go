@@ -25,12 +25,15 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
 )
 
 // Start starts the metric telemetry generator
@@ -40,6 +43,34 @@ func Start(cfg *Config) error {
 		return err
 	}
 
+	if err = cfg.Validate(); err != nil {
+		return err
+	}
+	tlsCfg, err := cfg.TLSConfig()
+	if err != nil {
+		return err
+	}
+
+	if cfg.ScenarioFile != "" {
+		s, err := scenario.Load(cfg.ScenarioFile)
+		if err != nil {
+			return err
+		}
+		if s.Metrics == nil {
+			return fmt.Errorf("scenario file %q has no metrics section", cfg.ScenarioFile)
+		}
+		exp, err := newExporter(cfg, tlsCfg)
+		if err != nil {
+			return fmt.Errorf("failed to obtain OTLP exporter: %w", err)
+		}
+		stats := common.NewStats()
+		if err = RunScenario(cfg, s, &recordingExporter{exporter: exp, stats: stats}, logger); err != nil {
+			logger.Error("failed to execute the test scenario.", zap.Error(err))
+			return err
+		}
+		return cfg.Report(logger, "data points", stats.Summary())
+	}
+
 	grpcExpOpt := []otlpmetricgrpc.Option{
 		otlpmetricgrpc.WithEndpoint(cfg.Endpoint),
 		otlpmetricgrpc.WithDialOption(
@@ -54,6 +85,9 @@ func Start(cfg *Config) error {
 	if cfg.Insecure {
 		grpcExpOpt = append(grpcExpOpt, otlpmetricgrpc.WithInsecure())
 		httpExpOpt = append(httpExpOpt, otlpmetrichttp.WithInsecure())
+	} else {
+		grpcExpOpt = append(grpcExpOpt, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		httpExpOpt = append(httpExpOpt, otlpmetrichttp.WithTLSClientConfig(tlsCfg))
 	}
 
 	if len(cfg.Headers) > 0 {
@@ -61,6 +95,13 @@ func Start(cfg *Config) error {
 		httpExpOpt = append(httpExpOpt, otlpmetrichttp.WithHeaders(cfg.Headers))
 	}
 
+	if compressor := cfg.GRPCCompressor(); compressor != "" {
+		grpcExpOpt = append(grpcExpOpt, otlpmetricgrpc.WithCompressor(compressor))
+	}
+	if cfg.Compression == common.CompressionGzip {
+		httpExpOpt = append(httpExpOpt, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	}
+
 	var exp sdkmetric.Exporter
 	if cfg.UseHTTP {
 		logger.Info("starting HTTP exporter")
@@ -80,16 +121,62 @@ func Start(cfg *Config) error {
 		}
 	}()
 
-	if err = Run(cfg, exp, logger); err != nil {
+	stats := common.NewStats()
+	if err = Run(cfg, &recordingSDKExporter{Exporter: exp, stats: stats}, logger); err != nil {
 		logger.Error("failed to stop the exporter", zap.Error(err))
 		return err
 	}
 
-	return nil
+	return cfg.Report(logger, "data points", stats.Summary())
+}
+
+// recordingSDKExporter records the outcome of each export in the stats of the test.
+type recordingSDKExporter struct {
+	sdkmetric.Exporter
+	stats *common.Stats
+}
+
+func (e *recordingSDKExporter) Export(ctx context.Context, rm metricdata.ResourceMetrics) error {
+	start := time.Now()
+	err := e.Exporter.Export(ctx, rm)
+	e.stats.Record(dataPointCount(rm), time.Since(start), err)
+	return err
+}
+
+func dataPointCount(rm metricdata.ResourceMetrics) int {
+	count := 0
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Gauge[int64]:
+				count += len(data.DataPoints)
+			case metricdata.Gauge[float64]:
+				count += len(data.DataPoints)
+			case metricdata.Sum[int64]:
+				count += len(data.DataPoints)
+			case metricdata.Sum[float64]:
+				count += len(data.DataPoints)
+			case metricdata.Histogram:
+				count += len(data.DataPoints)
+			}
+		}
+	}
+	return count
 }
 
 // Run executes the test scenario.
 func Run(c *Config, exp sdkmetric.Exporter, logger *zap.Logger) error {
+	return run(c, exp, nil, logger)
+}
+
+// RunScenario executes the metrics section of a scenario file.
+func RunScenario(c *Config, s *scenario.Scenario, exp exporter, logger *zap.Logger) error {
+	return run(c, nil, func(i int) *scenarioGenerator {
+		return newScenarioGenerator(s.Metrics, c.GetAttributes(), exp, s.NewRand(i))
+	}, logger)
+}
+
+func run(c *Config, exp sdkmetric.Exporter, newGenerator func(int) *scenarioGenerator, logger *zap.Logger) error {
 	if c.TotalDuration > 0 {
 		c.NumMetrics = 0
 	} else if c.NumMetrics <= 0 {
@@ -122,6 +209,10 @@ func Run(c *Config, exp sdkmetric.Exporter, logger *zap.Logger) error {
 			index:          i,
 		}
 
+		if newGenerator != nil {
+			w.scenario = newGenerator(i)
+		}
+
 		go w.simulateMetrics(res, exp)
 	}
 	if c.TotalDuration > 0 {
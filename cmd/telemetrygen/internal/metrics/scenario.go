@@ -0,0 +1,356 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
+)
+
+const defaultSamples = 10
+
+// defaultBounds are the default explicit bucket boundaries of the SDK.
+var defaultBounds = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// exporter sends metrics built with pdata, which, unlike the SDK, can
+// express every metric type of a scenario file.
+type exporter interface {
+	export(pmetric.Metrics) error
+}
+
+type gRPCClientExporter struct {
+	client  pmetricotlp.GRPCClient
+	headers metadata.MD
+}
+
+func (e *gRPCClientExporter) export(metrics pmetric.Metrics) error {
+	ctx := metadata.NewOutgoingContext(context.Background(), e.headers)
+	req := pmetricotlp.NewExportRequestFromMetrics(metrics)
+	if _, err := e.client.Export(ctx, req); err != nil {
+		return err
+	}
+	return nil
+}
+
+type httpClientExporter struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+	gzip    bool
+}
+
+func (e *httpClientExporter) export(metrics pmetric.Metrics) error {
+	body, err := pmetricotlp.NewExportRequestFromMetrics(metrics).MarshalProto()
+	if err != nil {
+		return err
+	}
+	if e.gzip {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		if _, err = gw.Write(body); err != nil {
+			return err
+		}
+		if err = gw.Close(); err != nil {
+			return err
+		}
+		body = buf.Bytes()
+	}
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	if e.gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("export request failed with status %q", resp.Status)
+	}
+	return nil
+}
+
+func newExporter(cfg *Config, tlsCfg *tls.Config) (exporter, error) {
+	if cfg.UseHTTP {
+		scheme := "https"
+		if cfg.Insecure {
+			scheme = "http"
+		}
+		url := scheme + "://" + cfg.Endpoint
+		if !strings.Contains(cfg.Endpoint, "/") {
+			url += "/v1/metrics"
+		}
+		return &httpClientExporter{
+			client:  &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}},
+			url:     url,
+			headers: cfg.Headers,
+			gzip:    cfg.Compression == common.CompressionGzip,
+		}, nil
+	}
+
+	creds := insecure.NewCredentials()
+	if tlsCfg != nil {
+		creds = credentials.NewTLS(tlsCfg)
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if compressor := cfg.GRPCCompressor(); compressor != "" {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(compressor)))
+	}
+	clientConn, err := grpc.DialContext(context.Background(), cfg.Endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &gRPCClientExporter{
+		client:  pmetricotlp.NewGRPCClient(clientConn),
+		headers: metadata.New(cfg.Headers),
+	}, nil
+}
+
+// recordingExporter records the outcome of each export in the stats of the test.
+type recordingExporter struct {
+	exporter
+	stats *common.Stats
+}
+
+func (e *recordingExporter) export(metrics pmetric.Metrics) error {
+	return common.RecordExport(e.stats, metrics, pmetric.Metrics.DataPointCount, e.exporter.export)
+}
+
+// scenarioGenerator builds metrics following a scenario file. Each metric
+// gets one data point per combination of its attribute values, covering its
+// whole cardinality on every iteration. Sums and histograms are reported with
+// delta temporality, since the previous iteration.
+type scenarioGenerator struct {
+	metrics    *scenario.Metrics
+	resource   []attribute.KeyValue
+	exporter   exporter
+	rand       *rand.Rand
+	lastExport time.Time
+}
+
+func newScenarioGenerator(metrics *scenario.Metrics, res []attribute.KeyValue, exp exporter, r *rand.Rand) *scenarioGenerator {
+	return &scenarioGenerator{
+		metrics:    metrics,
+		resource:   res,
+		exporter:   exp,
+		rand:       r,
+		lastExport: time.Now(),
+	}
+}
+
+// generate builds and exports one batch of metrics.
+func (g *scenarioGenerator) generate() error {
+	now := time.Now()
+	md := g.build(pcommon.NewTimestampFromTime(g.lastExport), pcommon.NewTimestampFromTime(now))
+	g.lastExport = now
+	return g.exporter.export(md)
+}
+
+func (g *scenarioGenerator) build(start, ts pcommon.Timestamp) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	for _, attr := range g.resource {
+		rm.Resource().Attributes().PutStr(string(attr.Key), attr.Value.AsString())
+	}
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("telemetrygen")
+
+	for _, def := range g.metrics.Metrics {
+		m := sm.Metrics().AppendEmpty()
+		m.SetName(def.Name)
+		m.SetDescription(def.Description)
+		m.SetUnit(def.Unit)
+
+		series := 1
+		for _, attr := range def.Attributes {
+			series *= attr.Len()
+		}
+		for i := 0; i < series; i++ {
+			var attrs pcommon.Map
+			switch def.Type {
+			case scenario.MetricTypeGauge:
+				if i == 0 {
+					m.SetEmptyGauge()
+				}
+				dp := m.Gauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetDoubleValue(def.Value.Sample(g.rand))
+				attrs = dp.Attributes()
+			case scenario.MetricTypeSum:
+				if i == 0 {
+					m.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+					m.Sum().SetIsMonotonic(def.Monotonic)
+				}
+				dp := m.Sum().DataPoints().AppendEmpty()
+				dp.SetStartTimestamp(start)
+				dp.SetTimestamp(ts)
+				v := def.Value.Sample(g.rand)
+				if def.Monotonic {
+					v = math.Max(v, 0)
+				}
+				dp.SetDoubleValue(v)
+				attrs = dp.Attributes()
+			case scenario.MetricTypeHistogram:
+				if i == 0 {
+					m.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+				}
+				dp := m.Histogram().DataPoints().AppendEmpty()
+				dp.SetStartTimestamp(start)
+				dp.SetTimestamp(ts)
+				g.fillHistogram(dp, def)
+				attrs = dp.Attributes()
+			case scenario.MetricTypeExponentialHistogram:
+				if i == 0 {
+					m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+				}
+				dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+				dp.SetStartTimestamp(start)
+				dp.SetTimestamp(ts)
+				g.fillExponentialHistogram(dp, def)
+				attrs = dp.Attributes()
+			}
+			putSeriesAttributes(attrs, def.Attributes, i)
+		}
+	}
+	return md
+}
+
+// putSeriesAttributes sets the attribute values of the i-th combination of values.
+func putSeriesAttributes(dest pcommon.Map, attrs []scenario.Attribute, i int) {
+	for _, attr := range attrs {
+		dest.PutStr(attr.Key, attr.Value(i%attr.Len()))
+		i /= attr.Len()
+	}
+}
+
+func (g *scenarioGenerator) samples(def scenario.Metric) []float64 {
+	n := def.Samples
+	if n == 0 {
+		n = defaultSamples
+	}
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = def.Value.Sample(g.rand)
+	}
+	return values
+}
+
+func (g *scenarioGenerator) fillHistogram(dp pmetric.HistogramDataPoint, def scenario.Metric) {
+	bounds := def.Bounds
+	if len(bounds) == 0 {
+		bounds = defaultBounds
+	}
+	counts := make([]uint64, len(bounds)+1)
+	values := g.samples(def)
+	sum, min, max := 0.0, math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		idx := 0
+		for idx < len(bounds) && v > bounds[idx] {
+			idx++
+		}
+		counts[idx]++
+		sum += v
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(counts)
+	dp.SetCount(uint64(len(values)))
+	dp.SetSum(sum)
+	dp.SetMin(min)
+	dp.SetMax(max)
+}
+
+func (g *scenarioGenerator) fillExponentialHistogram(dp pmetric.ExponentialHistogramDataPoint, def scenario.Metric) {
+	positive, negative := map[int32]uint64{}, map[int32]uint64{}
+	values := g.samples(def)
+	sum, min, max := 0.0, math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		switch {
+		case v > 0:
+			positive[exponentialIndex(v, def.Scale)]++
+		case v < 0:
+			negative[exponentialIndex(-v, def.Scale)]++
+		default:
+			dp.SetZeroCount(dp.ZeroCount() + 1)
+		}
+		sum += v
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	dp.SetScale(def.Scale)
+	setExponentialBuckets(dp.Positive(), positive)
+	setExponentialBuckets(dp.Negative(), negative)
+	dp.SetCount(uint64(len(values)))
+	dp.SetSum(sum)
+	dp.SetMin(min)
+	dp.SetMax(max)
+}
+
+// exponentialIndex returns the index of the bucket holding the positive value v,
+// buckets being upper-inclusive.
+func exponentialIndex(v float64, scale int32) int32 {
+	return int32(math.Ceil(math.Log2(v)*math.Ldexp(1, int(scale)))) - 1
+}
+
+func setExponentialBuckets(dest pmetric.ExponentialHistogramDataPointBuckets, counts map[int32]uint64) {
+	if len(counts) == 0 {
+		return
+	}
+	lowest, highest := int32(math.MaxInt32), int32(math.MinInt32)
+	for idx := range counts {
+		if idx < lowest {
+			lowest = idx
+		}
+		if idx > highest {
+			highest = idx
+		}
+	}
+	buckets := make([]uint64, highest-lowest+1)
+	for idx, c := range counts {
+		buckets[idx-lowest] = c
+	}
+	dest.SetOffset(lowest)
+	dest.BucketCounts().FromRaw(buckets)
+}
@@ -28,13 +28,14 @@ import (
 )
 
 type worker struct {
-	running        *atomic.Bool    // pointer to shared flag that indicates it's time to stop the test
-	numMetrics     int             // how many metrics the worker has to generate (only when duration==0)
-	totalDuration  time.Duration   // how long to run the test for (overrides `numMetrics`)
-	limitPerSecond rate.Limit      // how many metrics per second to generate
-	wg             *sync.WaitGroup // notify when done
-	logger         *zap.Logger     // logger
-	index          int             // worker index
+	running        *atomic.Bool       // pointer to shared flag that indicates it's time to stop the test
+	numMetrics     int                // how many metrics the worker has to generate (only when duration==0)
+	totalDuration  time.Duration      // how long to run the test for (overrides `numMetrics`)
+	limitPerSecond rate.Limit         // how many metrics per second to generate
+	wg             *sync.WaitGroup    // notify when done
+	logger         *zap.Logger        // logger
+	index          int                // worker index
+	scenario       *scenarioGenerator // generates the metrics of a scenario file instead of the built-in gauge
 }
 
 func (w worker) simulateMetrics(res *resource.Resource, exporter sdkmetric.Exporter) {
@@ -42,6 +43,20 @@ func (w worker) simulateMetrics(res *resource.Resource, exporter sdkmetric.Expor
 	var i int64
 
 	for w.running.Load() {
+		if w.scenario != nil {
+			if err := w.scenario.generate(); err != nil {
+				w.logger.Error("exporter failed", zap.Error(err))
+			}
+			if err := limiter.Wait(context.Background()); err != nil {
+				w.logger.Fatal("limiter wait failed, retry", zap.Error(err))
+			}
+			i++
+			if w.numMetrics != 0 && i >= int64(w.numMetrics) {
+				break
+			}
+			continue
+		}
+
 		rm := metricdata.ResourceMetrics{
 			Resource: res,
 			ScopeMetrics: []metricdata.ScopeMetrics{
@@ -63,7 +78,7 @@ func (w worker) simulateMetrics(res *resource.Resource, exporter sdkmetric.Expor
 			},
 		}
 		if err := exporter.Export(context.Background(), rm); err != nil {
-			w.logger.Fatal("exporter failed", zap.Error(err))
+			w.logger.Error("exporter failed", zap.Error(err))
 		}
 		if err := limiter.Wait(context.Background()); err != nil {
 			w.logger.Fatal("limiter wait failed, retry", zap.Error(err))
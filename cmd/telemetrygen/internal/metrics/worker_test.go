@@ -15,18 +15,26 @@
 package metrics
 
 import (
+	"compress/gzip"
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
 )
 
 type mockExporter struct {
@@ -54,6 +62,15 @@ func (m *mockExporter) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+type mockPdataExporter struct {
+	metrics []pmetric.Metrics
+}
+
+func (m *mockPdataExporter) export(metrics pmetric.Metrics) error {
+	m.metrics = append(m.metrics, metrics)
+	return nil
+}
+
 func TestFixedNumberOfMetrics(t *testing.T) {
 	cfg := &Config{
 		Config: common.Config{
@@ -109,3 +126,120 @@ func TestUnthrottled(t *testing.T) {
 
 	assert.True(t, len(exp.rms) > 100, "there should have been more than 100 metrics, had %d", len(exp.rms))
 }
+
+func TestScenario(t *testing.T) {
+	s := &scenario.Scenario{
+		Seed: 1,
+		Metrics: &scenario.Metrics{
+			Metrics: []scenario.Metric{
+				{
+					Name:      "requests",
+					Type:      scenario.MetricTypeSum,
+					Monotonic: true,
+					Value:     scenario.Distribution{Value: 3},
+					Attributes: []scenario.Attribute{
+						{Key: "route", Values: []string{"/a", "/b"}},
+						{Key: "pod", Cardinality: 3},
+					},
+				},
+				{
+					Name:    "duration",
+					Type:    scenario.MetricTypeHistogram,
+					Value:   scenario.Distribution{Value: 42},
+					Samples: 4,
+					Bounds:  []float64{10, 50},
+				},
+				{
+					Name:    "latency",
+					Type:    scenario.MetricTypeExponentialHistogram,
+					Value:   scenario.Distribution{Value: 4},
+					Samples: 2,
+					Scale:   1,
+				},
+			},
+		},
+	}
+	require.NoError(t, s.Validate())
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:        1,
+			ResourceAttributes: common.KeyValue{"service.name": "load"},
+		},
+		NumMetrics: 2,
+	}
+	exp := &mockPdataExporter{}
+
+	// test
+	require.NoError(t, RunScenario(cfg, s, exp, zap.NewNop()))
+
+	// verify
+	require.Len(t, exp.metrics, 2)
+	rm := exp.metrics[0].ResourceMetrics().At(0)
+	name, _ := rm.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "load", name.Str())
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, metrics.Len())
+
+	sum := metrics.At(0).Sum()
+	assert.True(t, sum.IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, sum.AggregationTemporality())
+	require.Equal(t, 6, sum.DataPoints().Len(), "one data point per attribute combination")
+	series := map[string]bool{}
+	for i := 0; i < sum.DataPoints().Len(); i++ {
+		dp := sum.DataPoints().At(i)
+		assert.Equal(t, 3.0, dp.DoubleValue())
+		route, _ := dp.Attributes().Get("route")
+		pod, _ := dp.Attributes().Get("pod")
+		series[route.Str()+" "+pod.Str()] = true
+	}
+	assert.Len(t, series, 6)
+
+	histogram := metrics.At(1).Histogram().DataPoints().At(0)
+	assert.EqualValues(t, 4, histogram.Count())
+	assert.Equal(t, 168.0, histogram.Sum())
+	assert.Equal(t, []float64{10, 50}, histogram.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{0, 4, 0}, histogram.BucketCounts().AsRaw())
+
+	exponential := metrics.At(2).ExponentialHistogram().DataPoints().At(0)
+	assert.EqualValues(t, 2, exponential.Count())
+	assert.EqualValues(t, 1, exponential.Scale())
+	// with a scale of 1, 4 falls into the bucket (2^1.5, 2^2]
+	assert.EqualValues(t, 3, exponential.Positive().Offset())
+	assert.Equal(t, []uint64{2}, exponential.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, 0, exponential.Negative().BucketCounts().Len())
+}
+
+func TestHTTPClientExporter(t *testing.T) {
+	var received pmetric.Metrics
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		gr, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(gr)
+		require.NoError(t, err)
+		req := pmetricotlp.NewExportRequest()
+		require.NoError(t, req.UnmarshalProto(body))
+		received = req.Metrics()
+	}))
+	defer srv.Close()
+
+	cfg := &Config{
+		Config: common.Config{
+			Endpoint:    strings.TrimPrefix(srv.URL, "http://"),
+			Insecure:    true,
+			UseHTTP:     true,
+			Compression: common.CompressionGzip,
+			Headers:     common.KeyValue{"Authorization": "secret"},
+		},
+	}
+	exp, err := newExporter(cfg, nil)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("gen")
+	require.NoError(t, exp.export(md))
+	assert.Equal(t, md, received)
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenario // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+const (
+	DistributionConstant    = "constant"
+	DistributionUniform     = "uniform"
+	DistributionNormal      = "normal"
+	DistributionExponential = "exponential"
+)
+
+// Distribution describes how random values are drawn. Values drawn from a
+// normal or exponential distribution are clamped to [Min, Max] when Max is set.
+type Distribution struct {
+	// Type is one of constant, uniform, normal or exponential. It defaults to constant.
+	Type string `yaml:"type"`
+	// Value is the value of a constant distribution.
+	Value  float64 `yaml:"value"`
+	Min    float64 `yaml:"min"`
+	Max    float64 `yaml:"max"`
+	Mean   float64 `yaml:"mean"`
+	StdDev float64 `yaml:"stddev"`
+}
+
+func (d Distribution) validate() error {
+	switch d.Type {
+	case "", DistributionConstant:
+	case DistributionUniform:
+		if d.Max < d.Min {
+			return fmt.Errorf("max must be greater than or equal to min")
+		}
+	case DistributionNormal:
+		if d.StdDev < 0 {
+			return fmt.Errorf("stddev must not be negative")
+		}
+	case DistributionExponential:
+		if d.Mean <= 0 {
+			return fmt.Errorf("mean must be positive")
+		}
+	default:
+		return fmt.Errorf("unsupported distribution %q", d.Type)
+	}
+	if d.Max != 0 && d.Max < d.Min {
+		return fmt.Errorf("max must be greater than or equal to min")
+	}
+	return nil
+}
+
+// Sample draws a value from the distribution.
+func (d Distribution) Sample(r *rand.Rand) float64 {
+	var v float64
+	switch d.Type {
+	case DistributionUniform:
+		return d.Min + r.Float64()*(d.Max-d.Min)
+	case DistributionNormal:
+		v = d.Mean + r.NormFloat64()*d.StdDev
+	case DistributionExponential:
+		v = r.ExpFloat64() * d.Mean
+	default:
+		return d.Value
+	}
+	if d.Max != 0 {
+		v = math.Min(math.Max(v, d.Min), d.Max)
+	}
+	return v
+}
+
+// PickWeighted returns the index of an item picked according to the weights.
+// Items all weighing zero are picked uniformly.
+func PickWeighted(r *rand.Rand, weights []int) int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	if total == 0 {
+		return r.Intn(len(weights))
+	}
+	n := r.Intn(total)
+	for i, w := range weights {
+		if n < w {
+			return i
+		}
+		n -= w
+	}
+	return len(weights) - 1
+}
@@ -0,0 +1,379 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scenario loads scenario files describing the telemetry that
+// telemetrygen should simulate.
+package scenario // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	MetricTypeGauge                = "gauge"
+	MetricTypeSum                  = "sum"
+	MetricTypeHistogram            = "histogram"
+	MetricTypeExponentialHistogram = "exponential_histogram"
+)
+
+// Scenario describes the telemetry to generate. Each signal command only
+// reads its own section of the file.
+type Scenario struct {
+	// Seed makes the generated data reproducible. Each worker adds its index to it.
+	// When zero, the current time is used.
+	Seed    int64    `yaml:"seed"`
+	Traces  *Traces  `yaml:"traces"`
+	Metrics *Metrics `yaml:"metrics"`
+	Logs    *Logs    `yaml:"logs"`
+}
+
+// NewRand returns the source of randomness of the worker with the given index.
+func (s *Scenario) NewRand(worker int) *rand.Rand {
+	seed := s.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed + int64(worker))) // nolint:gosec
+}
+
+// Traces describes a topology of services calling each other.
+type Traces struct {
+	Services []Service `yaml:"services"`
+	// Entrypoints are the operations that start a trace. One is picked per trace,
+	// according to its weight.
+	Entrypoints []Entrypoint `yaml:"entrypoints"`
+}
+
+// Service is a simulated service, emitting spans under its own resource.
+type Service struct {
+	Name               string            `yaml:"name"`
+	ResourceAttributes map[string]string `yaml:"resource_attributes"`
+	Operations         []Operation       `yaml:"operations"`
+}
+
+// Operation is a span emitted by a service.
+type Operation struct {
+	Name string `yaml:"name"`
+	// Latency is the time spent in the operation itself, in milliseconds.
+	// Downstream calls are added on top of it.
+	Latency Distribution `yaml:"latency_ms"`
+	// ErrorRate is the probability, between 0 and 1, that the operation fails.
+	ErrorRate  float64     `yaml:"error_rate"`
+	Attributes []Attribute `yaml:"attributes"`
+	// Calls are performed sequentially, each one as a child of this operation.
+	Calls []Call `yaml:"calls"`
+}
+
+// Call references an operation of a service, possibly the same one.
+type Call struct {
+	Service   string `yaml:"service"`
+	Operation string `yaml:"operation"`
+}
+
+// Entrypoint is an operation starting a trace.
+type Entrypoint struct {
+	Call   `yaml:",inline"`
+	Weight int `yaml:"weight"`
+}
+
+// Metrics describes the metrics emitted on every iteration.
+type Metrics struct {
+	Metrics []Metric `yaml:"metrics"`
+}
+
+// Metric is a metric emitting one data point per combination of its attribute values.
+type Metric struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Unit        string `yaml:"unit"`
+	// Type is one of gauge, sum, histogram or exponential_histogram.
+	Type      string `yaml:"type"`
+	Monotonic bool   `yaml:"monotonic"`
+	// Value is the distribution of the values recorded by the metric.
+	Value Distribution `yaml:"value"`
+	// Samples is the number of values aggregated in each histogram data point.
+	Samples int `yaml:"samples"`
+	// Bounds are the explicit bucket boundaries of histograms.
+	Bounds []float64 `yaml:"bounds"`
+	// Scale is the scale of exponential histograms.
+	Scale      int32       `yaml:"scale"`
+	Attributes []Attribute `yaml:"attributes"`
+}
+
+// Logs describes the log records to pick from.
+type Logs struct {
+	Records []Record `yaml:"records"`
+}
+
+// Record is a log record template. One is picked per log, according to its weight.
+type Record struct {
+	Body       string      `yaml:"body"`
+	Severity   string      `yaml:"severity"`
+	Weight     int         `yaml:"weight"`
+	Attributes []Attribute `yaml:"attributes"`
+}
+
+var severities = map[string]plog.SeverityNumber{
+	"TRACE": plog.SeverityNumberTrace,
+	"DEBUG": plog.SeverityNumberDebug,
+	"INFO":  plog.SeverityNumberInfo,
+	"WARN":  plog.SeverityNumberWarn,
+	"ERROR": plog.SeverityNumberError,
+	"FATAL": plog.SeverityNumberFatal,
+}
+
+// SeverityNumber returns the severity number matching the severity text of the record.
+func (r Record) SeverityNumber() plog.SeverityNumber {
+	return severities[strings.ToUpper(r.Severity)]
+}
+
+// Attribute is an attribute whose value is picked from a set of values.
+type Attribute struct {
+	Key string `yaml:"key"`
+	// Values lists the values of the attribute.
+	Values []string `yaml:"values"`
+	// Cardinality generates that many values, named after the key, when Values is empty.
+	Cardinality int `yaml:"cardinality"`
+}
+
+// Len returns the number of values the attribute can take.
+func (a Attribute) Len() int {
+	if len(a.Values) > 0 {
+		return len(a.Values)
+	}
+	return a.Cardinality
+}
+
+// Value returns the i-th value of the attribute.
+func (a Attribute) Value(i int) string {
+	if len(a.Values) > 0 {
+		return a.Values[i]
+	}
+	return fmt.Sprintf("%s-%d", a.Key, i)
+}
+
+func (a Attribute) validate() error {
+	if a.Key == "" {
+		return errors.New("attribute key must not be empty")
+	}
+	if len(a.Values) == 0 && a.Cardinality <= 0 {
+		return fmt.Errorf("attribute %q must have values or a positive cardinality", a.Key)
+	}
+	return nil
+}
+
+// Load reads and validates the scenario file at path.
+func Load(path string) (*Scenario, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario file: %w", err)
+	}
+	s := &Scenario{}
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err = dec.Decode(s); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario file: %w", err)
+	}
+	if err = s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario file: %w", err)
+	}
+	return s, nil
+}
+
+// Validate checks the scenario is consistent.
+func (s *Scenario) Validate() error {
+	var errs error
+	if s.Traces != nil {
+		errs = multierr.Append(errs, s.Traces.validate())
+	}
+	if s.Metrics != nil {
+		errs = multierr.Append(errs, s.Metrics.validate())
+	}
+	if s.Logs != nil {
+		errs = multierr.Append(errs, s.Logs.validate())
+	}
+	return errs
+}
+
+// Operation returns the operation referenced by the call, if any.
+func (t *Traces) Operation(c Call) (*Operation, bool) {
+	for i := range t.Services {
+		if t.Services[i].Name != c.Service {
+			continue
+		}
+		for j := range t.Services[i].Operations {
+			if t.Services[i].Operations[j].Name == c.Operation {
+				return &t.Services[i].Operations[j], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Service returns the service with the given name, if any.
+func (t *Traces) Service(name string) (*Service, bool) {
+	for i := range t.Services {
+		if t.Services[i].Name == name {
+			return &t.Services[i], true
+		}
+	}
+	return nil, false
+}
+
+func (t *Traces) validate() error {
+	var errs error
+	if len(t.Services) == 0 {
+		errs = multierr.Append(errs, errors.New("traces: at least one service must be defined"))
+	}
+	if len(t.Entrypoints) == 0 {
+		errs = multierr.Append(errs, errors.New("traces: at least one entrypoint must be defined"))
+	}
+	seen := map[string]bool{}
+	for _, svc := range t.Services {
+		if svc.Name == "" {
+			errs = multierr.Append(errs, errors.New("traces: service name must not be empty"))
+			continue
+		}
+		if seen[svc.Name] {
+			errs = multierr.Append(errs, fmt.Errorf("traces: duplicate service %q", svc.Name))
+		}
+		seen[svc.Name] = true
+		for _, op := range svc.Operations {
+			if op.ErrorRate < 0 || op.ErrorRate > 1 {
+				errs = multierr.Append(errs, fmt.Errorf("traces: operation %q of service %q: error_rate must be between 0 and 1", op.Name, svc.Name))
+			}
+			if err := op.Latency.validate(); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("traces: operation %q of service %q: latency_ms: %w", op.Name, svc.Name, err))
+			}
+			for _, attr := range op.Attributes {
+				if err := attr.validate(); err != nil {
+					errs = multierr.Append(errs, fmt.Errorf("traces: operation %q of service %q: %w", op.Name, svc.Name, err))
+				}
+			}
+			for _, c := range op.Calls {
+				if _, ok := t.Operation(c); !ok {
+					errs = multierr.Append(errs, fmt.Errorf("traces: operation %q of service %q calls unknown operation %q of service %q", op.Name, svc.Name, c.Operation, c.Service))
+				}
+			}
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	visiting, done := map[Call]bool{}, map[Call]bool{}
+	for _, e := range t.Entrypoints {
+		if _, ok := t.Operation(e.Call); !ok {
+			errs = multierr.Append(errs, fmt.Errorf("traces: unknown entrypoint operation %q of service %q", e.Operation, e.Service))
+			continue
+		}
+		if e.Weight < 0 {
+			errs = multierr.Append(errs, fmt.Errorf("traces: entrypoint %q of service %q: weight must not be negative", e.Operation, e.Service))
+		}
+		if err := t.checkCycles(e.Call, visiting, done); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// checkCycles makes sure no operation ends up calling itself.
+func (t *Traces) checkCycles(c Call, visiting, done map[Call]bool) error {
+	if done[c] {
+		return nil
+	}
+	if visiting[c] {
+		return fmt.Errorf("traces: operation %q of service %q ends up calling itself", c.Operation, c.Service)
+	}
+	visiting[c] = true
+	op, _ := t.Operation(c)
+	for _, child := range op.Calls {
+		if err := t.checkCycles(child, visiting, done); err != nil {
+			return err
+		}
+	}
+	visiting[c] = false
+	done[c] = true
+	return nil
+}
+
+func (m *Metrics) validate() error {
+	var errs error
+	if len(m.Metrics) == 0 {
+		errs = multierr.Append(errs, errors.New("metrics: at least one metric must be defined"))
+	}
+	for _, metric := range m.Metrics {
+		if metric.Name == "" {
+			errs = multierr.Append(errs, errors.New("metrics: metric name must not be empty"))
+		}
+		switch metric.Type {
+		case MetricTypeGauge, MetricTypeSum:
+		case MetricTypeHistogram:
+			for i := 1; i < len(metric.Bounds); i++ {
+				if metric.Bounds[i] <= metric.Bounds[i-1] {
+					errs = multierr.Append(errs, fmt.Errorf("metrics: metric %q: bounds must be sorted in increasing order", metric.Name))
+					break
+				}
+			}
+		case MetricTypeExponentialHistogram:
+			if metric.Scale < -10 || metric.Scale > 20 {
+				errs = multierr.Append(errs, fmt.Errorf("metrics: metric %q: scale must be between -10 and 20", metric.Name))
+			}
+		default:
+			errs = multierr.Append(errs, fmt.Errorf("metrics: metric %q: unsupported type %q", metric.Name, metric.Type))
+		}
+		if metric.Samples < 0 {
+			errs = multierr.Append(errs, fmt.Errorf("metrics: metric %q: samples must not be negative", metric.Name))
+		}
+		if err := metric.Value.validate(); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("metrics: metric %q: value: %w", metric.Name, err))
+		}
+		for _, attr := range metric.Attributes {
+			if err := attr.validate(); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("metrics: metric %q: %w", metric.Name, err))
+			}
+		}
+	}
+	return errs
+}
+
+func (l *Logs) validate() error {
+	var errs error
+	if len(l.Records) == 0 {
+		errs = multierr.Append(errs, errors.New("logs: at least one record must be defined"))
+	}
+	for i, r := range l.Records {
+		if _, ok := severities[strings.ToUpper(r.Severity)]; r.Severity != "" && !ok {
+			errs = multierr.Append(errs, fmt.Errorf("logs: record %d: unknown severity %q", i, r.Severity))
+		}
+		if r.Weight < 0 {
+			errs = multierr.Append(errs, fmt.Errorf("logs: record %d: weight must not be negative", i))
+		}
+		for _, attr := range r.Attributes {
+			if err := attr.validate(); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("logs: record %d: %w", i, err))
+			}
+		}
+	}
+	return errs
+}
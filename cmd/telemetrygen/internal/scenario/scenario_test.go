@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenario
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestLoad(t *testing.T) {
+	s, err := Load(filepath.Join("testdata", "scenario.yaml"))
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 42, s.Seed)
+	require.NotNil(t, s.Traces)
+	require.Len(t, s.Traces.Services, 2)
+	op, ok := s.Traces.Operation(Call{Service: "frontend", Operation: "GET /checkout"})
+	require.True(t, ok)
+	assert.Equal(t, Distribution{Type: DistributionNormal, Mean: 20, StdDev: 5, Min: 1, Max: 100}, op.Latency)
+	assert.Equal(t, []Call{{Service: "frontend", Operation: "render"}, {Service: "checkout", Operation: "PlaceOrder"}}, op.Calls)
+	assert.Equal(t, []Entrypoint{{Call: Call{Service: "frontend", Operation: "GET /checkout"}, Weight: 1}}, s.Traces.Entrypoints)
+
+	require.NotNil(t, s.Metrics)
+	require.Len(t, s.Metrics.Metrics, 3)
+	assert.Equal(t, MetricTypeExponentialHistogram, s.Metrics.Metrics[2].Type)
+	assert.EqualValues(t, 3, s.Metrics.Metrics[2].Scale)
+
+	require.NotNil(t, s.Logs)
+	require.Len(t, s.Logs.Records, 2)
+	assert.Equal(t, plog.SeverityNumberInfo, s.Logs.Records[0].SeverityNumber())
+	assert.Equal(t, plog.SeverityNumberError, s.Logs.Records[1].SeverityNumber())
+}
+
+func TestLoadInvalid(t *testing.T) {
+	_, err := Load(filepath.Join("testdata", "invalid.yaml"))
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `error_rate must be between 0 and 1`)
+	assert.ErrorContains(t, err, `calls unknown operation "missing" of service "backend"`)
+	assert.ErrorContains(t, err, `unsupported type "summary"`)
+	assert.ErrorContains(t, err, `unknown severity "verbose"`)
+
+	_, err = Load(filepath.Join("testdata", "cycle.yaml"))
+	assert.ErrorContains(t, err, `operation "loop" of service "frontend" ends up calling itself`)
+
+	_, err = Load(filepath.Join("testdata", "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read scenario file")
+}
+
+func TestAttributeValues(t *testing.T) {
+	explicit := Attribute{Key: "route", Values: []string{"/a", "/b"}}
+	assert.Equal(t, 2, explicit.Len())
+	assert.Equal(t, "/b", explicit.Value(1))
+
+	generated := Attribute{Key: "pod", Cardinality: 3}
+	assert.Equal(t, 3, generated.Len())
+	assert.Equal(t, "pod-2", generated.Value(2))
+}
+
+func TestDistributionSample(t *testing.T) {
+	r := rand.New(rand.NewSource(1)) // nolint:gosec
+	assert.Equal(t, 5.0, Distribution{Value: 5}.Sample(r))
+	for i := 0; i < 100; i++ {
+		v := Distribution{Type: DistributionUniform, Min: 2, Max: 4}.Sample(r)
+		assert.True(t, v >= 2 && v <= 4, "uniform value %v out of range", v)
+		v = Distribution{Type: DistributionNormal, Mean: 10, StdDev: 100, Min: 0, Max: 20}.Sample(r)
+		assert.True(t, v >= 0 && v <= 20, "normal value %v not clamped", v)
+		v = Distribution{Type: DistributionExponential, Mean: 10}.Sample(r)
+		assert.True(t, v >= 0, "exponential value %v negative", v)
+	}
+}
+
+func TestPickWeighted(t *testing.T) {
+	r := rand.New(rand.NewSource(1)) // nolint:gosec
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, 1, PickWeighted(r, []int{0, 3, 0}))
+	}
+	picked := map[int]bool{}
+	for i := 0; i < 100; i++ {
+		picked[PickWeighted(r, []int{0, 0})] = true
+	}
+	assert.Len(t, picked, 2)
+}
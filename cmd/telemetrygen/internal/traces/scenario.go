@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/traces"
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
+)
+
+// fakeNetworkLatency is the time between a client span and the server span it wraps.
+const fakeNetworkLatency = 500 * time.Microsecond
+
+// scenarioGenerator emits traces following the topology of a scenario file.
+type scenarioGenerator struct {
+	traces  *scenario.Traces
+	tracers map[string]trace.Tracer
+	weights []int
+	rand    *rand.Rand
+}
+
+func newScenarioGenerator(traces *scenario.Traces, tracers map[string]trace.Tracer, r *rand.Rand) *scenarioGenerator {
+	weights := make([]int, len(traces.Entrypoints))
+	for i, e := range traces.Entrypoints {
+		weights[i] = e.Weight
+	}
+	return &scenarioGenerator{
+		traces:  traces,
+		tracers: tracers,
+		weights: weights,
+		rand:    r,
+	}
+}
+
+// generate emits a trace starting at one of the entrypoints.
+func (g *scenarioGenerator) generate() {
+	e := g.traces.Entrypoints[scenario.PickWeighted(g.rand, g.weights)]
+	g.emit(context.Background(), e.Call, trace.SpanKindServer, time.Now())
+}
+
+// emit records the span of an operation and of its downstream calls, returning
+// when the operation ended and whether it failed.
+func (g *scenarioGenerator) emit(ctx context.Context, call scenario.Call, kind trace.SpanKind, start time.Time) (time.Time, bool) {
+	op, _ := g.traces.Operation(call)
+	tracer := g.tracers[call.Service]
+	ctx, span := tracer.Start(ctx, op.Name,
+		trace.WithSpanKind(kind),
+		trace.WithTimestamp(start),
+		trace.WithAttributes(g.attributes(op.Attributes)...),
+	)
+
+	cursor := start
+	for _, c := range op.Calls {
+		if c.Service == call.Service {
+			cursor, _ = g.emit(ctx, c, trace.SpanKindInternal, cursor)
+			continue
+		}
+
+		// simulates going remote: the client span wraps the server span of the called service
+		clientCtx, client := tracer.Start(ctx, c.Operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithTimestamp(cursor),
+			trace.WithAttributes(semconv.PeerServiceKey.String(c.Service)),
+		)
+		end, failed := g.emit(clientCtx, c, trace.SpanKindServer, cursor.Add(fakeNetworkLatency))
+		if failed {
+			client.SetStatus(codes.Error, "downstream call failed")
+		}
+		cursor = end.Add(fakeNetworkLatency)
+		client.End(trace.WithTimestamp(cursor))
+	}
+
+	latency := math.Max(op.Latency.Sample(g.rand), 0)
+	end := cursor.Add(time.Duration(latency * float64(time.Millisecond)))
+	failed := g.rand.Float64() < op.ErrorRate
+	if failed {
+		span.SetStatus(codes.Error, "simulated error")
+	}
+	span.End(trace.WithTimestamp(end))
+	return end, failed
+}
+
+func (g *scenarioGenerator) attributes(attrs []scenario.Attribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		kvs = append(kvs, attribute.String(a.Key, a.Value(g.rand.Intn(a.Len()))))
+	}
+	return kvs
+}
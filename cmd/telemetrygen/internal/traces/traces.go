@@ -29,11 +29,14 @@ import (
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
 )
 
 func Start(cfg *Config) error {
@@ -42,6 +45,24 @@ func Start(cfg *Config) error {
 		return err
 	}
 
+	if err = cfg.Validate(); err != nil {
+		return err
+	}
+	tlsCfg, err := cfg.TLSConfig()
+	if err != nil {
+		return err
+	}
+
+	var s *scenario.Scenario
+	if cfg.ScenarioFile != "" {
+		if s, err = scenario.Load(cfg.ScenarioFile); err != nil {
+			return err
+		}
+		if s.Traces == nil {
+			return fmt.Errorf("scenario file %q has no traces section", cfg.ScenarioFile)
+		}
+	}
+
 	grpcExpOpt := []otlptracegrpc.Option{
 		otlptracegrpc.WithEndpoint(cfg.Endpoint),
 		otlptracegrpc.WithDialOption(
@@ -56,6 +77,9 @@ func Start(cfg *Config) error {
 	if cfg.Insecure {
 		grpcExpOpt = append(grpcExpOpt, otlptracegrpc.WithInsecure())
 		httpExpOpt = append(httpExpOpt, otlptracehttp.WithInsecure())
+	} else {
+		grpcExpOpt = append(grpcExpOpt, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		httpExpOpt = append(httpExpOpt, otlptracehttp.WithTLSClientConfig(tlsCfg))
 	}
 
 	if len(cfg.Headers) > 0 {
@@ -63,6 +87,13 @@ func Start(cfg *Config) error {
 		httpExpOpt = append(httpExpOpt, otlptracehttp.WithHeaders(cfg.Headers))
 	}
 
+	if compressor := cfg.GRPCCompressor(); compressor != "" {
+		grpcExpOpt = append(grpcExpOpt, otlptracegrpc.WithCompressor(compressor))
+	}
+	if cfg.Compression == common.CompressionGzip {
+		httpExpOpt = append(httpExpOpt, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+
 	var exp *otlptrace.Exporter
 	if cfg.UseHTTP {
 		logger.Info("starting HTTP exporter")
@@ -82,7 +113,8 @@ func Start(cfg *Config) error {
 		}
 	}()
 
-	ssp := sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithBatchTimeout(time.Second))
+	stats := common.NewStats()
+	ssp := sdktrace.NewBatchSpanProcessor(&recordingExporter{SpanExporter: exp, stats: stats}, sdktrace.WithBatchTimeout(time.Second))
 	defer func() {
 		logger.Info("stop the batch span processor")
 		if tempError := ssp.Shutdown(context.Background()); tempError != nil {
@@ -90,28 +122,78 @@ func Start(cfg *Config) error {
 		}
 	}()
 
-	var attributes []attribute.KeyValue
-	// may be overridden by `-otlp-attributes service.name="foo"`
-	attributes = append(attributes, semconv.ServiceNameKey.String(cfg.ServiceName))
-	attributes = append(attributes, cfg.GetAttributes()...)
+	if s != nil {
+		providers := make(map[string]trace.TracerProvider, len(s.Traces.Services))
+		for _, svc := range s.Traces.Services {
+			attributes := []attribute.KeyValue{semconv.ServiceNameKey.String(svc.Name)}
+			for k, v := range svc.ResourceAttributes {
+				attributes = append(attributes, attribute.String(k, v))
+			}
+			attributes = append(attributes, cfg.GetAttributes()...)
+			tp := sdktrace.NewTracerProvider(
+				sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attributes...)),
+			)
+			tp.RegisterSpanProcessor(ssp)
+			providers[svc.Name] = tp
+		}
+		err = RunScenario(cfg, s, providers, logger)
+	} else {
+		var attributes []attribute.KeyValue
+		// may be overridden by `-otlp-attributes service.name="foo"`
+		attributes = append(attributes, semconv.ServiceNameKey.String(cfg.ServiceName))
+		attributes = append(attributes, cfg.GetAttributes()...)
 
-	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attributes...)),
-	)
+		tracerProvider := sdktrace.NewTracerProvider(
+			sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attributes...)),
+		)
 
-	tracerProvider.RegisterSpanProcessor(ssp)
-	otel.SetTracerProvider(tracerProvider)
+		tracerProvider.RegisterSpanProcessor(ssp)
+		otel.SetTracerProvider(tracerProvider)
 
-	if err = Run(cfg, logger); err != nil {
+		err = Run(cfg, logger)
+	}
+	if err != nil {
 		logger.Error("failed to execute the test scenario.", zap.Error(err))
 		return err
 	}
 
-	return nil
+	// export the pending spans so that they are accounted for in the summary
+	if err = ssp.ForceFlush(context.Background()); err != nil {
+		logger.Error("failed to flush the batch span processor", zap.Error(err))
+	}
+	return cfg.Report(logger, "spans", stats.Summary())
+}
+
+// recordingExporter records the outcome of each export in the stats of the test.
+type recordingExporter struct {
+	sdktrace.SpanExporter
+	stats *common.Stats
+}
+
+func (e *recordingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	return common.RecordExport(e.stats, spans, func(spans []sdktrace.ReadOnlySpan) int { return len(spans) }, func(spans []sdktrace.ReadOnlySpan) error {
+		return e.SpanExporter.ExportSpans(ctx, spans)
+	})
 }
 
 // Run executes the test scenario.
 func Run(c *Config, logger *zap.Logger) error {
+	return run(c, nil, logger)
+}
+
+// RunScenario executes the traces section of a scenario file. Each service
+// emits its spans through its own tracer provider.
+func RunScenario(c *Config, s *scenario.Scenario, providers map[string]trace.TracerProvider, logger *zap.Logger) error {
+	tracers := make(map[string]trace.Tracer, len(providers))
+	for name, tp := range providers {
+		tracers[name] = tp.Tracer("telemetrygen")
+	}
+	return run(c, func(i int) *scenarioGenerator {
+		return newScenarioGenerator(s.Traces, tracers, s.NewRand(i))
+	}, logger)
+}
+
+func run(c *Config, newGenerator func(int) *scenarioGenerator, logger *zap.Logger) error {
 	if c.TotalDuration > 0 {
 		c.NumTraces = 0
 	} else if c.NumTraces <= 0 {
@@ -142,6 +224,9 @@ func Run(c *Config, logger *zap.Logger) error {
 			wg:               &wg,
 			logger:           logger.With(zap.Int("worker", i)),
 		}
+		if newGenerator != nil {
+			w.scenario = newGenerator(i)
+		}
 
 		go w.simulateTraces()
 	}
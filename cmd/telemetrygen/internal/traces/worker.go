@@ -37,6 +37,7 @@ type worker struct {
 	limitPerSecond   rate.Limit      // how many spans per second to generate
 	wg               *sync.WaitGroup // notify when done
 	logger           *zap.Logger
+	scenario         *scenarioGenerator // generates the traces of a scenario file instead of the built-in shape
 }
 
 const (
@@ -50,6 +51,18 @@ func (w worker) simulateTraces() {
 	limiter := rate.NewLimiter(w.limitPerSecond, 1)
 	var i int
 	for w.running.Load() {
+		if w.scenario != nil {
+			if err := limiter.Wait(context.Background()); err != nil {
+				w.logger.Fatal("limiter waited failed, retry", zap.Error(err))
+			}
+			w.scenario.generate()
+			i++
+			if w.numTraces != 0 && i >= w.numTraces {
+				break
+			}
+			continue
+		}
+
 		ctx, sp := tracer.Start(context.Background(), "lets-go", trace.WithAttributes(
 			attribute.String("span.kind", "client"), // is there a semantic convention for this?
 			semconv.NetPeerIPKey.String(fakeIP),
@@ -22,10 +22,16 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
 )
 
 func TestFixedNumberOfTraces(t *testing.T) {
@@ -108,6 +114,100 @@ func TestUnthrottled(t *testing.T) {
 	assert.True(t, len(syncer.spans) > 100, "there should have been more than 100 spans, had %d", len(syncer.spans))
 }
 
+func TestScenario(t *testing.T) {
+	// prepare
+	syncer := &mockSyncer{}
+	sp := sdktrace.NewSimpleSpanProcessor(syncer)
+	providers := map[string]trace.TracerProvider{}
+	for _, name := range []string{"frontend", "checkout"} {
+		tp := sdktrace.NewTracerProvider(sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceNameKey.String(name))))
+		tp.RegisterSpanProcessor(sp)
+		providers[name] = tp
+	}
+
+	s := &scenario.Scenario{
+		Seed: 1,
+		Traces: &scenario.Traces{
+			Services: []scenario.Service{
+				{
+					Name: "frontend",
+					Operations: []scenario.Operation{
+						{
+							Name:    "GET /checkout",
+							Latency: scenario.Distribution{Value: 10},
+							Calls: []scenario.Call{
+								{Service: "frontend", Operation: "render"},
+								{Service: "checkout", Operation: "PlaceOrder"},
+							},
+						},
+						{Name: "render", Latency: scenario.Distribution{Value: 1}},
+					},
+				},
+				{
+					Name: "checkout",
+					Operations: []scenario.Operation{
+						{
+							Name:       "PlaceOrder",
+							Latency:    scenario.Distribution{Value: 20},
+							ErrorRate:  1,
+							Attributes: []scenario.Attribute{{Key: "customer.id", Cardinality: 5}},
+						},
+					},
+				},
+			},
+			Entrypoints: []scenario.Entrypoint{{Call: scenario.Call{Service: "frontend", Operation: "GET /checkout"}}},
+		},
+	}
+	require.NoError(t, s.Validate())
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		NumTraces: 1,
+	}
+
+	// test
+	require.NoError(t, RunScenario(cfg, s, providers, zap.NewNop()))
+
+	// verify
+	require.Len(t, syncer.spans, 4) // root, internal, client and server spans
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range syncer.spans {
+		service, _ := span.Resource().Set().Value(semconv.ServiceNameKey)
+		spans[service.AsString()+" "+span.SpanKind().String()] = span
+	}
+
+	root := spans["frontend server"]
+	require.NotNil(t, root)
+	assert.Equal(t, "GET /checkout", root.Name())
+	assert.False(t, root.Parent().IsValid())
+	assert.Equal(t, codes.Unset, root.Status().Code)
+
+	internal := spans["frontend internal"]
+	require.NotNil(t, internal)
+	assert.Equal(t, root.SpanContext().SpanID(), internal.Parent().SpanID())
+	assert.Equal(t, time.Millisecond, internal.EndTime().Sub(internal.StartTime()))
+
+	client := spans["frontend client"]
+	require.NotNil(t, client)
+	assert.Equal(t, "PlaceOrder", client.Name())
+	assert.Equal(t, root.SpanContext().SpanID(), client.Parent().SpanID())
+	assert.Equal(t, codes.Error, client.Status().Code)
+
+	server := spans["checkout server"]
+	require.NotNil(t, server)
+	assert.Equal(t, client.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.Equal(t, root.SpanContext().TraceID(), server.SpanContext().TraceID())
+	assert.Equal(t, codes.Error, server.Status().Code)
+	assert.Equal(t, 20*time.Millisecond, server.EndTime().Sub(server.StartTime()))
+	require.Len(t, server.Attributes(), 1)
+	assert.Equal(t, attribute.Key("customer.id"), server.Attributes()[0].Key)
+
+	// the root span lasts for its own latency on top of its calls
+	assert.Equal(t, 10*time.Millisecond+1*time.Millisecond+20*time.Millisecond+2*fakeNetworkLatency, root.EndTime().Sub(root.StartTime()))
+}
+
 var _ sdktrace.SpanExporter = (*mockSyncer)(nil)
 
 type mockSyncer struct {
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const defaultColumnType = "String"
+
+var columnNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// columnConverters converts an attribute value to the value inserted into a column of the given type.
+// Missing or unparsable values are inserted as the zero value of the type.
+var columnConverters = map[string]func(v pcommon.Value, ok bool) any{
+	"String":                 toStringColumn,
+	"LowCardinality(String)": toStringColumn,
+	"Int64": func(v pcommon.Value, ok bool) any {
+		if !ok {
+			return int64(0)
+		}
+		switch v.Type() {
+		case pcommon.ValueTypeInt:
+			return v.Int()
+		case pcommon.ValueTypeDouble:
+			return int64(v.Double())
+		case pcommon.ValueTypeBool:
+			if v.Bool() {
+				return int64(1)
+			}
+			return int64(0)
+		default:
+			i, _ := strconv.ParseInt(v.AsString(), 10, 64)
+			return i
+		}
+	},
+	"Float64": func(v pcommon.Value, ok bool) any {
+		if !ok {
+			return float64(0)
+		}
+		switch v.Type() {
+		case pcommon.ValueTypeDouble:
+			return v.Double()
+		case pcommon.ValueTypeInt:
+			return float64(v.Int())
+		default:
+			f, _ := strconv.ParseFloat(v.AsString(), 64)
+			return f
+		}
+	},
+	"Bool": func(v pcommon.Value, ok bool) any {
+		if !ok {
+			return false
+		}
+		switch v.Type() {
+		case pcommon.ValueTypeBool:
+			return v.Bool()
+		case pcommon.ValueTypeInt:
+			return v.Int() != 0
+		default:
+			b, _ := strconv.ParseBool(v.AsString())
+			return b
+		}
+	},
+}
+
+func toStringColumn(v pcommon.Value, ok bool) any {
+	if !ok {
+		return ""
+	}
+	return v.AsString()
+}
+
+func (m ColumnMapping) columnType() string {
+	if m.Type == "" {
+		return defaultColumnType
+	}
+	return m.Type
+}
+
+// value returns the value of the column for a record with the given resource and record attributes.
+func (m ColumnMapping) value(resAttrs pcommon.Map, attrs pcommon.Map) any {
+	if m.Resource {
+		attrs = resAttrs
+	}
+	v, ok := attrs.Get(m.Attribute)
+	return columnConverters[m.columnType()](v, ok)
+}
+
+// renderColumnsDDL renders the definitions of the columns, to be placed after the built-in ones.
+func renderColumnsDDL(columns []ColumnMapping) string {
+	var b strings.Builder
+	for _, column := range columns {
+		fmt.Fprintf(&b, "\n     %s %s CODEC(ZSTD(1)),", column.Name, column.columnType())
+	}
+	return b.String()
+}
+
+// renderAddColumnsSQL renders the statement adding the columns to an existing table, as `CREATE TABLE IF NOT EXISTS`
+// leaves the table unchanged when it was created with other columns. It returns an empty string without columns.
+func renderAddColumnsSQL(table string, cluster string, columns []ColumnMapping) string {
+	if len(columns) == 0 {
+		return ""
+	}
+	clauses := make([]string, 0, len(columns))
+	for _, column := range columns {
+		clauses = append(clauses, fmt.Sprintf("ADD COLUMN IF NOT EXISTS %s %s CODEC(ZSTD(1))", column.Name, column.columnType()))
+	}
+	return fmt.Sprintf("ALTER TABLE %s %s %s", table, cluster, strings.Join(clauses, ", "))
+}
+
+// renderColumnsInsert renders the names and placeholders of the columns, to be placed after the built-in ones.
+func renderColumnsInsert(columns []ColumnMapping) (names string, placeholders string) {
+	for _, column := range columns {
+		names += ",\n                        " + column.Name
+		placeholders += ",\n                                  ?"
+	}
+	return names, placeholders
+}
+
+// columnValues appends the values of the columns to values.
+func columnValues(values []any, columns []ColumnMapping, resAttrs pcommon.Map, attrs pcommon.Map) []any {
+	for _, column := range columns {
+		values = append(values, column.value(resAttrs, attrs))
+	}
+	return values
+}
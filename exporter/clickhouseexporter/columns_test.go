@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestColumnMapping_value(t *testing.T) {
+	res := pcommon.NewMap()
+	res.PutStr("host.name", "guarana")
+	attrs := pcommon.NewMap()
+	attrs.PutInt("http.status_code", 200)
+	attrs.PutStr("http.duration", "1.5")
+	attrs.PutStr("http.retried", "true")
+	attrs.PutDouble("http.size", 42.7)
+
+	tests := []struct {
+		name   string
+		column ColumnMapping
+		want   any
+	}{
+		{name: "string", column: ColumnMapping{Attribute: "http.status_code"}, want: "200"},
+		{name: "resource", column: ColumnMapping{Attribute: "host.name", Resource: true}, want: "guarana"},
+		{name: "missing string", column: ColumnMapping{Attribute: "host.name"}, want: ""},
+		{name: "int", column: ColumnMapping{Type: "Int64", Attribute: "http.status_code"}, want: int64(200)},
+		{name: "int from double", column: ColumnMapping{Type: "Int64", Attribute: "http.size"}, want: int64(42)},
+		{name: "missing int", column: ColumnMapping{Type: "Int64", Attribute: "http.missing"}, want: int64(0)},
+		{name: "float from string", column: ColumnMapping{Type: "Float64", Attribute: "http.duration"}, want: 1.5},
+		{name: "float from int", column: ColumnMapping{Type: "Float64", Attribute: "http.status_code"}, want: float64(200)},
+		{name: "bool from string", column: ColumnMapping{Type: "Bool", Attribute: "http.retried"}, want: true},
+		{name: "missing bool", column: ColumnMapping{Type: "Bool", Attribute: "http.missing"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.column.value(res, attrs))
+		})
+	}
+}
+
+func TestRenderAddColumnsSQL(t *testing.T) {
+	assert.Empty(t, renderAddColumnsSQL("otel_logs", "", nil))
+	assert.Equal(t,
+		"ALTER TABLE otel_logs  ADD COLUMN IF NOT EXISTS HttpMethod String CODEC(ZSTD(1)), ADD COLUMN IF NOT EXISTS HttpStatusCode Int64 CODEC(ZSTD(1))",
+		renderAddColumnsSQL("otel_logs", "", []ColumnMapping{
+			{Name: "HttpMethod", Attribute: "http.method"},
+			{Name: "HttpStatusCode", Type: "Int64", Attribute: "http.status_code"},
+		}))
+}
@@ -51,6 +51,28 @@ type Config struct {
 	MetricsTableName string `mapstructure:"metrics_table_name"`
 	// TTLDays is The data time-to-live in days, 0 means no ttl.
 	TTLDays uint `mapstructure:"ttl_days"`
+	// CreateSchema is whether the database, tables and views are created on start. default is true.
+	CreateSchema bool `mapstructure:"create_schema"`
+	// ClusterName is the cluster the schema is created on with `ON CLUSTER`, using replicated table engines.
+	ClusterName string `mapstructure:"cluster_name"`
+	// TraceIDTimestampTable is whether the trace id timestamp lookup table is created. default is true.
+	TraceIDTimestampTable bool `mapstructure:"trace_id_timestamp_table"`
+	// LogsColumns promotes log and resource attributes to dedicated columns of the logs table.
+	LogsColumns []ColumnMapping `mapstructure:"logs_columns"`
+	// TracesColumns promotes span and resource attributes to dedicated columns of the traces table.
+	TracesColumns []ColumnMapping `mapstructure:"traces_columns"`
+}
+
+// ColumnMapping promotes an attribute to a dedicated column.
+type ColumnMapping struct {
+	// Name is the column name.
+	Name string `mapstructure:"name"`
+	// Type is the column type, one of String, LowCardinality(String), Int64, Float64 or Bool. default is String.
+	Type string `mapstructure:"type"`
+	// Attribute is the key of the attribute stored in the column.
+	Attribute string `mapstructure:"attribute"`
+	// Resource is whether the attribute is looked up in the resource attributes.
+	Resource bool `mapstructure:"resource"`
 }
 
 // QueueSettings is a subset of exporterhelper.QueueSettings.
@@ -64,6 +86,8 @@ const defaultDatabase = "default"
 var (
 	errConfigNoEndpoint      = errors.New("endpoint must be specified")
 	errConfigInvalidEndpoint = errors.New("endpoint must be url format")
+	errConfigInvalidColumn   = errors.New("invalid column mapping")
+	errConfigInvalidCluster  = errors.New("cluster_name must be a valid identifier")
 )
 
 // Validate the clickhouse server configuration.
@@ -82,9 +106,59 @@ func (cfg *Config) Validate() (err error) {
 		err = multierr.Append(err, e)
 	}
 
+	// The cluster name is written as is in the DDL statements.
+	if cfg.ClusterName != "" && !columnNamePattern.MatchString(cfg.ClusterName) {
+		err = multierr.Append(err, fmt.Errorf("%w: %q", errConfigInvalidCluster, cfg.ClusterName))
+	}
+	err = multierr.Append(err, validateColumns("logs_columns", cfg.LogsColumns, logsBuiltinColumns))
+	err = multierr.Append(err, validateColumns("traces_columns", cfg.TracesColumns, tracesBuiltinColumns))
+
 	return err
 }
 
+func validateColumns(field string, columns []ColumnMapping, builtinColumns []string) (err error) {
+	builtin := map[string]struct{}{}
+	for _, name := range builtinColumns {
+		builtin[name] = struct{}{}
+	}
+	names := map[string]struct{}{}
+	for i, column := range columns {
+		if !columnNamePattern.MatchString(column.Name) {
+			err = multierr.Append(err, fmt.Errorf("%w: %s[%d]: name %q must be a valid identifier", errConfigInvalidColumn, field, i, column.Name))
+		}
+		if _, ok := builtin[column.Name]; ok {
+			err = multierr.Append(err, fmt.Errorf("%w: %s[%d]: name %q is a built-in column", errConfigInvalidColumn, field, i, column.Name))
+		}
+		if _, ok := names[column.Name]; ok {
+			err = multierr.Append(err, fmt.Errorf("%w: %s[%d]: duplicate name %q", errConfigInvalidColumn, field, i, column.Name))
+		}
+		names[column.Name] = struct{}{}
+		if column.Attribute == "" {
+			err = multierr.Append(err, fmt.Errorf("%w: %s[%d]: attribute must be specified", errConfigInvalidColumn, field, i))
+		}
+		if _, ok := columnConverters[column.columnType()]; !ok {
+			err = multierr.Append(err, fmt.Errorf("%w: %s[%d]: unsupported type %q", errConfigInvalidColumn, field, i, column.Type))
+		}
+	}
+	return err
+}
+
+// clusterString returns the `ON CLUSTER` clause of the DDL statements.
+func (cfg *Config) clusterString() string {
+	if cfg.ClusterName == "" {
+		return ""
+	}
+	return fmt.Sprintf("ON CLUSTER %s", cfg.ClusterName)
+}
+
+// tableEngineString returns the engine of the tables, replicated when a cluster is set.
+func (cfg *Config) tableEngineString() string {
+	if cfg.ClusterName == "" {
+		return "MergeTree()"
+	}
+	return "ReplicatedMergeTree('/clickhouse/tables/{shard}/{database}/{table}', '{replica}')"
+}
+
 func (cfg *Config) enforcedQueueSettings() exporterhelper.QueueSettings {
 	return exporterhelper.QueueSettings{
 		Enabled:      true,
@@ -74,6 +74,15 @@ func TestLoadConfig(t *testing.T) {
 				QueueSettings: QueueSettings{
 					QueueSize: 100,
 				},
+				CreateSchema:          false,
+				ClusterName:           "otel_cluster",
+				TraceIDTimestampTable: false,
+				LogsColumns: []ColumnMapping{
+					{Name: "K8sNamespace", Type: "LowCardinality(String)", Attribute: "k8s.namespace.name", Resource: true},
+				},
+				TracesColumns: []ColumnMapping{
+					{Name: "HttpStatusCode", Type: "Int64", Attribute: "http.status_code"},
+				},
 			},
 		},
 	}
@@ -93,6 +102,83 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestConfig_ValidateColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []ColumnMapping
+		wantErr string
+	}{
+		{
+			name: "valid",
+			columns: []ColumnMapping{
+				{Name: "HttpMethod", Attribute: "http.method"},
+				{Name: "host_name", Type: "LowCardinality(String)", Attribute: "host.name", Resource: true},
+			},
+		},
+		{
+			name:    "invalid name",
+			columns: []ColumnMapping{{Name: "http.method", Attribute: "http.method"}},
+			wantErr: `traces_columns[0]: name "http.method" must be a valid identifier`,
+		},
+		{
+			name: "duplicate name",
+			columns: []ColumnMapping{
+				{Name: "HttpMethod", Attribute: "http.method"},
+				{Name: "HttpMethod", Attribute: "http.request.method"},
+			},
+			wantErr: `traces_columns[1]: duplicate name "HttpMethod"`,
+		},
+		{
+			name:    "built-in column",
+			columns: []ColumnMapping{{Name: "ServiceName", Attribute: "service.name", Resource: true}},
+			wantErr: `traces_columns[0]: name "ServiceName" is a built-in column`,
+		},
+		{
+			name:    "missing attribute",
+			columns: []ColumnMapping{{Name: "HttpMethod"}},
+			wantErr: "traces_columns[0]: attribute must be specified",
+		},
+		{
+			name:    "unsupported type",
+			columns: []ColumnMapping{{Name: "HttpStatusCode", Type: "UInt16", Attribute: "http.status_code"}},
+			wantErr: `traces_columns[0]: unsupported type "UInt16"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.TracesColumns = tt.columns
+			})
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, errConfigInvalidColumn)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_ValidateClusterName(t *testing.T) {
+	for _, name := range []string{"", "otel_cluster", "Cluster1"} {
+		cfg := withDefaultConfig(func(cfg *Config) {
+			cfg.Endpoint = defaultEndpoint
+			cfg.ClusterName = name
+		})
+		assert.NoError(t, cfg.Validate(), name)
+	}
+
+	for _, name := range []string{"otel-cluster", "c; DROP DATABASE otel", "1cluster"} {
+		cfg := withDefaultConfig(func(cfg *Config) {
+			cfg.Endpoint = defaultEndpoint
+			cfg.ClusterName = name
+		})
+		assert.ErrorIs(t, cfg.Validate(), errConfigInvalidCluster, name)
+	}
+}
+
 func withDefaultConfig(fns ...func(*Config)) *Config {
 	cfg := createDefaultConfig().(*Config)
 	for _, fn := range fns {
@@ -53,6 +53,10 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*logsExporter, error) {
 }
 
 func (e *logsExporter) start(ctx context.Context, _ component.Host) error {
+	if !e.cfg.CreateSchema {
+		return nil
+	}
+
 	if err := createDatabase(ctx, e.cfg); err != nil {
 		return err
 	}
@@ -94,7 +98,7 @@ func (e *logsExporter) pushLogsData(ctx context.Context, ld plog.Logs) error {
 				for k := 0; k < rs.Len(); k++ {
 					r := rs.At(k)
 					logAttr := attributesToMap(r.Attributes())
-					_, err = statement.ExecContext(ctx,
+					values := []any{
 						r.Timestamp().AsTime(),
 						traceutil.TraceIDToHexOrEmptyString(r.TraceID()),
 						traceutil.SpanIDToHexOrEmptyString(r.SpanID()),
@@ -105,7 +109,9 @@ func (e *logsExporter) pushLogsData(ctx context.Context, ld plog.Logs) error {
 						r.Body().AsString(),
 						resAttr,
 						logAttr,
-					)
+					}
+					values = columnValues(values, e.cfg.LogsColumns, res.Attributes(), r.Attributes())
+					_, err = statement.ExecContext(ctx, values...)
 					if err != nil {
 						return fmt.Errorf("ExecContext:%w", err)
 					}
@@ -132,7 +138,7 @@ func attributesToMap(attributes pcommon.Map) map[string]string {
 const (
 	// language=ClickHouse SQL
 	createLogsTableSQL = `
-CREATE TABLE IF NOT EXISTS %s (
+CREATE TABLE IF NOT EXISTS %s %s (
      Timestamp DateTime64(9) CODEC(Delta, ZSTD(1)),
      TraceId String CODEC(ZSTD(1)),
      SpanId String CODEC(ZSTD(1)),
@@ -142,14 +148,14 @@ CREATE TABLE IF NOT EXISTS %s (
      ServiceName LowCardinality(String) CODEC(ZSTD(1)),
      Body String CODEC(ZSTD(1)),
      ResourceAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
-     LogAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     LogAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),%s
      INDEX idx_trace_id TraceId TYPE bloom_filter(0.001) GRANULARITY 1,
      INDEX idx_res_attr_key mapKeys(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
      INDEX idx_res_attr_value mapValues(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
      INDEX idx_log_attr_key mapKeys(LogAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
      INDEX idx_log_attr_value mapValues(LogAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
      INDEX idx_body Body TYPE tokenbf_v1(32768, 3, 0) GRANULARITY 1
-) ENGINE MergeTree()
+) ENGINE %s
 %s
 PARTITION BY toDate(Timestamp)
 ORDER BY (ServiceName, SeverityText, toUnixTimestamp(Timestamp), TraceId)
@@ -166,7 +172,7 @@ SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
                         ServiceName,
                         Body,
                         ResourceAttributes,
-                        LogAttributes
+                        LogAttributes%s
                         ) VALUES (
                                   ?,
                                   ?,
@@ -177,10 +183,16 @@ SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
                                   ?,
                                   ?,
                                   ?,
-                                  ?
+                                  ?%s
                                   )`
 )
 
+// logsBuiltinColumns are the columns of the logs table, which can't be used by column mappings.
+var logsBuiltinColumns = []string{
+	"Timestamp", "TraceId", "SpanId", "TraceFlags", "SeverityText", "SeverityNumber", "ServiceName", "Body",
+	"ResourceAttributes", "LogAttributes",
+}
+
 var driverName = "clickhouse" // for testing
 
 // newClickhouseClient create a clickhouse client.
@@ -205,7 +217,7 @@ func createDatabase(ctx context.Context, cfg *Config) error {
 	defer func() {
 		_ = db.Close()
 	}()
-	query := fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", cfg.Database)
+	query := fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s %s", cfg.Database, cfg.clusterString())
 	_, err = db.ExecContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("create database:%w", err)
@@ -217,6 +229,11 @@ func createLogsTable(ctx context.Context, cfg *Config, db *sql.DB) error {
 	if _, err := db.ExecContext(ctx, renderCreateLogsTableSQL(cfg)); err != nil {
 		return fmt.Errorf("exec create logs table sql: %w", err)
 	}
+	if query := renderAddColumnsSQL(cfg.LogsTableName, cfg.clusterString(), cfg.LogsColumns); query != "" {
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("exec add logs columns sql: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -225,11 +242,13 @@ func renderCreateLogsTableSQL(cfg *Config) string {
 	if cfg.TTLDays > 0 {
 		ttlExpr = fmt.Sprintf(`TTL toDateTime(Timestamp) + toIntervalDay(%d)`, cfg.TTLDays)
 	}
-	return fmt.Sprintf(createLogsTableSQL, cfg.LogsTableName, ttlExpr)
+	return fmt.Sprintf(createLogsTableSQL, cfg.LogsTableName, cfg.clusterString(),
+		renderColumnsDDL(cfg.LogsColumns), cfg.tableEngineString(), ttlExpr)
 }
 
 func renderInsertLogsSQL(cfg *Config) string {
-	return fmt.Sprintf(insertLogsSQLTemplate, cfg.LogsTableName)
+	names, placeholders := renderColumnsInsert(cfg.LogsColumns)
+	return fmt.Sprintf(insertLogsSQLTemplate, cfg.LogsTableName, names, placeholders)
 }
 
 func doWithTx(_ context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
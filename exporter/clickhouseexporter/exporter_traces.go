@@ -53,6 +53,10 @@ func newTracesExporter(logger *zap.Logger, cfg *Config) (*tracesExporter, error)
 }
 
 func (e *tracesExporter) start(ctx context.Context, _ component.Host) error {
+	if !e.cfg.CreateSchema {
+		return nil
+	}
+
 	if err := createDatabase(ctx, e.cfg); err != nil {
 		return err
 	}
@@ -103,7 +107,7 @@ func (e *tracesExporter) pushTraceData(ctx context.Context, td ptrace.Traces) er
 					status := r.Status()
 					eventTimes, eventNames, eventAttrs := convertEvents(r.Events())
 					linksTraceIDs, linksSpanIDs, linksTraceStates, linksAttrs := convertLinks(r.Links())
-					_, err = statement.ExecContext(ctx,
+					values := []any{
 						r.StartTimestamp().AsTime(),
 						traceutil.TraceIDToHexOrEmptyString(r.TraceID()),
 						traceutil.SpanIDToHexOrEmptyString(r.SpanID()),
@@ -124,7 +128,9 @@ func (e *tracesExporter) pushTraceData(ctx context.Context, td ptrace.Traces) er
 						linksSpanIDs,
 						linksTraceStates,
 						linksAttrs,
-					)
+					}
+					values = columnValues(values, e.cfg.TracesColumns, res.Attributes(), r.Attributes())
+					_, err = statement.ExecContext(ctx, values...)
 					if err != nil {
 						return fmt.Errorf("ExecContext:%w", err)
 					}
@@ -174,7 +180,7 @@ func convertLinks(links ptrace.SpanLinkSlice) ([]string, []string, []string, []m
 const (
 	// language=ClickHouse SQL
 	createTracesTableSQL = `
-CREATE TABLE IF NOT EXISTS %s (
+CREATE TABLE IF NOT EXISTS %s %s (
      Timestamp DateTime64(9) CODEC(Delta, ZSTD(1)),
      TraceId String CODEC(ZSTD(1)),
      SpanId String CODEC(ZSTD(1)),
@@ -198,14 +204,14 @@ CREATE TABLE IF NOT EXISTS %s (
          SpanId String,
          TraceState String,
          Attributes Map(LowCardinality(String), String)
-     ) CODEC(ZSTD(1)),
+     ) CODEC(ZSTD(1)),%s
      INDEX idx_trace_id TraceId TYPE bloom_filter(0.001) GRANULARITY 1,
      INDEX idx_res_attr_key mapKeys(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
      INDEX idx_res_attr_value mapValues(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
      INDEX idx_span_attr_key mapKeys(SpanAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
      INDEX idx_span_attr_value mapValues(SpanAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
      INDEX idx_duration Duration TYPE minmax GRANULARITY 1
-) ENGINE MergeTree()
+) ENGINE %s
 %s
 PARTITION BY toDate(Timestamp)
 ORDER BY (ServiceName, SpanName, toUnixTimestamp(Timestamp), TraceId)
@@ -232,7 +238,7 @@ SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
                         Links.TraceId,
                         Links.SpanId,
                         Links.TraceState,
-                        Links.Attributes
+                        Links.Attributes%s
                         ) VALUES (
                                   ?,
                                   ?,
@@ -253,24 +259,24 @@ SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
                                   ?,
                                   ?,
                                   ?,
-                                  ?
+                                  ?%s
                                   )`
 )
 
 const (
 	createTraceIDTsTableSQL = `
-create table IF NOT EXISTS %s_trace_id_ts (
+create table IF NOT EXISTS %s_trace_id_ts %s (
      TraceId String CODEC(ZSTD(1)),
      Start DateTime64(9) CODEC(Delta, ZSTD(1)),
      End DateTime64(9) CODEC(Delta, ZSTD(1)),
      INDEX idx_trace_id TraceId TYPE bloom_filter(0.01) GRANULARITY 1
-) ENGINE MergeTree()
+) ENGINE %s
 %s
 ORDER BY (TraceId, toUnixTimestamp(Start))
 SETTINGS index_granularity=8192;
 `
 	createTraceIDTsMaterializedViewSQL = `
-CREATE MATERIALIZED VIEW IF NOT EXISTS %s_trace_id_ts_mv
+CREATE MATERIALIZED VIEW IF NOT EXISTS %s_trace_id_ts_mv %s
 TO %s.%s_trace_id_ts
 AS SELECT
 TraceId,
@@ -283,10 +289,24 @@ GROUP BY TraceId;
 `
 )
 
+// tracesBuiltinColumns are the columns of the traces table, which can't be used by column mappings.
+var tracesBuiltinColumns = []string{
+	"Timestamp", "TraceId", "SpanId", "ParentSpanId", "TraceState", "SpanName", "SpanKind", "ServiceName",
+	"ResourceAttributes", "SpanAttributes", "Duration", "StatusCode", "StatusMessage", "Events", "Links",
+}
+
 func createTracesTable(ctx context.Context, cfg *Config, db *sql.DB) error {
 	if _, err := db.ExecContext(ctx, renderCreateTracesTableSQL(cfg)); err != nil {
 		return fmt.Errorf("exec create traces table sql: %w", err)
 	}
+	if query := renderAddColumnsSQL(cfg.TracesTableName, cfg.clusterString(), cfg.TracesColumns); query != "" {
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("exec add traces columns sql: %w", err)
+		}
+	}
+	if !cfg.TraceIDTimestampTable {
+		return nil
+	}
 	if _, err := db.ExecContext(ctx, renderCreateTraceIDTsTableSQL(cfg)); err != nil {
 		return fmt.Errorf("exec create traceIDTs table sql: %w", err)
 	}
@@ -297,7 +317,8 @@ func createTracesTable(ctx context.Context, cfg *Config, db *sql.DB) error {
 }
 
 func renderInsertTracesSQL(cfg *Config) string {
-	return fmt.Sprintf(strings.ReplaceAll(insertTracesSQLTemplate, "'", "`"), cfg.TracesTableName)
+	names, placeholders := renderColumnsInsert(cfg.TracesColumns)
+	return fmt.Sprintf(strings.ReplaceAll(insertTracesSQLTemplate, "'", "`"), cfg.TracesTableName, names, placeholders)
 }
 
 func renderCreateTracesTableSQL(cfg *Config) string {
@@ -305,7 +326,8 @@ func renderCreateTracesTableSQL(cfg *Config) string {
 	if cfg.TTLDays > 0 {
 		ttlExpr = fmt.Sprintf(`TTL toDateTime(Timestamp) + toIntervalDay(%d)`, cfg.TTLDays)
 	}
-	return fmt.Sprintf(createTracesTableSQL, cfg.TracesTableName, ttlExpr)
+	return fmt.Sprintf(createTracesTableSQL, cfg.TracesTableName, cfg.clusterString(),
+		renderColumnsDDL(cfg.TracesColumns), cfg.tableEngineString(), ttlExpr)
 }
 
 func renderCreateTraceIDTsTableSQL(cfg *Config) string {
@@ -313,10 +335,11 @@ func renderCreateTraceIDTsTableSQL(cfg *Config) string {
 	if cfg.TTLDays > 0 {
 		ttlExpr = fmt.Sprintf(`TTL toDateTime(Start) + toIntervalDay(%d)`, cfg.TTLDays)
 	}
-	return fmt.Sprintf(createTraceIDTsTableSQL, cfg.TracesTableName, ttlExpr)
+	return fmt.Sprintf(createTraceIDTsTableSQL, cfg.TracesTableName, cfg.clusterString(),
+		cfg.tableEngineString(), ttlExpr)
 }
 
 func renderTraceIDTsMaterializedViewSQL(cfg *Config) string {
-	return fmt.Sprintf(createTraceIDTsMaterializedViewSQL, cfg.TracesTableName,
+	return fmt.Sprintf(createTraceIDTsMaterializedViewSQL, cfg.TracesTableName, cfg.clusterString(),
 		cfg.Database, cfg.TracesTableName, cfg.Database, cfg.TracesTableName)
 }
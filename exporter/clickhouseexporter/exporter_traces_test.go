@@ -63,6 +63,84 @@ func TestExporter_pushTracesData(t *testing.T) {
 		exporter := newTestTracesExporter(t, defaultEndpoint)
 		mustPushTracesData(t, exporter, simpleTraces(1))
 	})
+	t.Run("promote attributes to columns", func(t *testing.T) {
+		var inserted []driver.Value
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				require.Contains(t, query, "Links.Attributes,\n                        HttpMethod,\n                        Region")
+				inserted = values
+			}
+			return nil
+		})
+
+		exporter := newTestTracesExporter(t, defaultEndpoint, func(cfg *Config) {
+			cfg.TracesColumns = []ColumnMapping{
+				{Name: "HttpMethod", Attribute: "http.method"},
+				{Name: "Region", Type: "LowCardinality(String)", Attribute: "cloud.region", Resource: true},
+			}
+		})
+		td := simpleTraces(1)
+		td.ResourceSpans().At(0).Resource().Attributes().PutStr("cloud.region", "eu-west-1")
+		td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutStr("http.method", "GET")
+		mustPushTracesData(t, exporter, td)
+
+		require.Len(t, inserted, 22)
+		require.Equal(t, "GET", inserted[20])
+		require.Equal(t, "eu-west-1", inserted[21])
+	})
+}
+
+func TestExporter_createTracesSchema(t *testing.T) {
+	t.Run("on cluster", func(t *testing.T) {
+		var queries []string
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			queries = append(queries, query)
+			return nil
+		})
+
+		newTestTracesExporter(t, defaultEndpoint, func(cfg *Config) {
+			cfg.Database = "otel"
+			cfg.ClusterName = "otel_cluster"
+			cfg.TracesColumns = []ColumnMapping{{Name: "HttpStatusCode", Type: "Int64", Attribute: "http.status_code"}}
+		})
+
+		require.Len(t, queries, 5)
+		require.Equal(t, "CREATE DATABASE IF NOT EXISTS otel ON CLUSTER otel_cluster", queries[0])
+		require.Contains(t, queries[1], "CREATE TABLE IF NOT EXISTS otel_traces ON CLUSTER otel_cluster (")
+		require.Contains(t, queries[1], "HttpStatusCode Int64 CODEC(ZSTD(1)),")
+		require.Contains(t, queries[1], "ENGINE ReplicatedMergeTree('/clickhouse/tables/{shard}/{database}/{table}', '{replica}')")
+		require.Equal(t, "ALTER TABLE otel_traces ON CLUSTER otel_cluster ADD COLUMN IF NOT EXISTS HttpStatusCode Int64 CODEC(ZSTD(1))", queries[2])
+		require.Contains(t, queries[3], "create table IF NOT EXISTS otel_traces_trace_id_ts ON CLUSTER otel_cluster (")
+		require.Contains(t, queries[4], "CREATE MATERIALIZED VIEW IF NOT EXISTS otel_traces_trace_id_ts_mv ON CLUSTER otel_cluster")
+	})
+	t.Run("without trace id timestamp table", func(t *testing.T) {
+		var queries []string
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			queries = append(queries, query)
+			return nil
+		})
+
+		newTestTracesExporter(t, defaultEndpoint, func(cfg *Config) {
+			cfg.TraceIDTimestampTable = false
+		})
+
+		require.Len(t, queries, 1)
+		require.NotContains(t, queries[0], "ON CLUSTER")
+		require.Contains(t, queries[0], "ENGINE MergeTree()")
+	})
+	t.Run("without schema creation", func(t *testing.T) {
+		var queries []string
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			queries = append(queries, query)
+			return nil
+		})
+
+		newTestTracesExporter(t, defaultEndpoint, func(cfg *Config) {
+			cfg.CreateSchema = false
+		})
+
+		require.Empty(t, queries)
+	})
 }
 
 func newTestTracesExporter(t *testing.T, dsn string, fns ...func(*Config)) *tracesExporter {
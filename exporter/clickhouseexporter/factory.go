@@ -43,15 +43,17 @@ func NewFactory() exporter.Factory {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		TimeoutSettings:  exporterhelper.NewDefaultTimeoutSettings(),
-		QueueSettings:    QueueSettings{QueueSize: exporterhelper.NewDefaultQueueSettings().QueueSize},
-		RetrySettings:    exporterhelper.NewDefaultRetrySettings(),
-		ConnectionParams: map[string]string{},
-		Database:         defaultDatabase,
-		LogsTableName:    "otel_logs",
-		TracesTableName:  "otel_traces",
-		MetricsTableName: "otel_metrics",
-		TTLDays:          0,
+		TimeoutSettings:       exporterhelper.NewDefaultTimeoutSettings(),
+		QueueSettings:         QueueSettings{QueueSize: exporterhelper.NewDefaultQueueSettings().QueueSize},
+		RetrySettings:         exporterhelper.NewDefaultRetrySettings(),
+		ConnectionParams:      map[string]string{},
+		Database:              defaultDatabase,
+		LogsTableName:         "otel_logs",
+		TracesTableName:       "otel_traces",
+		MetricsTableName:      "otel_metrics",
+		TTLDays:               0,
+		CreateSchema:          true,
+		TraceIDTimestampTable: true,
 	}
 }
 
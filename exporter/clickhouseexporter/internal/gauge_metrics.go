@@ -28,7 +28,7 @@ import (
 const (
 	// language=ClickHouse SQL
 	createGaugeTableSQL = `
-CREATE TABLE IF NOT EXISTS %s_gauge (
+CREATE TABLE IF NOT EXISTS %s_gauge %s (
     ResourceAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
     ResourceSchemaUrl String CODEC(ZSTD(1)),
     ScopeName String CODEC(ZSTD(1)),
@@ -57,7 +57,7 @@ CREATE TABLE IF NOT EXISTS %s_gauge (
 	INDEX idx_scope_attr_value mapValues(ScopeAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
 	INDEX idx_attr_key mapKeys(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1,
 	INDEX idx_attr_value mapValues(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1
-) ENGINE MergeTree()
+) ENGINE %s
 %s
 PARTITION BY toDate(TimeUnix)
 ORDER BY (MetricName, Attributes, toUnixTimestamp64Nano(TimeUnix))
@@ -28,7 +28,7 @@ import (
 const (
 	// language=ClickHouse SQL
 	createHistogramTableSQL = `
-CREATE TABLE IF NOT EXISTS %s_histogram (
+CREATE TABLE IF NOT EXISTS %s_histogram %s (
     ResourceAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
     ResourceSchemaUrl String CODEC(ZSTD(1)),
     ScopeName String CODEC(ZSTD(1)),
@@ -62,7 +62,7 @@ CREATE TABLE IF NOT EXISTS %s_histogram (
 	INDEX idx_scope_attr_value mapValues(ScopeAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
 	INDEX idx_attr_key mapKeys(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1,
 	INDEX idx_attr_value mapValues(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1
-) ENGINE MergeTree()
+) ENGINE %s
 %s
 PARTITION BY toDate(TimeUnix)
 ORDER BY (MetricName, Attributes, toUnixTimestamp64Nano(TimeUnix))
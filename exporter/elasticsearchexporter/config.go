@@ -21,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/observiq/ctimefmt"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -58,6 +59,21 @@ type Config struct {
 	// This setting is required when traces pipelines used.
 	TracesIndex string `mapstructure:"traces_index"`
 
+	// This setting is required when metrics pipelines used.
+	MetricsIndex string `mapstructure:"metrics_index"`
+
+	// LogsDynamicIndex configures computing the index of each log record from its attributes.
+	LogsDynamicIndex DynamicIndexSetting `mapstructure:"logs_dynamic_index"`
+
+	// TracesDynamicIndex configures computing the index of each span from its attributes.
+	TracesDynamicIndex DynamicIndexSetting `mapstructure:"traces_dynamic_index"`
+
+	// MetricsDynamicIndex configures computing the index of each metrics document from its attributes.
+	MetricsDynamicIndex DynamicIndexSetting `mapstructure:"metrics_dynamic_index"`
+
+	// LogstashFormat configures appending the date of each event to its index name.
+	LogstashFormat LogstashFormatSettings `mapstructure:"logstash_format"`
+
 	// Pipeline configures the ingest node pipeline name that should be used to process the
 	// events.
 	//
@@ -90,6 +106,34 @@ type HTTPClientSettings struct {
 	configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
 }
 
+// DynamicIndexSetting defines how the index of each event is computed from the attributes
+// of the event, its scope and its resource, in this order of precedence.
+type DynamicIndexSetting struct {
+	// Enabled computes the index of each event from its attributes instead of using the configured index.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Mode configures how the index is computed. Valid modes are:
+	//  - data_stream: the index is the data stream `<type>-<dataset>-<namespace>`, where the dataset and the
+	//    namespace are read from the `data_stream.dataset` and `data_stream.namespace` attributes and default
+	//    to `generic` and `default`.
+	//  - prefix_suffix: the configured index is surrounded by the values of the `elasticsearch.index.prefix`
+	//    and `elasticsearch.index.suffix` attributes.
+	Mode string `mapstructure:"mode"`
+}
+
+// LogstashFormatSettings defines the settings of time-suffixed indices, where the date of each event
+// is appended to its index name.
+type LogstashFormatSettings struct {
+	// Enabled appends the date of each event to its index name.
+	Enabled bool `mapstructure:"enabled"`
+
+	// PrefixSeparator is the separator between the index name and the date.
+	PrefixSeparator string `mapstructure:"prefix_separator"`
+
+	// DateFormat is the strftime-like format of the date, for example `%Y.%m.%d`.
+	DateFormat string `mapstructure:"date_format"`
+}
+
 // AuthenticationSettings defines user authentication related settings.
 type AuthenticationSettings struct {
 	// User is used to configure HTTP Basic Authentication.
@@ -226,5 +270,25 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("unknown mapping mode %v", cfg.Mapping.Mode)
 	}
 
+	for name, dynamicIndex := range map[string]DynamicIndexSetting{
+		"logs_dynamic_index":    cfg.LogsDynamicIndex,
+		"traces_dynamic_index":  cfg.TracesDynamicIndex,
+		"metrics_dynamic_index": cfg.MetricsDynamicIndex,
+	} {
+		if dynamicIndex.Enabled && !isValidDynamicIndexMode(dynamicIndex.Mode) {
+			return fmt.Errorf("unknown %s mode %v", name, dynamicIndex.Mode)
+		}
+		// The date would break the <type>-<dataset>-<namespace> naming of data streams and create one per day.
+		if dynamicIndex.Enabled && dynamicIndex.Mode == dynamicIndexModeDataStream && cfg.LogstashFormat.Enabled {
+			return fmt.Errorf("logstash_format can't be enabled with the %s mode %v", name, dynamicIndex.Mode)
+		}
+	}
+
+	if cfg.LogstashFormat.Enabled {
+		if _, err := ctimefmt.ToNative(cfg.LogstashFormat.DateFormat); err != nil {
+			return fmt.Errorf("invalid logstash_format date_format %q: %w", cfg.LogstashFormat.DateFormat, err)
+		}
+	}
+
 	return nil
 }
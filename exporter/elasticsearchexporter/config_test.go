@@ -42,12 +42,21 @@ func TestLoad_DeprecatedIndexConfigOption(t *testing.T) {
 			NumConsumers: 10,
 			QueueSize:    5000,
 		},
-		Endpoints:   []string{"http://localhost:9200"},
-		CloudID:     "TRNMxjXlNJEt",
-		Index:       "my_log_index",
-		LogsIndex:   "logs-generic-default",
-		TracesIndex: "traces-generic-default",
-		Pipeline:    "mypipeline",
+		Endpoints:           []string{"http://localhost:9200"},
+		CloudID:             "TRNMxjXlNJEt",
+		Index:               "my_log_index",
+		LogsIndex:           "logs-generic-default",
+		TracesIndex:         "traces-generic-default",
+		MetricsIndex:        "metrics-generic-default",
+		LogsDynamicIndex:    DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+		TracesDynamicIndex:  DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+		MetricsDynamicIndex: DynamicIndexSetting{Enabled: false, Mode: dynamicIndexModeDataStream},
+		LogstashFormat: LogstashFormatSettings{
+			Enabled:         false,
+			PrefixSeparator: "-",
+			DateFormat:      "%Y.%m.%d",
+		},
+		Pipeline: "mypipeline",
 		HTTPClientSettings: HTTPClientSettings{
 			Authentication: AuthenticationSettings{
 				User:     "elastic",
@@ -104,12 +113,21 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 10,
 					QueueSize:    5000,
 				},
-				Endpoints:   []string{"https://elastic.example.com:9200"},
-				CloudID:     "TRNMxjXlNJEt",
-				Index:       "",
-				LogsIndex:   "logs-generic-default",
-				TracesIndex: "trace_index",
-				Pipeline:    "mypipeline",
+				Endpoints:           []string{"https://elastic.example.com:9200"},
+				CloudID:             "TRNMxjXlNJEt",
+				Index:               "",
+				LogsIndex:           "logs-generic-default",
+				TracesIndex:         "trace_index",
+				MetricsIndex:        "metrics-generic-default",
+				LogsDynamicIndex:    DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+				TracesDynamicIndex:  DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+				MetricsDynamicIndex: DynamicIndexSetting{Enabled: false, Mode: dynamicIndexModeDataStream},
+				LogstashFormat: LogstashFormatSettings{
+					Enabled:         false,
+					PrefixSeparator: "-",
+					DateFormat:      "%Y.%m.%d",
+				},
+				Pipeline: "mypipeline",
 				HTTPClientSettings: HTTPClientSettings{
 					Authentication: AuthenticationSettings{
 						User:     "elastic",
@@ -149,12 +167,75 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 10,
 					QueueSize:    5000,
 				},
-				Endpoints:   []string{"http://localhost:9200"},
-				CloudID:     "TRNMxjXlNJEt",
-				Index:       "",
-				LogsIndex:   "my_log_index",
-				TracesIndex: "traces-generic-default",
-				Pipeline:    "mypipeline",
+				Endpoints:           []string{"http://localhost:9200"},
+				CloudID:             "TRNMxjXlNJEt",
+				Index:               "",
+				LogsIndex:           "my_log_index",
+				TracesIndex:         "traces-generic-default",
+				MetricsIndex:        "metrics-generic-default",
+				LogsDynamicIndex:    DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+				TracesDynamicIndex:  DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+				MetricsDynamicIndex: DynamicIndexSetting{Enabled: false, Mode: dynamicIndexModeDataStream},
+				LogstashFormat: LogstashFormatSettings{
+					Enabled:         false,
+					PrefixSeparator: "-",
+					DateFormat:      "%Y.%m.%d",
+				},
+				Pipeline: "mypipeline",
+				HTTPClientSettings: HTTPClientSettings{
+					Authentication: AuthenticationSettings{
+						User:     "elastic",
+						Password: "search",
+						APIKey:   "AvFsEiPs==",
+					},
+					Timeout: 2 * time.Minute,
+					Headers: map[string]string{
+						"myheader": "test",
+					},
+				},
+				Discovery: DiscoverySettings{
+					OnStart: true,
+				},
+				Flush: FlushSettings{
+					Bytes: 10485760,
+				},
+				Retry: RetrySettings{
+					Enabled:         true,
+					MaxRequests:     5,
+					InitialInterval: 100 * time.Millisecond,
+					MaxInterval:     1 * time.Minute,
+				},
+				Mapping: MappingsSettings{
+					Mode:  "ecs",
+					Dedup: true,
+					Dedot: true,
+				},
+			},
+		},
+		{
+			id:         component.NewIDWithName(typeStr, "metric"),
+			configFile: "config.yaml",
+			expected: &Config{
+				QueueSettings: exporterhelper.QueueSettings{
+					Enabled:      false,
+					NumConsumers: 10,
+					QueueSize:    5000,
+				},
+				Endpoints:           []string{"http://localhost:9200"},
+				CloudID:             "TRNMxjXlNJEt",
+				Index:               "",
+				LogsIndex:           "logs-generic-default",
+				TracesIndex:         "traces-generic-default",
+				MetricsIndex:        "my_metric_index",
+				LogsDynamicIndex:    DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+				TracesDynamicIndex:  DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+				MetricsDynamicIndex: DynamicIndexSetting{Enabled: true, Mode: dynamicIndexModePrefixSuffix},
+				LogstashFormat: LogstashFormatSettings{
+					Enabled:         true,
+					PrefixSeparator: "-",
+					DateFormat:      "%Y.%m.%d",
+				},
+				Pipeline: "mypipeline",
 				HTTPClientSettings: HTTPClientSettings{
 					Authentication: AuthenticationSettings{
 						User:     "elastic",
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"strings"
+	"time"
+
+	"github.com/observiq/ctimefmt"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	dynamicIndexModeDataStream   = "data_stream"
+	dynamicIndexModePrefixSuffix = "prefix_suffix"
+
+	dataStreamDatasetAttribute   = "data_stream.dataset"
+	dataStreamNamespaceAttribute = "data_stream.namespace"
+	indexPrefixAttribute         = "elasticsearch.index.prefix"
+	indexSuffixAttribute         = "elasticsearch.index.suffix"
+
+	defaultDataStreamDataset   = "generic"
+	defaultDataStreamNamespace = "default"
+)
+
+func isValidDynamicIndexMode(mode string) bool {
+	return mode == dynamicIndexModeDataStream || mode == dynamicIndexModePrefixSuffix
+}
+
+// indexRouter computes the index of each event from the dynamic index and logstash format settings.
+type indexRouter struct {
+	// dataStreamType is the type of the data streams, that is logs, traces or metrics.
+	dataStreamType string
+	dynamicIndex   DynamicIndexSetting
+	logstashFormat bool
+	separator      string
+	dateLayout     string
+}
+
+func newIndexRouter(dataStreamType string, dynamicIndex DynamicIndexSetting, logstashFormat LogstashFormatSettings) (*indexRouter, error) {
+	router := &indexRouter{
+		dataStreamType: dataStreamType,
+		dynamicIndex:   dynamicIndex,
+		logstashFormat: logstashFormat.Enabled,
+		separator:      logstashFormat.PrefixSeparator,
+	}
+	if logstashFormat.Enabled {
+		layout, err := ctimefmt.ToNative(logstashFormat.DateFormat)
+		if err != nil {
+			return nil, err
+		}
+		router.dateLayout = layout
+	}
+	return router, nil
+}
+
+// route returns the index of an event with the given timestamp. Attributes are looked up in the
+// given maps in order, so the event attributes should come before the scope and resource ones.
+func (r *indexRouter) route(index string, ts pcommon.Timestamp, attrs ...pcommon.Map) string {
+	if r.dynamicIndex.Enabled {
+		switch r.dynamicIndex.Mode {
+		case dynamicIndexModeDataStream:
+			dataset := sanitizeDataStreamField(lookupAttribute(dataStreamDatasetAttribute, defaultDataStreamDataset, attrs))
+			namespace := sanitizeDataStreamField(lookupAttribute(dataStreamNamespaceAttribute, defaultDataStreamNamespace, attrs))
+			index = r.dataStreamType + "-" + dataset + "-" + namespace
+		case dynamicIndexModePrefixSuffix:
+			index = lookupAttribute(indexPrefixAttribute, "", attrs) + index + lookupAttribute(indexSuffixAttribute, "", attrs)
+		}
+	}
+
+	if r.logstashFormat {
+		t := ts.AsTime()
+		if ts == 0 {
+			t = time.Now()
+		}
+		index += r.separator + t.UTC().Format(r.dateLayout)
+	}
+	return index
+}
+
+func lookupAttribute(key, defaultValue string, attrs []pcommon.Map) string {
+	for _, m := range attrs {
+		if v, ok := m.Get(key); ok && v.AsString() != "" {
+			return v.AsString()
+		}
+	}
+	return defaultValue
+}
+
+// sanitizeDataStreamField makes the value usable as the dataset or namespace of a data stream name,
+// which must be lowercase and can't contain `-` or any character disallowed in index names.
+//
+// https://www.elastic.co/guide/en/ecs/current/ecs-data_stream.html
+func sanitizeDataStreamField(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '\\', '/', '*', '?', '"', '<', '>', '|', ' ', ',', '#', ':':
+			return '_'
+		}
+		return r
+	}, strings.ToLower(value))
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestIndexRouter(t *testing.T) {
+	ts := pcommon.NewTimestampFromTime(time.Date(2023, 4, 5, 23, 0, 0, 0, time.UTC))
+
+	tests := map[string]struct {
+		dynamicIndex   DynamicIndexSetting
+		logstashFormat LogstashFormatSettings
+		attributes     map[string]any
+		resource       map[string]any
+		want           string
+	}{
+		"disabled": {
+			dynamicIndex: DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+			attributes:   map[string]any{dataStreamDatasetAttribute: "nginx"},
+			want:         "logs-index",
+		},
+		"data stream defaults": {
+			dynamicIndex: DynamicIndexSetting{Enabled: true, Mode: dynamicIndexModeDataStream},
+			want:         "logs-generic-default",
+		},
+		"data stream from record and resource": {
+			dynamicIndex: DynamicIndexSetting{Enabled: true, Mode: dynamicIndexModeDataStream},
+			attributes:   map[string]any{dataStreamDatasetAttribute: "nginx.access"},
+			resource:     map[string]any{dataStreamDatasetAttribute: "ignored", dataStreamNamespaceAttribute: "Team-A"},
+			want:         "logs-nginx.access-team_a",
+		},
+		"prefix and suffix": {
+			dynamicIndex: DynamicIndexSetting{Enabled: true, Mode: dynamicIndexModePrefixSuffix},
+			attributes:   map[string]any{indexSuffixAttribute: "-suffix"},
+			resource:     map[string]any{indexPrefixAttribute: "prefix-"},
+			want:         "prefix-logs-index-suffix",
+		},
+		"logstash format": {
+			dynamicIndex:   DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+			logstashFormat: LogstashFormatSettings{Enabled: true, PrefixSeparator: "-", DateFormat: "%Y.%m.%d"},
+			want:           "logs-index-2023.04.05",
+		},
+		"prefix and suffix with logstash format": {
+			dynamicIndex:   DynamicIndexSetting{Enabled: true, Mode: dynamicIndexModePrefixSuffix},
+			logstashFormat: LogstashFormatSettings{Enabled: true, PrefixSeparator: "-", DateFormat: "%Y.%m.%d"},
+			attributes:     map[string]any{indexPrefixAttribute: "prefix-"},
+			want:           "prefix-logs-index-2023.04.05",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			router, err := newIndexRouter("logs", tt.dynamicIndex, tt.logstashFormat)
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMap()
+			require.NoError(t, attributes.FromRaw(tt.attributes))
+			resource := pcommon.NewMap()
+			require.NoError(t, resource.FromRaw(tt.resource))
+			assert.Equal(t, tt.want, router.route("logs-index", ts, attributes, resource))
+		})
+	}
+}
+
+func TestConfig_ValidateDynamicIndex(t *testing.T) {
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoints = []string{"test:9200"}
+		cfg.TracesDynamicIndex = DynamicIndexSetting{Enabled: true, Mode: "unknown"}
+	})
+	assert.EqualError(t, cfg.Validate(), "unknown traces_dynamic_index mode unknown")
+
+	cfg = withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoints = []string{"test:9200"}
+		cfg.LogstashFormat = LogstashFormatSettings{Enabled: true, DateFormat: "%Q"}
+	})
+	assert.ErrorContains(t, cfg.Validate(), "invalid logstash_format date_format")
+
+	cfg = withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoints = []string{"test:9200"}
+		cfg.LogsDynamicIndex = DynamicIndexSetting{Enabled: true, Mode: dynamicIndexModeDataStream}
+		cfg.LogstashFormat.Enabled = true
+	})
+	assert.EqualError(t, cfg.Validate(), "logstash_format can't be enabled with the logs_dynamic_index mode data_stream")
+
+	cfg = withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoints = []string{"test:9200"}
+		cfg.LogsDynamicIndex = DynamicIndexSetting{Enabled: true, Mode: dynamicIndexModePrefixSuffix}
+		cfg.LogstashFormat.Enabled = true
+	})
+	assert.NoError(t, cfg.Validate())
+}
@@ -29,6 +29,9 @@ const (
 	typeStr            = "elasticsearch"
 	defaultLogsIndex   = "logs-generic-default"
 	defaultTracesIndex = "traces-generic-default"
+	// defaultMetricsIndex follows the data stream naming scheme so that metrics can be
+	// routed to a time series data stream.
+	defaultMetricsIndex = "metrics-generic-default"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelBeta
 )
@@ -40,6 +43,7 @@ func NewFactory() exporter.Factory {
 		createDefaultConfig,
 		exporter.WithLogs(createLogsExporter, stability),
 		exporter.WithTraces(createTracesExporter, stability),
+		exporter.WithMetrics(createMetricsExporter, component.StabilityLevelDevelopment),
 	)
 }
 
@@ -51,9 +55,17 @@ func createDefaultConfig() component.Config {
 		HTTPClientSettings: HTTPClientSettings{
 			Timeout: 90 * time.Second,
 		},
-		Index:       "",
-		LogsIndex:   defaultLogsIndex,
-		TracesIndex: defaultTracesIndex,
+		Index:               "",
+		LogsIndex:           defaultLogsIndex,
+		TracesIndex:         defaultTracesIndex,
+		MetricsIndex:        defaultMetricsIndex,
+		LogsDynamicIndex:    DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+		TracesDynamicIndex:  DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+		MetricsDynamicIndex: DynamicIndexSetting{Mode: dynamicIndexModeDataStream},
+		LogstashFormat: LogstashFormatSettings{
+			PrefixSeparator: "-",
+			DateFormat:      "%Y.%m.%d",
+		},
 		Retry: RetrySettings{
 			Enabled:         true,
 			MaxRequests:     3,
@@ -113,3 +125,26 @@ func createTracesExporter(ctx context.Context,
 		exporterhelper.WithShutdown(exporter.Shutdown),
 		exporterhelper.WithQueue(cf.QueueSettings))
 }
+
+// createMetricsExporter creates a new exporter for metrics.
+//
+// Data points sharing the same resource, scope, attributes and timestamp are
+// indexed into Elasticsearch as a single document.
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.CreateSettings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	cf := cfg.(*Config)
+	exporter, err := newMetricsExporter(set.Logger, cf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Elasticsearch metrics exporter: %w", err)
+	}
+	return exporterhelper.NewMetricsExporter(
+		ctx,
+		set,
+		cfg,
+		exporter.pushMetricsData,
+		exporterhelper.WithShutdown(exporter.Shutdown),
+		exporterhelper.WithQueue(cf.QueueSettings))
+}
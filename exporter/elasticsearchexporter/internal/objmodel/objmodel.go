@@ -391,6 +391,12 @@ func ArrValue(values ...Value) Value {
 	return Value{kind: KindArr, arr: values}
 }
 
+// ObjectValue creates a new value from a document. The fields of the document are
+// serialized as a nested object.
+func ObjectValue(doc Document) Value {
+	return Value{kind: KindObject, doc: doc}
+}
+
 // TimestampValue create a new value from a time.Time.
 func TimestampValue(ts time.Time) Value {
 	return Value{kind: KindTimestamp, ts: ts}
@@ -30,6 +30,7 @@ type elasticsearchLogsExporter struct {
 	logger *zap.Logger
 
 	index       string
+	router      *indexRouter
 	maxAttempts int
 
 	client      *esClientCurrent
@@ -64,6 +65,11 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchLogsExporte
 	// TODO: Apply encoding and field mapping settings.
 	model := &encodeModel{dedup: true, dedot: false}
 
+	router, err := newIndexRouter("logs", cfg.LogsDynamicIndex, cfg.LogstashFormat)
+	if err != nil {
+		return nil, err
+	}
+
 	indexStr := cfg.LogsIndex
 	if cfg.Index != "" {
 		indexStr = cfg.Index
@@ -73,6 +79,7 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchLogsExporte
 		client:      client,
 		bulkIndexer: bulkIndexer,
 		index:       indexStr,
+		router:      router,
 		maxAttempts: maxAttempts,
 		model:       model,
 	}
@@ -92,9 +99,10 @@ func (e *elasticsearchLogsExporter) pushLogsData(ctx context.Context, ld plog.Lo
 		resource := rl.Resource()
 		ills := rl.ScopeLogs()
 		for j := 0; j < ills.Len(); j++ {
+			scope := ills.At(j).Scope()
 			logs := ills.At(j).LogRecords()
 			for k := 0; k < logs.Len(); k++ {
-				if err := e.pushLogRecord(ctx, resource, logs.At(k)); err != nil {
+				if err := e.pushLogRecord(ctx, resource, scope, logs.At(k)); err != nil {
 					if cerr := ctx.Err(); cerr != nil {
 						return cerr
 					}
@@ -108,10 +116,16 @@ func (e *elasticsearchLogsExporter) pushLogsData(ctx context.Context, ld plog.Lo
 	return multierr.Combine(errs...)
 }
 
-func (e *elasticsearchLogsExporter) pushLogRecord(ctx context.Context, resource pcommon.Resource, record plog.LogRecord) error {
+func (e *elasticsearchLogsExporter) pushLogRecord(ctx context.Context, resource pcommon.Resource, scope pcommon.InstrumentationScope, record plog.LogRecord) error {
+	ts := record.Timestamp()
+	if ts == 0 {
+		ts = record.ObservedTimestamp()
+	}
+	index := e.router.route(e.index, ts, record.Attributes(), scope.Attributes(), resource.Attributes())
+
 	document, err := e.model.encodeLog(resource, record)
 	if err != nil {
 		return fmt.Errorf("Failed to encode log event: %w", err)
 	}
-	return pushDocuments(ctx, e.logger, e.index, document, e.bulkIndexer, e.maxAttempts)
+	return pushDocuments(ctx, e.logger, index, document, e.bulkIndexer, e.maxAttempts)
 }
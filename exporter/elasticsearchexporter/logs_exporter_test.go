@@ -27,6 +27,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 )
@@ -162,6 +163,28 @@ func TestExporter_PushEvent(t *testing.T) {
 		rec.WaitItems(2)
 	})
 
+	t.Run("publish with dynamic index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+			cfg.LogsDynamicIndex.Enabled = true
+		})
+
+		logs := plog.NewLogs()
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr(dataStreamNamespaceAttribute, "team-a")
+		record := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		record.Attributes().PutStr(dataStreamDatasetAttribute, "nginx.access")
+		require.NoError(t, exporter.pushLogsData(context.TODO(), logs))
+
+		rec.WaitItems(1)
+		assert.JSONEq(t, `{"create":{"_index":"logs-nginx.access-team_a"}}`, string(rec.Items()[0].Action))
+	})
+
 	t.Run("retry http request", func(t *testing.T) {
 		failures := 0
 		rec := newBulkRecorder()
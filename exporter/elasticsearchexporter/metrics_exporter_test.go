@@ -0,0 +1,176 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"context"
+	"encoding/json"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMetricsExporter_New(t *testing.T) {
+	t.Run("no endpoint", func(t *testing.T) {
+		t.Setenv(defaultElasticsearchEnvName, "")
+		exporter, err := newMetricsExporter(zaptest.NewLogger(t), withDefaultConfig())
+		require.ErrorIs(t, err, errConfigNoEndpoint)
+		require.Nil(t, exporter)
+	})
+
+	t.Run("create with metrics_index", func(t *testing.T) {
+		exporter, err := newMetricsExporter(zaptest.NewLogger(t), withDefaultConfig(func(cfg *Config) {
+			cfg.Endpoints = []string{"test:9200"}
+			cfg.MetricsIndex = "metrics-test-default"
+		}))
+		require.NoError(t, err)
+		require.NoError(t, exporter.Shutdown(context.TODO()))
+		assert.Equal(t, "metrics-test-default", exporter.index)
+	})
+}
+
+func TestMetricsExporter_PushMetricsData(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/14759")
+	}
+
+	rec := newBulkRecorder()
+	server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+		rec.Record(docs)
+		return itemsAllOK(docs)
+	})
+
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig()(server.URL))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, exporter.Shutdown(context.TODO()))
+	})
+
+	require.NoError(t, exporter.pushMetricsData(context.TODO(), testMetrics()))
+	rec.WaitItems(3)
+
+	var docs []map[string]interface{}
+	for _, item := range rec.Items() {
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal(item.Document, &doc))
+		docs = append(docs, doc)
+	}
+	require.Len(t, docs, 3)
+
+	// The gauge and the sum share attributes and timestamp, so they are indexed in the same document.
+	hashes := map[interface{}]bool{}
+	for _, doc := range docs {
+		assert.Equal(t, "localhost", doc["Resource.host.name"])
+		assert.Equal(t, "scope", doc["Scope.Name"])
+		hashes[doc[metricNamesHashField]] = true
+		delete(doc, metricNamesHashField)
+	}
+	assert.Len(t, hashes, 3)
+
+	assert.Contains(t, docs, map[string]interface{}{
+		"@timestamp":         "1970-01-01T00:00:01.000000000Z",
+		"Attributes.state":   "idle",
+		"Metrics.cpu.usage":  0.5,
+		"Metrics.cpu.time":   float64(10),
+		"Resource.host.name": "localhost",
+		"Scope.Name":         "scope",
+	})
+	assert.Contains(t, docs, map[string]interface{}{
+		"@timestamp":         "1970-01-01T00:00:01.000000000Z",
+		"Attributes.state":   "user",
+		"Metrics.cpu.time":   float64(20),
+		"Resource.host.name": "localhost",
+		"Scope.Name":         "scope",
+	})
+	assert.Contains(t, docs, map[string]interface{}{
+		"@timestamp": "1970-01-01T00:00:01.000000000Z",
+		"Metrics.latency": map[string]interface{}{
+			"values": []interface{}{0.5, 1.5, 2.5, float64(3)},
+			"counts": []interface{}{float64(1), float64(2), float64(3), float64(4)},
+		},
+		"Metrics.request.duration": map[string]interface{}{
+			"values": []interface{}{-1.5, float64(0), 1.5, float64(3)},
+			"counts": []interface{}{float64(1), float64(2), float64(3), float64(4)},
+		},
+		"Resource.host.name": "localhost",
+		"Scope.Name":         "scope",
+	})
+}
+
+func TestHistogramValueWithoutBuckets(t *testing.T) {
+	dp := pmetric.NewHistogramDataPoint()
+	dp.SetCount(4)
+	_, ok := histogramValue(dp)
+	assert.False(t, ok, "a data point without buckets nor sum has no value")
+
+	dp.SetSum(10)
+	value, ok := histogramValue(dp)
+	require.True(t, ok)
+	assert.Equal(t, histogramFieldValue([]float64{2.5}, []uint64{4}), value)
+}
+
+func testMetrics() pmetric.Metrics {
+	ts := pcommon.NewTimestampFromTime(time.Unix(1, 0))
+
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host.name", "localhost")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("cpu.usage")
+	dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(0.5)
+	dp.Attributes().PutStr("state", "idle")
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("cpu.time")
+	dp = sum.SetEmptySum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(10)
+	dp.Attributes().PutStr("state", "idle")
+	dp = sum.Sum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(20)
+	dp.Attributes().PutStr("state", "user")
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetTimestamp(ts)
+	hdp.SetCount(10)
+	hdp.ExplicitBounds().FromRaw([]float64{1, 2, 3})
+	hdp.BucketCounts().FromRaw([]uint64{1, 2, 3, 4})
+
+	expHistogram := sm.Metrics().AppendEmpty()
+	expHistogram.SetName("request.duration")
+	edp := expHistogram.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetTimestamp(ts)
+	edp.SetCount(10)
+	edp.SetScale(0)
+	edp.SetZeroCount(2)
+	edp.Negative().BucketCounts().FromRaw([]uint64{1})
+	edp.Positive().BucketCounts().FromRaw([]uint64{3, 4})
+
+	return metrics
+}
@@ -16,19 +16,26 @@ package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
+	"hash/fnv"
+	"math"
+	"sort"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
 )
 
 type mappingModel interface {
 	encodeLog(pcommon.Resource, plog.LogRecord) ([]byte, error)
 	encodeSpan(pcommon.Resource, ptrace.Span) ([]byte, error)
+	encodeMetrics(pcommon.Resource, pcommon.InstrumentationScope, pmetric.MetricSlice) ([]*metricDocument, error)
 }
 
 // encodeModel tries to keep the event as close to the original open telemetry semantics as is.
@@ -46,6 +53,9 @@ const (
 	traceIDField   = "traceID"
 	spanIDField    = "spanID"
 	attributeField = "attribute"
+
+	metricsField         = "Metrics"
+	metricNamesHashField = "_metric_names_hash"
 )
 
 func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord) ([]byte, error) {
@@ -96,6 +106,230 @@ func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([
 	return buf.Bytes(), err
 }
 
+// metricDocument accumulates the data points sharing the same timestamp and attributes.
+type metricDocument struct {
+	timestamp  pcommon.Timestamp
+	attributes pcommon.Map
+	names      []string
+	values     []objmodel.Value
+	// body is the serialized document, set once all the data points are added.
+	body []byte
+}
+
+func (d *metricDocument) add(name string, value objmodel.Value) {
+	d.names = append(d.names, name)
+	d.values = append(d.values, value)
+}
+
+// metricNamesHash identifies the set of metrics in the document. Elasticsearch TSDB rejects documents
+// sharing the same dimensions and timestamp, so the hash must be mapped as a dimension to allow
+// documents holding different metrics to coexist.
+func (d *metricDocument) metricNamesHash() string {
+	names := append([]string(nil), d.names...)
+	sort.Strings(names)
+	h := fnv.New64a()
+	for _, name := range names {
+		_, _ = h.Write([]byte(name))
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// encodeMetrics groups the data points of the metrics sharing the same timestamp and attributes
+// into one document, in order to reduce the number of documents and the storage overhead of dimensions.
+func (m *encodeModel) encodeMetrics(resource pcommon.Resource, scope pcommon.InstrumentationScope, metrics pmetric.MetricSlice) ([]*metricDocument, error) {
+	var docs []*metricDocument
+	docsByKey := make(map[string]*metricDocument)
+	addDataPoint := func(ts pcommon.Timestamp, attrs pcommon.Map, name string, value objmodel.Value) {
+		hash := pdatautil.MapHash(attrs)
+		key := string(hash[:]) + ts.String()
+		doc, ok := docsByKey[key]
+		if !ok {
+			doc = &metricDocument{timestamp: ts, attributes: attrs}
+			docsByKey[key] = doc
+			docs = append(docs, doc)
+		}
+		doc.add(name, value)
+	}
+
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		switch metric.Type() {
+		case pmetric.MetricTypeGauge:
+			addNumberDataPoints(metric.Name(), metric.Gauge().DataPoints(), addDataPoint)
+		case pmetric.MetricTypeSum:
+			addNumberDataPoints(metric.Name(), metric.Sum().DataPoints(), addDataPoint)
+		case pmetric.MetricTypeHistogram:
+			dps := metric.Histogram().DataPoints()
+			for j := 0; j < dps.Len(); j++ {
+				dp := dps.At(j)
+				if dp.Count() == 0 {
+					continue
+				}
+				if value, ok := histogramValue(dp); ok {
+					addDataPoint(dp.Timestamp(), dp.Attributes(), metric.Name(), value)
+				}
+			}
+		case pmetric.MetricTypeExponentialHistogram:
+			dps := metric.ExponentialHistogram().DataPoints()
+			for j := 0; j < dps.Len(); j++ {
+				dp := dps.At(j)
+				if dp.Count() == 0 {
+					continue
+				}
+				addDataPoint(dp.Timestamp(), dp.Attributes(), metric.Name(), exponentialHistogramValue(dp))
+			}
+		case pmetric.MetricTypeSummary:
+			dps := metric.Summary().DataPoints()
+			for j := 0; j < dps.Len(); j++ {
+				dp := dps.At(j)
+				addDataPoint(dp.Timestamp(), dp.Attributes(), metric.Name(), summaryValue(dp))
+			}
+		}
+	}
+
+	for _, doc := range docs {
+		var document objmodel.Document
+		document.AddTimestamp("@timestamp", doc.timestamp)
+		document.AddString(metricNamesHashField, doc.metricNamesHash())
+		for i, name := range doc.names {
+			document.Add(metricsField+"."+name, doc.values[i])
+		}
+		document.AddAttributes("Attributes", doc.attributes)
+		document.AddAttributes("Resource", resource.Attributes())
+		document.AddString("Scope.Name", scope.Name())
+		document.AddString("Scope.Version", scope.Version())
+		document.AddAttributes("Scope.Attributes", scope.Attributes())
+
+		if m.dedup {
+			document.Dedup()
+		} else if m.dedot {
+			document.Sort()
+		}
+
+		var buf bytes.Buffer
+		if err := document.Serialize(&buf, m.dedot); err != nil {
+			return nil, err
+		}
+		doc.body = buf.Bytes()
+	}
+	return docs, nil
+}
+
+func addNumberDataPoints(name string, dps pmetric.NumberDataPointSlice,
+	addDataPoint func(pcommon.Timestamp, pcommon.Map, string, objmodel.Value)) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			addDataPoint(dp.Timestamp(), dp.Attributes(), name, objmodel.IntValue(dp.IntValue()))
+		case pmetric.NumberDataPointValueTypeDouble:
+			addDataPoint(dp.Timestamp(), dp.Attributes(), name, objmodel.DoubleValue(dp.DoubleValue()))
+		}
+	}
+}
+
+// histogramValue converts the data point to the Elasticsearch histogram field type, using the
+// midpoint of each non-empty bucket as its representative value.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/histogram.html
+//
+// The data point is skipped when it has neither buckets nor sum, as its measurements can't be represented.
+func histogramValue(dp pmetric.HistogramDataPoint) (objmodel.Value, bool) {
+	bounds := dp.ExplicitBounds()
+	counts := dp.BucketCounts()
+	if bounds.Len() == 0 || counts.Len() != bounds.Len()+1 {
+		if !dp.HasSum() {
+			return objmodel.Value{}, false
+		}
+		// Without buckets all the measurements are represented by their mean.
+		return histogramFieldValue([]float64{dp.Sum() / float64(dp.Count())}, []uint64{dp.Count()}), true
+	}
+
+	values := make([]float64, 0, counts.Len())
+	bucketCounts := make([]uint64, 0, counts.Len())
+	for i := 0; i < counts.Len(); i++ {
+		count := counts.At(i)
+		if count == 0 {
+			continue
+		}
+		var value float64
+		switch {
+		case i == 0:
+			value = bounds.At(0)
+			if value > 0 {
+				value /= 2
+			}
+		case i == bounds.Len():
+			value = bounds.At(i - 1)
+		default:
+			value = bounds.At(i-1) + (bounds.At(i)-bounds.At(i-1))/2
+		}
+		values = append(values, value)
+		bucketCounts = append(bucketCounts, count)
+	}
+	return histogramFieldValue(values, bucketCounts), true
+}
+
+// exponentialHistogramValue converts the data point to the Elasticsearch histogram field type, using the
+// midpoint of each non-empty bucket as its representative value.
+func exponentialHistogramValue(dp pmetric.ExponentialHistogramDataPoint) objmodel.Value {
+	base := math.Ldexp(math.Ln2, -int(dp.Scale()))
+	midpoint := func(index int) float64 {
+		lower := math.Exp(float64(index) * base)
+		upper := math.Exp(float64(index+1) * base)
+		return lower + (upper-lower)/2
+	}
+
+	values := make([]float64, 0)
+	counts := make([]uint64, 0)
+	negative := dp.Negative()
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		if count := negative.BucketCounts().At(i); count != 0 {
+			values = append(values, -midpoint(int(negative.Offset())+i))
+			counts = append(counts, count)
+		}
+	}
+	if dp.ZeroCount() != 0 {
+		values = append(values, 0)
+		counts = append(counts, dp.ZeroCount())
+	}
+	positive := dp.Positive()
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		if count := positive.BucketCounts().At(i); count != 0 {
+			values = append(values, midpoint(int(positive.Offset())+i))
+			counts = append(counts, count)
+		}
+	}
+	return histogramFieldValue(values, counts)
+}
+
+func histogramFieldValue(values []float64, counts []uint64) objmodel.Value {
+	vs := make([]objmodel.Value, len(values))
+	for i, v := range values {
+		vs[i] = objmodel.DoubleValue(v)
+	}
+	cs := make([]objmodel.Value, len(counts))
+	for i, c := range counts {
+		cs[i] = objmodel.IntValue(int64(c))
+	}
+
+	var doc objmodel.Document
+	doc.Add("counts", objmodel.ArrValue(cs...))
+	doc.Add("values", objmodel.ArrValue(vs...))
+	return objmodel.ObjectValue(doc)
+}
+
+// summaryValue converts the data point to the Elasticsearch aggregate_metric_double field type.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/aggregate-metric-double.html
+func summaryValue(dp pmetric.SummaryDataPoint) objmodel.Value {
+	var doc objmodel.Document
+	doc.Add("sum", objmodel.DoubleValue(dp.Sum()))
+	doc.Add("value_count", objmodel.IntValue(int64(dp.Count())))
+	return objmodel.ObjectValue(doc)
+}
+
 func spanLinksToString(spanLinkSlice ptrace.SpanLinkSlice) string {
 	linkArray := make([]map[string]interface{}, 0, spanLinkSlice.Len())
 	for i := 0; i < spanLinkSlice.Len(); i++ {
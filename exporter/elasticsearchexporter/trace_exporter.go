@@ -30,6 +30,7 @@ type elasticsearchTracesExporter struct {
 	logger *zap.Logger
 
 	index       string
+	router      *indexRouter
 	maxAttempts int
 
 	client      *esClientCurrent
@@ -57,6 +58,11 @@ func newTracesExporter(logger *zap.Logger, cfg *Config) (*elasticsearchTracesExp
 		maxAttempts = cfg.Retry.MaxRequests
 	}
 
+	router, err := newIndexRouter("traces", cfg.TracesDynamicIndex, cfg.LogstashFormat)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO: Apply encoding and field mapping settings.
 	model := &encodeModel{dedup: true, dedot: false}
 
@@ -66,6 +72,7 @@ func newTracesExporter(logger *zap.Logger, cfg *Config) (*elasticsearchTracesExp
 		bulkIndexer: bulkIndexer,
 
 		index:       cfg.TracesIndex,
+		router:      router,
 		maxAttempts: maxAttempts,
 		model:       model,
 	}, nil
@@ -86,9 +93,10 @@ func (e *elasticsearchTracesExporter) pushTraceData(
 		resource := il.Resource()
 		scopeSpans := il.ScopeSpans()
 		for j := 0; j < scopeSpans.Len(); j++ {
+			scope := scopeSpans.At(j).Scope()
 			spans := scopeSpans.At(j).Spans()
 			for k := 0; k < spans.Len(); k++ {
-				if err := e.pushTraceRecord(ctx, resource, spans.At(k)); err != nil {
+				if err := e.pushTraceRecord(ctx, resource, scope, spans.At(k)); err != nil {
 					if cerr := ctx.Err(); cerr != nil {
 						return cerr
 					}
@@ -101,10 +109,12 @@ func (e *elasticsearchTracesExporter) pushTraceData(
 	return multierr.Combine(errs...)
 }
 
-func (e *elasticsearchTracesExporter) pushTraceRecord(ctx context.Context, resource pcommon.Resource, span ptrace.Span) error {
+func (e *elasticsearchTracesExporter) pushTraceRecord(ctx context.Context, resource pcommon.Resource, scope pcommon.InstrumentationScope, span ptrace.Span) error {
+	index := e.router.route(e.index, span.StartTimestamp(), span.Attributes(), scope.Attributes(), resource.Attributes())
+
 	document, err := e.model.encodeSpan(resource, span)
 	if err != nil {
 		return fmt.Errorf("Failed to encode trace record: %w", err)
 	}
-	return pushDocuments(ctx, e.logger, e.index, document, e.bulkIndexer, e.maxAttempts)
+	return pushDocuments(ctx, e.logger, index, document, e.bulkIndexer, e.maxAttempts)
 }
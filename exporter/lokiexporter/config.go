@@ -27,6 +27,20 @@ type Config struct {
 	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
 	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// DefaultLabels lists the attributes promoted to labels for every log record,
+	// in addition to the ones set via the "loki.*.labels" hints.
+	DefaultLabels DefaultLabels `mapstructure:"default_labels"`
+
+	// StructuredMetadata sends the attributes that aren't promoted to labels as
+	// structured metadata of the log entries, instead of encoding them in the line.
+	StructuredMetadata bool `mapstructure:"structured_metadata"`
+}
+
+// DefaultLabels defines the resource and log attributes promoted to labels.
+type DefaultLabels struct {
+	Resource   []string `mapstructure:"resource"`
+	Attributes []string `mapstructure:"attributes"`
 }
 
 func (c *Config) Validate() error {
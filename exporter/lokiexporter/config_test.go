@@ -74,6 +74,11 @@ func TestLoadConfigNewExporter(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
+				DefaultLabels: DefaultLabels{
+					Resource:   []string{"service.name", "k8s.namespace.name"},
+					Attributes: []string{"level"},
+				},
+				StructuredMetadata: true,
 			},
 		},
 	}
@@ -23,7 +23,6 @@ import (
 	"net/http"
 	"sync"
 
-	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
@@ -43,19 +42,32 @@ type lokiExporter struct {
 	settings component.TelemetrySettings
 	client   *http.Client
 	wg       sync.WaitGroup
+	opts     []loki.Option
 }
 
 func newExporter(config *Config, settings component.TelemetrySettings) *lokiExporter {
 	settings.Logger.Info("using the new Loki exporter")
 
+	var opts []loki.Option
+	if len(config.DefaultLabels.Resource) > 0 {
+		opts = append(opts, loki.WithDefaultResourceLabels(config.DefaultLabels.Resource...))
+	}
+	if len(config.DefaultLabels.Attributes) > 0 {
+		opts = append(opts, loki.WithDefaultAttributeLabels(config.DefaultLabels.Attributes...))
+	}
+	if config.StructuredMetadata {
+		opts = append(opts, loki.WithStructuredMetadata())
+	}
+
 	return &lokiExporter{
 		config:   config,
 		settings: settings,
+		opts:     opts,
 	}
 }
 
 func (l *lokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
-	requests := loki.LogsToLokiRequests(ld)
+	requests := loki.LogsToLokiRequests(ld, l.opts...)
 
 	var errs error
 	for tenant, request := range requests {
@@ -80,7 +92,7 @@ func (l *lokiExporter) sendPushRequest(ctx context.Context, tenant string, reque
 		)
 	}
 
-	buf, err := encode(pushReq)
+	buf, err := encode(request)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -129,8 +141,12 @@ func (l *lokiExporter) sendPushRequest(ctx context.Context, tenant string, reque
 	return nil
 }
 
-func encode(pb proto.Message) ([]byte, error) {
-	buf, err := proto.Marshal(pb)
+type marshaler interface {
+	Marshal() ([]byte, error)
+}
+
+func encode(pb marshaler) ([]byte, error) {
+	buf, err := pb.Marshal()
 	if err != nil {
 		return nil, err
 	}
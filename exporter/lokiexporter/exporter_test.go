@@ -33,6 +33,8 @@ import (
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
 )
 
 func TestPushLogData(t *testing.T) {
@@ -304,6 +306,111 @@ func TestLogsToLokiRequestWithGroupingByTenant(t *testing.T) {
 	}
 }
 
+func TestPushLogDataWithDefaultLabels(t *testing.T) {
+	actualPushRequest := &push.PushRequest{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encPayload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		decPayload, err := snappy.Decode(nil, encPayload)
+		require.NoError(t, err)
+
+		err = proto.Unmarshal(decPayload, actualPushRequest)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: ts.URL,
+		},
+		DefaultLabels: DefaultLabels{
+			Resource:   []string{"service.name"},
+			Attributes: []string{"level"},
+		},
+	}
+
+	f := NewFactory()
+	exp, err := f.CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	rl.Resource().Attributes().PutStr("region.az", "eu-west-1a")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("payment accepted")
+	lr.Attributes().PutStr("level", "info")
+	lr.Attributes().PutInt("http.status", 200)
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, actualPushRequest.Streams, 1)
+	assert.Equal(t, `{exporter="OTLP", job="checkout", level="info", service_name="checkout"}`, actualPushRequest.Streams[0].Labels)
+
+	require.Len(t, actualPushRequest.Streams[0].Entries, 1)
+	assert.Equal(t, `{"body":"payment accepted","attributes":{"http.status":200},"resources":{"region.az":"eu-west-1a"}}`, actualPushRequest.Streams[0].Entries[0].Line)
+
+	assert.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestPushLogDataWithStructuredMetadata(t *testing.T) {
+	var actualPayload []byte
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encPayload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		actualPayload, err = snappy.Decode(nil, encPayload)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: ts.URL,
+		},
+		DefaultLabels: DefaultLabels{
+			Resource: []string{"service.name"},
+		},
+		StructuredMetadata: true,
+	}
+
+	f := NewFactory()
+	exp, err := f.CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	rl.Resource().Attributes().PutStr("region.az", "eu-west-1a")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("payment accepted")
+	lr.Attributes().PutInt("http.status", 200)
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+
+	// the request is decoded with the push types, which skip the structured metadata
+	actualPushRequest := &push.PushRequest{}
+	require.NoError(t, proto.Unmarshal(actualPayload, actualPushRequest))
+	require.Len(t, actualPushRequest.Streams, 1)
+	assert.Equal(t, `{exporter="OTLP", job="checkout", service_name="checkout"}`, actualPushRequest.Streams[0].Labels)
+	require.Len(t, actualPushRequest.Streams[0].Entries, 1)
+	assert.Equal(t, `{"body":"payment accepted"}`, actualPushRequest.Streams[0].Entries[0].Line)
+
+	expected, err := loki.PushRequest{
+		PushRequest:        actualPushRequest,
+		StructuredMetadata: [][]model.LabelSet{{{"http_status": "200", "region_az": "eu-west-1a"}}},
+	}.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, expected, actualPayload)
+
+	assert.NoError(t, exp.Shutdown(context.Background()))
+}
+
 func TestExporter_encode(t *testing.T) {
 	t.Run("with good proto", func(t *testing.T) {
 		labels := model.LabelSet{
@@ -16,6 +16,7 @@ package prometheusremotewriteexporter // import "github.com/open-telemetry/opent
 
 import (
 	"fmt"
+	"net/url"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -53,6 +54,32 @@ type Config struct {
 
 	// CreatedMetric allows customizing creation of _created metrics
 	CreatedMetric *CreatedMetric `mapstructure:"export_created_metric,omitempty"`
+
+	// SendMetadata allows sending the type, description and unit of the metrics as metric metadata
+	SendMetadata bool `mapstructure:"send_metadata"`
+
+	// Tenant allows routing the metrics of each tenant, read from a resource attribute,
+	// through a dedicated queue and write-ahead log.
+	Tenant *TenantConfig `mapstructure:"tenant,omitempty"`
+}
+
+type TenantConfig struct {
+	// FromAttribute is the resource attribute holding the tenant of the metrics.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// Header is the HTTP header the tenant is sent in. Default is "X-Scope-OrgID".
+	Header string `mapstructure:"header"`
+
+	// DefaultTenant is the tenant of the metrics whose resource doesn't have the attribute.
+	// If empty, these metrics are sent without tenant header.
+	DefaultTenant string `mapstructure:"default_tenant"`
+
+	// Endpoints overrides the endpoint the metrics of a tenant are sent to.
+	Endpoints map[string]string `mapstructure:"endpoints"`
+
+	// MaxTenants is the maximum number of tenants, besides the default tenant, with an exporter of their own.
+	// The metrics of the tenants over the limit are sent as the default tenant. Default is 100.
+	MaxTenants int `mapstructure:"max_tenants"`
 }
 
 type CreatedMetric struct {
@@ -108,5 +135,25 @@ func (cfg *Config) Validate() error {
 			Enabled: false,
 		}
 	}
+
+	if cfg.Tenant != nil {
+		if cfg.Tenant.FromAttribute == "" {
+			return fmt.Errorf("tenant from_attribute must be specified")
+		}
+		for tenant, endpoint := range cfg.Tenant.Endpoints {
+			if _, err := url.ParseRequestURI(endpoint); err != nil {
+				return fmt.Errorf("invalid endpoint of tenant %q: %w", tenant, err)
+			}
+		}
+		if cfg.Tenant.Header == "" {
+			cfg.Tenant.Header = defaultTenantHeader
+		}
+		if cfg.Tenant.MaxTenants < 0 {
+			return fmt.Errorf("tenant max_tenants can't be negative")
+		}
+		if cfg.Tenant.MaxTenants == 0 {
+			cfg.Tenant.MaxTenants = defaultMaxTenants
+		}
+	}
 	return nil
 }
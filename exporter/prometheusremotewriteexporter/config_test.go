@@ -86,6 +86,7 @@ func TestLoadConfig(t *testing.T) {
 					Enabled: true,
 				},
 				CreatedMetric: &CreatedMetric{Enabled: true},
+				SendMetadata:  true,
 			},
 		},
 		{
@@ -96,6 +97,18 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(typeStr, "negative_num_consumers"),
 			errorMessage: "remote write consumer number can't be negative",
 		},
+		{
+			id:           component.NewIDWithName(typeStr, "tenant_missing_attribute"),
+			errorMessage: "tenant from_attribute must be specified",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "tenant_negative_max_tenants"),
+			errorMessage: "tenant max_tenants can't be negative",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "tenant_invalid_endpoint"),
+			errorMessage: `invalid endpoint of tenant "team-a": parse "not a url": invalid URI for request`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -142,3 +155,23 @@ func TestDisabledTargetInfo(t *testing.T) {
 
 	assert.False(t, cfg.(*Config).TargetInfo.Enabled)
 }
+
+func TestTenantConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(typeStr, "tenant").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	assert.Equal(t, &TenantConfig{
+		FromAttribute: "tenant.id",
+		Header:        "X-Scope-OrgID",
+		DefaultTenant: "anonymous",
+		Endpoints:     map[string]string{"team-a": "http://localhost:9999/api/v1/push"},
+		MaxTenants:    10,
+	}, cfg.(*Config).Tenant)
+}
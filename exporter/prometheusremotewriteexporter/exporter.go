@@ -55,6 +55,7 @@ type prwExporter struct {
 
 	wal              *prweWAL
 	exporterSettings prometheusremotewrite.Settings
+	sendMetadata     bool
 }
 
 // newPRWExporter initializes a new prwExporter instance and sets fields accordingly.
@@ -85,6 +86,7 @@ func newPRWExporter(cfg *Config, set exporter.CreateSettings) (*prwExporter, err
 			DisableTargetInfo:   !cfg.TargetInfo.Enabled,
 			ExportCreatedMetric: cfg.CreatedMetric.Enabled,
 		},
+		sendMetadata: cfg.SendMetadata,
 	}
 	if cfg.WAL == nil {
 		return prwe, nil
@@ -141,8 +143,13 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 		if err != nil {
 			err = consumererror.NewPermanent(err)
 		}
+
+		var metadata []prompb.MetricMetadata
+		if prwe.sendMetadata {
+			metadata = prometheusremotewrite.OtelMetricsToMetadata(md, prwe.exporterSettings)
+		}
 		// Call export even if a conversion error, since there may be points that were successfully converted.
-		return multierr.Combine(err, prwe.handleExport(ctx, tsMap))
+		return multierr.Combine(err, prwe.handleExport(ctx, tsMap, metadata))
 	}
 }
 
@@ -158,14 +165,14 @@ func validateAndSanitizeExternalLabels(cfg *Config) (map[string]string, error) {
 	return sanitizedLabels, nil
 }
 
-func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*prompb.TimeSeries) error {
+func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*prompb.TimeSeries, metadata []prompb.MetricMetadata) error {
 	// There are no metrics to export, so return.
 	if len(tsMap) == 0 {
 		return nil
 	}
 
 	// Calls the helper function to convert and batch the TsMap to the desired format
-	requests, err := batchTimeSeries(tsMap, maxBatchByteSize)
+	requests, err := batchTimeSeries(tsMap, metadata, maxBatchByteSize)
 	if err != nil {
 		return err
 	}
@@ -343,6 +343,46 @@ func TestNoMetricsNoError(t *testing.T) {
 	assert.NoError(t, runExportPipeline(nil, serverURL))
 }
 
+func TestPushMetricsSendMetadata(t *testing.T) {
+	var mu sync.Mutex
+	var metadata []prompb.MetricMetadata
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		buf, err := snappy.Decode(nil, body)
+		require.NoError(t, err)
+		var wr prompb.WriteRequest
+		require.NoError(t, proto.Unmarshal(buf, &wr))
+
+		mu.Lock()
+		metadata = append(metadata, wr.Metadata...)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = server.URL
+	cfg.SendMetadata = true
+
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, prwe.Shutdown(context.Background()))
+	}()
+
+	md := getMetricsFromMetricList(validMetrics1[validDoubleGauge], validMetrics2[validDoubleGauge], validMetrics1[validSum])
+	require.NoError(t, prwe.PushMetrics(context.Background(), md))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.ElementsMatch(t, []prompb.MetricMetadata{
+		{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: validDoubleGauge},
+		{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: validSum},
+	}, metadata)
+}
+
 func runExportPipeline(ts *prompb.TimeSeries, endpoint *url.URL) error {
 	// First we will construct a TimeSeries array from the testutils package
 	testmap := make(map[string]*prompb.TimeSeries)
@@ -370,7 +410,7 @@ func runExportPipeline(ts *prompb.TimeSeries, endpoint *url.URL) error {
 		return err
 	}
 
-	return prwe.handleExport(context.Background(), testmap)
+	return prwe.handleExport(context.Background(), testmap, nil)
 }
 
 // Test_PushMetrics checks the number of TimeSeries received by server and the number of metrics dropped is the same as
@@ -912,7 +952,7 @@ func TestWALOnExporterRoundTrip(t *testing.T) {
 		"timeseries1": ts1,
 		"timeseries2": ts2,
 	}
-	errs := prwe.handleExport(ctx, tsMap)
+	errs := prwe.handleExport(ctx, tsMap, nil)
 	assert.NoError(t, errs)
 	// Shutdown after we've written to the WAL. This ensures that our
 	// exported data in-flight will flushed flushed to the WAL before exiting.
@@ -52,6 +52,21 @@ func createMetricsExporter(ctx context.Context, set exporter.CreateSettings,
 		return nil, errors.New("invalid configuration")
 	}
 
+	var exporter exporter.Metrics
+	var err error
+	if prwCfg.Tenant != nil {
+		exporter, err = newTenantMetricsExporter(ctx, set, prwCfg)
+	} else {
+		exporter, err = newMetricsExporter(ctx, set, prwCfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resourcetotelemetry.WrapMetricsExporter(prwCfg.ResourceToTelemetrySettings, exporter), nil
+}
+
+// newMetricsExporter creates an exporter sending all the metrics to the configured endpoint.
+func newMetricsExporter(ctx context.Context, set exporter.CreateSettings, prwCfg *Config) (exporter.Metrics, error) {
 	prwe, err := newPRWExporter(prwCfg, set)
 	if err != nil {
 		return nil, err
@@ -63,10 +78,10 @@ func createMetricsExporter(ctx context.Context, set exporter.CreateSettings,
 	// order for each timeseries. If we shard the incoming metrics
 	// without considering this limitation, we experience
 	// "out of order samples" errors.
-	exporter, err := exporterhelper.NewMetricsExporter(
+	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
-		cfg,
+		prwCfg,
 		prwe.PushMetrics,
 		exporterhelper.WithTimeout(prwCfg.TimeoutSettings),
 		exporterhelper.WithQueue(exporterhelper.QueueSettings{
@@ -78,10 +93,6 @@ func createMetricsExporter(ctx context.Context, set exporter.CreateSettings,
 		exporterhelper.WithStart(prwe.Start),
 		exporterhelper.WithShutdown(prwe.Shutdown),
 	)
-	if err != nil {
-		return nil, err
-	}
-	return resourcetotelemetry.WrapMetricsExporter(prwCfg.ResourceToTelemetrySettings, exporter), nil
 }
 
 func createDefaultConfig() component.Config {
@@ -21,8 +21,8 @@ import (
 	"github.com/prometheus/prometheus/prompb"
 )
 
-// batchTimeSeries splits series into multiple batch write requests.
-func batchTimeSeries(tsMap map[string]*prompb.TimeSeries, maxBatchByteSize int) ([]*prompb.WriteRequest, error) {
+// batchTimeSeries splits series and metadata into multiple batch write requests.
+func batchTimeSeries(tsMap map[string]*prompb.TimeSeries, metadata []prompb.MetricMetadata, maxBatchByteSize int) ([]*prompb.WriteRequest, error) {
 	if len(tsMap) == 0 {
 		return nil, errors.New("invalid tsMap: cannot be empty map")
 	}
@@ -51,6 +51,27 @@ func batchTimeSeries(tsMap map[string]*prompb.TimeSeries, maxBatchByteSize int)
 		requests = append(requests, wrapped)
 	}
 
+	// Like Prometheus, the metadata is sent in requests of its own.
+	var mArray []prompb.MetricMetadata
+	sizeOfCurrentBatch = 0
+	for i := range metadata {
+		sizeOfMetadata := metadata[i].Size()
+
+		if sizeOfCurrentBatch+sizeOfMetadata >= maxBatchByteSize {
+			requests = append(requests, &prompb.WriteRequest{Metadata: mArray})
+
+			mArray = nil
+			sizeOfCurrentBatch = 0
+		}
+
+		mArray = append(mArray, metadata[i])
+		sizeOfCurrentBatch += sizeOfMetadata
+	}
+
+	if len(mArray) != 0 {
+		requests = append(requests, &prompb.WriteRequest{Metadata: mArray})
+	}
+
 	return requests, nil
 }
 
@@ -68,7 +68,7 @@ func Test_batchTimeSeries(t *testing.T) {
 	// run tests
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			requests, err := batchTimeSeries(tt.tsMap, tt.maxBatchByteSize)
+			requests, err := batchTimeSeries(tt.tsMap, nil, tt.maxBatchByteSize)
 			if tt.returnErr {
 				assert.Error(t, err)
 				return
@@ -79,6 +79,30 @@ func Test_batchTimeSeries(t *testing.T) {
 	}
 }
 
+// Test_batchTimeSeriesWithMetadata checks the metadata is sent in requests of its own, batched by byte size.
+func Test_batchTimeSeriesWithMetadata(t *testing.T) {
+	labels := getPromLabels(label11, value11, label12, value12)
+	tsMap := getTimeseriesMap([]*prompb.TimeSeries{getTimeSeries(labels, getSample(floatVal1, msTime1))})
+
+	metadata := []prompb.MetricMetadata{
+		{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "http_requests_total", Help: "Number of requests."},
+		{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "memory_usage_bytes", Help: "Bytes of memory in use.", Unit: "bytes"},
+	}
+
+	requests, err := batchTimeSeries(tsMap, metadata, 300)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(requests))
+	assert.Empty(t, requests[0].Metadata)
+	assert.Empty(t, requests[1].Timeseries)
+	assert.Equal(t, metadata, requests[1].Metadata)
+
+	requests, err = batchTimeSeries(tsMap, metadata, metadata[0].Size()+metadata[1].Size())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(requests))
+	assert.Equal(t, metadata[:1], requests[1].Metadata)
+	assert.Equal(t, metadata[1:], requests[2].Metadata)
+}
+
 // Ensure that before a prompb.WriteRequest is created, that the points per TimeSeries
 // are sorted by Timestamp value, to prevent Prometheus from barfing when it gets poorly
 // sorted values. See issues:
@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTenantHeader = "X-Scope-OrgID"
+	defaultMaxTenants   = 100
+)
+
+var errShutdown = errors.New("shutdown has been called")
+
+// tenantExporter routes the metrics of each tenant to a dedicated exporter, created when the first
+// metrics of the tenant are received, so that tenants have independent queues and write-ahead logs.
+type tenantExporter struct {
+	config   *Config
+	settings exporter.CreateSettings
+
+	mu        sync.Mutex
+	host      component.Host
+	exporters map[string]*tenantMetricsExporter
+}
+
+// tenantMetricsExporter is the exporter of a tenant, usable once ready is closed.
+type tenantMetricsExporter struct {
+	ready chan struct{}
+	exp   exporter.Metrics
+	err   error
+}
+
+func newTenantMetricsExporter(ctx context.Context, set exporter.CreateSettings, cfg *Config) (exporter.Metrics, error) {
+	te := &tenantExporter{
+		config:    cfg,
+		settings:  set,
+		exporters: map[string]*tenantMetricsExporter{},
+	}
+
+	// The timeout, queue and retries are handled by the exporters of the tenants.
+	return exporterhelper.NewMetricsExporter(
+		ctx,
+		set,
+		cfg,
+		te.pushMetrics,
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{}),
+		exporterhelper.WithStart(te.start),
+		exporterhelper.WithShutdown(te.shutdown),
+	)
+}
+
+func (te *tenantExporter) start(_ context.Context, host component.Host) error {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	te.host = host
+	return nil
+}
+
+func (te *tenantExporter) shutdown(ctx context.Context) error {
+	te.mu.Lock()
+	exporters := te.exporters
+	te.exporters = nil
+	te.mu.Unlock()
+
+	var errs error
+	for _, tenantExp := range exporters {
+		<-tenantExp.ready
+		if tenantExp.err == nil {
+			errs = multierr.Append(errs, tenantExp.exp.Shutdown(ctx))
+		}
+	}
+	return errs
+}
+
+func (te *tenantExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var errs error
+	for tenant, tenantMetrics := range splitMetricsByTenant(md, te.config.Tenant) {
+		exp, err := te.exporterForTenant(tenant)
+		if err != nil {
+			errs = multierr.Append(errs, consumererror.NewPermanent(err))
+			continue
+		}
+		errs = multierr.Append(errs, exp.ConsumeMetrics(ctx, tenantMetrics))
+	}
+	return errs
+}
+
+// exporterForTenant returns the exporter of a tenant, creating and starting it if needed. Once max_tenants
+// tenants have an exporter, the exporter of the default tenant is returned for the other tenants.
+func (te *tenantExporter) exporterForTenant(tenant string) (exporter.Metrics, error) {
+	te.mu.Lock()
+	if te.exporters == nil {
+		te.mu.Unlock()
+		return nil, errShutdown
+	}
+	tenantExp, ok := te.exporters[tenant]
+	if !ok && tenant != te.config.Tenant.DefaultTenant && te.tenantCount() >= te.config.Tenant.MaxTenants {
+		te.settings.Logger.Debug("Too many tenants, sending the metrics as the default tenant", zap.String("tenant", tenant))
+		tenant = te.config.Tenant.DefaultTenant
+		tenantExp, ok = te.exporters[tenant]
+	}
+	if ok {
+		te.mu.Unlock()
+		<-tenantExp.ready
+		return tenantExp.exp, tenantExp.err
+	}
+
+	// The exporter is started without holding the lock, the other requests of the tenant wait until it's ready.
+	tenantExp = &tenantMetricsExporter{ready: make(chan struct{})}
+	te.exporters[tenant] = tenantExp
+	host := te.host
+	te.mu.Unlock()
+
+	tenantExp.exp, tenantExp.err = te.startExporter(tenant, host)
+	close(tenantExp.ready)
+	if tenantExp.err != nil {
+		// let the next request of the tenant try again
+		te.mu.Lock()
+		if te.exporters != nil && te.exporters[tenant] == tenantExp {
+			delete(te.exporters, tenant)
+		}
+		te.mu.Unlock()
+	}
+	return tenantExp.exp, tenantExp.err
+}
+
+// tenantCount returns the number of tenants with an exporter, the default tenant excluded. It must be called with
+// mu held.
+func (te *tenantExporter) tenantCount() int {
+	n := len(te.exporters)
+	if _, ok := te.exporters[te.config.Tenant.DefaultTenant]; ok {
+		n--
+	}
+	return n
+}
+
+func (te *tenantExporter) startExporter(tenant string, host component.Host) (exporter.Metrics, error) {
+	// The exporters of the tenants outlive the requests, they are created with a background context.
+	exp, err := newMetricsExporter(context.Background(), te.settings, te.config.forTenant(tenant))
+	if err != nil {
+		return nil, err
+	}
+	if err = exp.Start(context.Background(), host); err != nil {
+		return nil, err
+	}
+	return exp, nil
+}
+
+// forTenant returns the configuration of the exporter of a tenant, sending the tenant header
+// to the endpoint of the tenant and using a write-ahead log of its own.
+func (cfg *Config) forTenant(tenant string) *Config {
+	tenantCfg := *cfg
+	tenantCfg.Tenant = nil
+	if tenant == "" {
+		return &tenantCfg
+	}
+
+	headers := make(map[string]configopaque.String, len(cfg.HTTPClientSettings.Headers)+1)
+	for k, v := range cfg.HTTPClientSettings.Headers {
+		headers[k] = v
+	}
+	headers[cfg.Tenant.Header] = configopaque.String(tenant)
+	tenantCfg.HTTPClientSettings.Headers = headers
+
+	if endpoint, ok := cfg.Tenant.Endpoints[tenant]; ok {
+		tenantCfg.HTTPClientSettings.Endpoint = endpoint
+	}
+
+	if cfg.WAL != nil {
+		walCfg := *cfg.WAL
+		// the tenant is escaped and prefixed to be used as a directory name.
+		walCfg.Directory = filepath.Join(walCfg.Directory, "tenant_"+url.PathEscape(tenant))
+		tenantCfg.WAL = &walCfg
+	}
+	return &tenantCfg
+}
+
+// splitMetricsByTenant groups the resource metrics by the tenant read from their resource attributes.
+func splitMetricsByTenant(md pmetric.Metrics, cfg *TenantConfig) map[string]pmetric.Metrics {
+	tenants := map[string]pmetric.Metrics{}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		tenant := cfg.DefaultTenant
+		if v, ok := rm.Resource().Attributes().Get(cfg.FromAttribute); ok && v.AsString() != "" {
+			tenant = v.AsString()
+		}
+
+		tenantMetrics, ok := tenants[tenant]
+		if !ok {
+			tenantMetrics = pmetric.NewMetrics()
+			tenants[tenant] = tenantMetrics
+		}
+		rm.CopyTo(tenantMetrics.ResourceMetrics().AppendEmpty())
+	}
+	return tenants
+}
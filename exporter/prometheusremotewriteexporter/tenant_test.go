@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// tenantServer records the series received for each tenant.
+type tenantServer struct {
+	mu     sync.Mutex
+	series map[string][]string
+}
+
+func newTenantServer(t *testing.T) (*tenantServer, *httptest.Server) {
+	ts := &tenantServer{series: map[string][]string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		buf, err := snappy.Decode(nil, body)
+		require.NoError(t, err)
+		var wr prompb.WriteRequest
+		require.NoError(t, proto.Unmarshal(buf, &wr))
+
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+		tenant := r.Header.Get("X-Scope-OrgID")
+		for _, s := range wr.Timeseries {
+			for _, l := range s.Labels {
+				if l.Name == "__name__" && l.Value != "target_info" {
+					ts.series[tenant] = append(ts.series[tenant], l.Value)
+				}
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return ts, server
+}
+
+func (ts *tenantServer) received() map[string][]string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.series
+}
+
+func tenantMetrics(tenants ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, tenant := range tenants {
+		rm := md.ResourceMetrics().AppendEmpty()
+		if tenant != "" {
+			rm.Resource().Attributes().PutStr("tenant.id", tenant)
+		}
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("metric_" + tenant)
+		dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		dp.SetDoubleValue(1)
+	}
+	return md
+}
+
+func TestTenantMetricsExporter(t *testing.T) {
+	defaultTenants, defaultServer := newTenantServer(t)
+	overrideTenants, overrideServer := newTenantServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = defaultServer.URL
+	cfg.RemoteWriteQueue.Enabled = false
+	cfg.Tenant = &TenantConfig{
+		FromAttribute: "tenant.id",
+		DefaultTenant: "anonymous",
+		Endpoints:     map[string]string{"team-b": overrideServer.URL},
+	}
+	require.NoError(t, cfg.Validate())
+
+	exp, err := createMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), tenantMetrics("team-a", "team-b", "")))
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), tenantMetrics("team-a")))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	assert.Equal(t, map[string][]string{
+		"team-a":    {"metric_team_a", "metric_team_a"},
+		"anonymous": {"metric"},
+	}, defaultTenants.received())
+	assert.Equal(t, map[string][]string{
+		"team-b": {"metric_team_b"},
+	}, overrideTenants.received())
+
+	assert.Error(t, exp.ConsumeMetrics(context.Background(), tenantMetrics("team-a")))
+}
+
+func TestTenantMetricsExporterMaxTenants(t *testing.T) {
+	tenants, server := newTenantServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = server.URL
+	cfg.RemoteWriteQueue.Enabled = false
+	cfg.Tenant = &TenantConfig{
+		FromAttribute: "tenant.id",
+		DefaultTenant: "anonymous",
+		MaxTenants:    2,
+	}
+	require.NoError(t, cfg.Validate())
+
+	exp, err := createMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), tenantMetrics("team-a", "team-b")))
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), tenantMetrics("", "team-c", "team-a")))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	// the default tenant doesn't count, the metrics of the third tenant are sent as the default tenant
+	assert.Equal(t, map[string][]string{
+		"team-a":    {"metric_team_a", "metric_team_a"},
+		"team-b":    {"metric_team_b"},
+		"anonymous": {"metric", "metric_team_c"},
+	}, sortedSeries(tenants.received()))
+}
+
+func TestTenantMetricsExporterConcurrentStart(t *testing.T) {
+	tenants, server := newTenantServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = server.URL
+	cfg.RemoteWriteQueue.Enabled = false
+	cfg.Tenant = &TenantConfig{FromAttribute: "tenant.id"}
+	require.NoError(t, cfg.Validate())
+
+	exp, err := createMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, exp.ConsumeMetrics(context.Background(), tenantMetrics("team-a", "team-b")))
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	received := tenants.received()
+	assert.Len(t, received["team-a"], 10)
+	assert.Len(t, received["team-b"], 10)
+}
+
+// sortedSeries sorts the series received for each tenant, as the tenants of a request are sent in any order.
+func sortedSeries(series map[string][]string) map[string][]string {
+	for _, s := range series {
+		sort.Strings(s)
+	}
+	return series
+}
+
+func TestSplitMetricsByTenant(t *testing.T) {
+	md := tenantMetrics("team-a", "team-b", "", "team-a")
+
+	tenants := splitMetricsByTenant(md, &TenantConfig{FromAttribute: "tenant.id"})
+	require.Len(t, tenants, 3)
+	assert.Equal(t, 2, tenants["team-a"].ResourceMetrics().Len())
+	assert.Equal(t, 1, tenants["team-b"].ResourceMetrics().Len())
+	assert.Equal(t, 1, tenants[""].ResourceMetrics().Len())
+
+	tenants = splitMetricsByTenant(md, &TenantConfig{FromAttribute: "tenant.id", DefaultTenant: "anonymous"})
+	require.Len(t, tenants, 3)
+	assert.Equal(t, 1, tenants["anonymous"].ResourceMetrics().Len())
+}
+
+func TestConfigForTenant(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = "http://localhost:9009/api/v1/push"
+	cfg.HTTPClientSettings.Headers = map[string]configopaque.String{"Authorization": "token"}
+	cfg.WAL = &WALConfig{Directory: "wal"}
+	cfg.Tenant = &TenantConfig{
+		FromAttribute: "tenant.id",
+		Header:        "X-Tenant",
+		Endpoints:     map[string]string{"team-b": "http://team-b:9009/api/v1/push"},
+	}
+
+	tenantCfg := cfg.forTenant("team/a")
+	assert.Nil(t, tenantCfg.Tenant)
+	assert.Equal(t, "http://localhost:9009/api/v1/push", tenantCfg.HTTPClientSettings.Endpoint)
+	assert.Equal(t, map[string]configopaque.String{"Authorization": "token", "X-Tenant": "team/a"}, tenantCfg.HTTPClientSettings.Headers)
+	assert.Equal(t, filepath.Join("wal", "tenant_team%2Fa"), tenantCfg.WAL.Directory)
+
+	tenantCfg = cfg.forTenant("team-b")
+	assert.Equal(t, "http://team-b:9009/api/v1/push", tenantCfg.HTTPClientSettings.Endpoint)
+
+	tenantCfg = cfg.forTenant("")
+	assert.Equal(t, map[string]configopaque.String{"Authorization": "token"}, tenantCfg.HTTPClientSettings.Headers)
+	assert.Equal(t, "wal", tenantCfg.WAL.Directory)
+
+	// the configuration of the exporter is left untouched.
+	assert.Equal(t, map[string]configopaque.String{"Authorization": "token"}, cfg.HTTPClientSettings.Headers)
+	assert.Equal(t, "wal", cfg.WAL.Directory)
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 )
 
@@ -48,6 +49,13 @@ type Config struct {
 
 	// CheckCollectorPipeline contains the list of settings of collector pipeline health check
 	CheckCollectorPipeline checkCollectorPipelineSettings `mapstructure:"check_collector_pipeline"`
+
+	// ComponentHealth contains the settings of the per-component health report.
+	ComponentHealth componentHealthSettings `mapstructure:"component_health"`
+
+	// GRPC configures an optional server implementing the gRPC health checking
+	// protocol. The server is not started when this is nil.
+	GRPC *configgrpc.GRPCServerSettings `mapstructure:"grpc"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -55,6 +63,8 @@ var (
 	errNoEndpointProvided                      = errors.New("bad config: endpoint must be specified")
 	errInvalidExporterFailureThresholdProvided = errors.New("bad config: exporter_failure_threshold expects a positive number")
 	errInvalidPath                             = errors.New("bad config: path must start with /")
+	errInvalidComponentFailureThreshold        = errors.New("bad config: component_health::failure_threshold expects a positive number")
+	errNoGRPCEndpointProvided                  = errors.New("bad config: grpc::endpoint must be specified")
 )
 
 // Validate checks if the extension configuration is valid
@@ -72,6 +82,15 @@ func (cfg *Config) Validate() error {
 	if !strings.HasPrefix(cfg.Path, "/") {
 		return errInvalidPath
 	}
+	if _, err = time.ParseDuration(cfg.ComponentHealth.Interval); err != nil {
+		return err
+	}
+	if cfg.ComponentHealth.FailureThreshold <= 0 {
+		return errInvalidComponentFailureThreshold
+	}
+	if cfg.GRPC != nil && cfg.GRPC.NetAddr.Endpoint == "" {
+		return errNoGRPCEndpointProvided
+	}
 	return nil
 }
 
@@ -83,3 +102,24 @@ type checkCollectorPipelineSettings struct {
 	// ExporterFailureThreshold is the threshold of exporter failure numbers during the Interval
 	ExporterFailureThreshold int `mapstructure:"exporter_failure_threshold"`
 }
+
+type componentHealthSettings struct {
+	// Enabled indicates whether the health path reports the status of each
+	// pipeline and component as JSON.
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is the time between two evaluations of the component status.
+	Interval string `mapstructure:"interval"`
+	// FailureThreshold is the number of consecutive failed operations (sends of exporters,
+	// receive or scrape operations of receivers) after which a component is reported as unhealthy.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	// Pipelines lists the receivers and exporters of the collector pipelines by pipeline ID.
+	// When empty, pipelines are reported per data type. Their exporters are checked against the
+	// exporters of the collector at start, and mismatches are logged.
+	Pipelines map[component.ID]pipelineSettings `mapstructure:"pipelines"`
+}
+
+// pipelineSettings lists the receivers and exporters of a pipeline.
+type pipelineSettings struct {
+	Receivers []component.ID `mapstructure:"receivers"`
+	Exporters []component.ID `mapstructure:"exporters"`
+}
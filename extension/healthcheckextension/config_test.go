@@ -21,7 +21,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 )
@@ -52,10 +54,38 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentHealth:        defaultComponentHealthSettings(),
 				Path:                   "/",
 				ResponseBody:           nil,
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "componenthealth"),
+			expected: &Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:13",
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentHealth: componentHealthSettings{
+					Enabled:          true,
+					Interval:         "10s",
+					FailureThreshold: 2,
+					Pipelines: map[component.ID]pipelineSettings{
+						component.NewIDWithName("traces", "backend"): {
+							Receivers: []component.ID{component.NewID("otlp")},
+							Exporters: []component.ID{component.NewIDWithName("otlp", "backend")},
+						},
+					},
+				},
+				GRPC: &configgrpc.GRPCServerSettings{
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "localhost:14",
+						Transport: "tcp",
+					},
+				},
+				Path: "/",
+			},
+		},
 		{
 			id:          component.NewIDWithName(typeStr, "missingendpoint"),
 			expectedErr: errNoEndpointProvided,
@@ -68,6 +98,14 @@ func TestLoadConfig(t *testing.T) {
 			id:          component.NewIDWithName(typeStr, "invalidpath"),
 			expectedErr: errInvalidPath,
 		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalidcomponentthreshold"),
+			expectedErr: errInvalidComponentFailureThreshold,
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "missinggrpcendpoint"),
+			expectedErr: errNoGRPCEndpointProvided,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -49,6 +49,7 @@ func createDefaultConfig() component.Config {
 			Endpoint: defaultEndpoint,
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentHealth:        defaultComponentHealthSettings(),
 		Path:                   "/",
 	}
 }
@@ -67,3 +68,12 @@ func defaultCheckCollectorPipelineSettings() checkCollectorPipelineSettings {
 		ExporterFailureThreshold: 5,
 	}
 }
+
+// defaultComponentHealthSettings returns the default settings for ComponentHealth.
+func defaultComponentHealthSettings() componentHealthSettings {
+	return componentHealthSettings{
+		Enabled:          false,
+		Interval:         "30s",
+		FailureThreshold: 3,
+	}
+}
@@ -34,6 +34,7 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 			Endpoint: defaultEndpoint,
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentHealth:        defaultComponentHealthSettings(),
 		Path:                   "/",
 	}, cfg)
 
@@ -16,6 +16,7 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -26,16 +27,23 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type healthCheckExtension struct {
-	config   Config
-	logger   *zap.Logger
-	state    *healthcheck.HealthCheck
-	server   *http.Server
-	stopCh   chan struct{}
-	exporter *healthCheckExporter
-	settings component.TelemetrySettings
+	config      Config
+	logger      *zap.Logger
+	state       *healthcheck.HealthCheck
+	server      *http.Server
+	stopCh      chan struct{}
+	exporter    *healthCheckExporter
+	tracker     *statusTracker
+	trackerDone chan struct{}
+	grpcServer  *grpc.Server
+	grpcHealth  *health.Server
+	settings    component.TelemetrySettings
 }
 
 var _ extension.PipelineWatcher = (*healthCheckExtension)(nil)
@@ -43,20 +51,42 @@ var _ extension.PipelineWatcher = (*healthCheckExtension)(nil)
 func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) error {
 
 	hc.logger.Info("Starting health_check extension", zap.Any("config", hc.config))
+	if hc.config.ComponentHealth.Enabled {
+		if err := hc.startStatusTracker(host); err != nil {
+			return err
+		}
+	}
+
+	if hc.config.GRPC != nil {
+		if err := hc.startGRPCServer(host); err != nil {
+			hc.stopStatusTracker()
+			return err
+		}
+	}
+
 	ln, err := hc.config.ToListener()
 	if err != nil {
+		hc.stopGRPCServer()
+		hc.stopStatusTracker()
 		return fmt.Errorf("failed to bind to address %s: %w", hc.config.Endpoint, err)
 	}
 
 	hc.server, err = hc.config.ToServer(host, hc.settings, nil)
 	if err != nil {
+		_ = ln.Close()
+		hc.stopGRPCServer()
+		hc.stopStatusTracker()
 		return err
 	}
 
 	if !hc.config.CheckCollectorPipeline.Enabled {
 		// Mount HC handler
 		mux := http.NewServeMux()
-		mux.Handle(hc.config.Path, hc.baseHandler())
+		if hc.tracker != nil {
+			mux.Handle(hc.config.Path, hc.componentHealthHandler())
+		} else {
+			mux.Handle(hc.config.Path, hc.baseHandler())
+		}
 		hc.server.Handler = mux
 		hc.stopCh = make(chan struct{})
 		go func() {
@@ -81,7 +111,11 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 		ticker := time.NewTicker(time.Second)
 
 		mux := http.NewServeMux()
-		mux.Handle(hc.config.Path, hc.checkCollectorPipelineHandler())
+		if hc.tracker != nil {
+			mux.Handle(hc.config.Path, hc.componentHealthHandler())
+		} else {
+			mux.Handle(hc.config.Path, hc.checkCollectorPipelineHandler())
+		}
 		hc.server.Handler = mux
 		hc.stopCh = make(chan struct{})
 		go func() {
@@ -142,11 +176,157 @@ func (hc *healthCheckExtension) checkCollectorPipelineHandler() http.Handler {
 	})
 }
 
+// componentHealthHandler serves the per-component health report as JSON
+func (hc *healthCheckExtension) componentHealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		report := hc.report()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case report.Status == statusHealthy:
+			w.WriteHeader(http.StatusOK)
+		case report.Status == statusStarting:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
 func (hc *healthCheckExtension) check() bool {
 	return hc.exporter.checkHealthStatus(hc.config.CheckCollectorPipeline.ExporterFailureThreshold)
 }
 
+// report returns the health report of the collector, combining the pipeline readiness
+// with the status of the collector pipeline check when it is enabled.
+func (hc *healthCheckExtension) report() healthReport {
+	report := hc.tracker.report(hc.state.Get() == healthcheck.Ready)
+	if report.Status == statusHealthy && hc.exporter != nil && !hc.check() {
+		report.Status = statusUnhealthy
+	}
+	return report
+}
+
+// startStatusTracker registers the view exporter used to derive the status of the components
+// and periodically evaluates it.
+func (hc *healthCheckExtension) startStatusTracker(host component.Host) error {
+	interval, err := time.ParseDuration(hc.config.ComponentHealth.Interval)
+	if err != nil {
+		return err
+	}
+
+	tracker := newStatusTracker(hc.config.ComponentHealth.FailureThreshold)
+	// The configured pipelines are copied from the collector configuration, so they can drift from it.
+	for _, mismatch := range checkPipelines(hc.config.ComponentHealth.Pipelines, host.GetExporters()) {
+		hc.logger.Warn("component_health pipelines don't match the collector pipelines", zap.String("mismatch", mismatch))
+	}
+	tracker.setPipelines(hc.config.ComponentHealth.Pipelines, host.GetExporters())
+	if err = tracker.register(); err != nil {
+		return err
+	}
+	hc.tracker = tracker
+
+	done := make(chan struct{})
+	hc.trackerDone = done
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				hc.tracker.evaluate(readQueueUsage())
+				hc.updateGRPCStatus()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// startGRPCServer starts a server implementing the gRPC health checking protocol.
+func (hc *healthCheckExtension) startGRPCServer(host component.Host) error {
+	ln, err := hc.config.GRPC.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", hc.config.GRPC.NetAddr.Endpoint, err)
+	}
+
+	hc.grpcServer, err = hc.config.GRPC.ToServer(host, hc.settings)
+	if err != nil {
+		_ = ln.Close()
+		return err
+	}
+	hc.grpcHealth = health.NewServer()
+	healthpb.RegisterHealthServer(hc.grpcServer, hc.grpcHealth)
+	hc.updateGRPCStatus()
+
+	go func() {
+		if errGRPC := hc.grpcServer.Serve(ln); errGRPC != nil && !errors.Is(errGRPC, grpc.ErrServerStopped) {
+			host.ReportFatalError(errGRPC)
+		}
+	}()
+	return nil
+}
+
+// updateGRPCStatus publishes the current status to the gRPC health server. The empty service
+// name reports the overall status, pipelines are reported by pipeline ID and components as
+// "<kind>/<id>".
+func (hc *healthCheckExtension) updateGRPCStatus() {
+	if hc.grpcHealth == nil {
+		return
+	}
+
+	servingStatus := func(healthy bool) healthpb.HealthCheckResponse_ServingStatus {
+		if healthy {
+			return healthpb.HealthCheckResponse_SERVING
+		}
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	if hc.tracker == nil {
+		healthy := hc.state.Get() == healthcheck.Ready && (hc.exporter == nil || hc.check())
+		hc.grpcHealth.SetServingStatus("", servingStatus(healthy))
+		return
+	}
+
+	report := hc.report()
+	statuses := map[string]healthStatus{"": report.Status}
+	for name, ps := range report.Pipelines {
+		statuses[name] = ps.Status
+	}
+	for id, cs := range report.Receivers {
+		statuses[kindReceiver+"/"+id] = cs.Status
+	}
+	for id, cs := range report.Exporters {
+		statuses[kindExporter+"/"+id] = cs.Status
+	}
+	for service, status := range statuses {
+		hc.grpcHealth.SetServingStatus(service, servingStatus(status == statusHealthy))
+	}
+}
+
+// stopStatusTracker stops the evaluation of the component status and unregisters its views.
+func (hc *healthCheckExtension) stopStatusTracker() {
+	if hc.trackerDone == nil {
+		return
+	}
+	close(hc.trackerDone)
+	hc.trackerDone = nil
+	hc.tracker.unregister()
+}
+
+func (hc *healthCheckExtension) stopGRPCServer() {
+	if hc.grpcServer == nil {
+		return
+	}
+	hc.grpcHealth.Shutdown()
+	hc.grpcServer.Stop()
+	hc.grpcServer = nil
+}
+
 func (hc *healthCheckExtension) Shutdown(context.Context) error {
+	hc.stopStatusTracker()
+	hc.stopGRPCServer()
 	if hc.server == nil {
 		return nil
 	}
@@ -159,11 +339,13 @@ func (hc *healthCheckExtension) Shutdown(context.Context) error {
 
 func (hc *healthCheckExtension) Ready() error {
 	hc.state.Set(healthcheck.Ready)
+	hc.updateGRPCStatus()
 	return nil
 }
 
 func (hc *healthCheckExtension) NotReady() error {
 	hc.state.Set(healthcheck.Unavailable)
+	hc.updateGRPCStatus()
 	return nil
 }
 
@@ -16,6 +16,7 @@ package healthcheckextension
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net"
 	"net/http"
@@ -28,7 +29,12 @@ import (
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 )
@@ -343,6 +349,90 @@ func TestHealthCheckExtensionUsage(t *testing.T) {
 	}
 }
 
+// exportersHost implements a component.Host that returns a fixed set of exporters.
+type exportersHost struct {
+	component.Host
+	exporters map[component.DataType]map[component.ID]component.Component
+}
+
+func (h *exportersHost) GetExporters() map[component.DataType]map[component.ID]component.Component {
+	return h.exporters
+}
+
+func TestHealthCheckExtensionComponentHealth(t *testing.T) {
+	config := Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentHealth: componentHealthSettings{
+			Enabled:          true,
+			Interval:         "1h",
+			FailureThreshold: 1,
+		},
+		GRPC: &configgrpc.GRPCServerSettings{
+			NetAddr: confignet.NetAddr{
+				Endpoint:  testutil.GetAvailableLocalAddress(t),
+				Transport: "tcp",
+			},
+		},
+		Path: "/",
+	}
+	host := &exportersHost{
+		Host: newAssertNoErrorHost(t),
+		exporters: map[component.DataType]map[component.ID]component.Component{
+			component.DataTypeTraces: {component.NewID("otlp"): nil},
+		},
+	}
+
+	hcExt := newServer(config, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, hcExt.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, hcExt.Shutdown(context.Background())) })
+	require.Eventuallyf(t, ensureServerRunning(config.Endpoint), 30*time.Second, 1*time.Second, "Failed to start the testing server.")
+
+	conn, err := grpc.Dial(config.GRPC.NetAddr.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+	grpcClient := healthpb.NewHealthClient(conn)
+
+	check := func(expectedStatusCode int, expectedStatus healthStatus) healthReport {
+		resp, err := http.Get("http://" + config.Endpoint + config.Path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, expectedStatusCode, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		var report healthReport
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+		assert.Equal(t, expectedStatus, report.Status)
+		return report
+	}
+	grpcCheck := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := grpcClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.Status
+	}
+
+	check(http.StatusServiceUnavailable, statusStarting)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, grpcCheck(""))
+
+	require.NoError(t, hcExt.Ready())
+	report := check(http.StatusOK, statusHealthy)
+	assert.Equal(t, statusHealthy, report.Pipelines["traces"].Status)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, grpcCheck(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, grpcCheck("exporter/otlp"))
+
+	recordOperations(t, "exporter/send_failed_spans", "exporter", "otlp", 1)
+	exportViews(t, hcExt.tracker)
+	hcExt.tracker.evaluate(nil)
+	hcExt.updateGRPCStatus()
+	report = check(http.StatusInternalServerError, statusUnhealthy)
+	assert.Equal(t, statusUnhealthy, report.Exporters["otlp"].Status)
+	assert.Equal(t, statusUnhealthy, report.Pipelines["traces"].Status)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, grpcCheck(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, grpcCheck("traces"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, grpcCheck("exporter/otlp"))
+}
+
 func TestHealthCheckExtensionPortAlreadyInUse(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 
@@ -366,6 +456,39 @@ func TestHealthCheckExtensionPortAlreadyInUse(t *testing.T) {
 	require.Error(t, hcExt.Start(context.Background(), mh))
 }
 
+func TestHealthCheckExtensionGRPCPortAlreadyInUse(t *testing.T) {
+	grpcEndpoint := testutil.GetAvailableLocalAddress(t)
+	ln, err := net.Listen("tcp", grpcEndpoint)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	config := Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentHealth: componentHealthSettings{
+			Enabled:          true,
+			Interval:         "1h",
+			FailureThreshold: 1,
+		},
+		GRPC: &configgrpc.GRPCServerSettings{
+			NetAddr: confignet.NetAddr{
+				Endpoint:  grpcEndpoint,
+				Transport: "tcp",
+			},
+		},
+	}
+	hcExt := newServer(config, componenttest.NewNopTelemetrySettings())
+	require.Error(t, hcExt.Start(context.Background(), newAssertNoErrorHost(t)))
+
+	// The status tracker started before the gRPC server is torn down.
+	assert.Nil(t, hcExt.trackerDone)
+	for _, v := range hcExt.tracker.ocViews() {
+		assert.Nil(t, view.Find(v.Name))
+	}
+}
+
 func TestHealthCheckMultipleStarts(t *testing.T) {
 	config := Config{
 		HTTPServerSettings: confighttp.HTTPServerSettings{
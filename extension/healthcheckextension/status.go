@@ -0,0 +1,477 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"go.opencensus.io/metric/metricproducer"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+)
+
+type healthStatus string
+
+const (
+	statusStarting  healthStatus = "starting"
+	statusHealthy   healthStatus = "healthy"
+	statusUnhealthy healthStatus = "unhealthy"
+
+	kindReceiver = "receiver"
+	kindExporter = "exporter"
+
+	queueSizeMetric     = "exporter/queue_size"
+	queueCapacityMetric = "exporter/queue_capacity"
+)
+
+var (
+	tagKeyReceiver = tag.MustNewKey("receiver")
+	tagKeyExporter = tag.MustNewKey("exporter")
+)
+
+// operationMeasure is an obsreport measure of the number of items a component failed to process.
+// It is recorded once for every operation of the component, with 0 for successful operations,
+// so it tells the outcome of each send, receive or scrape.
+type operationMeasure struct {
+	kind     string
+	dataType component.DataType
+	tagKey   tag.Key
+	measure  *stats.Int64Measure
+}
+
+// operationMeasures lists the measures used to derive the status of components. stats.Int64 returns
+// the measures registered by the collector under the same names.
+var operationMeasures = []operationMeasure{
+	{kind: kindExporter, dataType: component.DataTypeTraces, tagKey: tagKeyExporter,
+		measure: stats.Int64("exporter/send_failed_spans", "Number of spans in failed attempts to send to destination.", stats.UnitDimensionless)},
+	{kind: kindExporter, dataType: component.DataTypeMetrics, tagKey: tagKeyExporter,
+		measure: stats.Int64("exporter/send_failed_metric_points", "Number of metric points in failed attempts to send to destination.", stats.UnitDimensionless)},
+	{kind: kindExporter, dataType: component.DataTypeLogs, tagKey: tagKeyExporter,
+		measure: stats.Int64("exporter/send_failed_log_records", "Number of log records in failed attempts to send to destination.", stats.UnitDimensionless)},
+	{kind: kindReceiver, dataType: component.DataTypeTraces, tagKey: tagKeyReceiver,
+		measure: stats.Int64("receiver/refused_spans", "Number of spans that could not be pushed into the pipeline.", stats.UnitDimensionless)},
+	{kind: kindReceiver, dataType: component.DataTypeMetrics, tagKey: tagKeyReceiver,
+		measure: stats.Int64("receiver/refused_metric_points", "Number of metric points that could not be pushed into the pipeline.", stats.UnitDimensionless)},
+	{kind: kindReceiver, dataType: component.DataTypeLogs, tagKey: tagKeyReceiver,
+		measure: stats.Int64("receiver/refused_log_records", "Number of log records that could not be pushed into the pipeline.", stats.UnitDimensionless)},
+	{kind: kindReceiver, dataType: component.DataTypeMetrics, tagKey: tagKeyReceiver,
+		measure: stats.Int64("scraper/errored_metric_points", "Number of metric points that were unable to be scraped.", stats.UnitDimensionless)},
+}
+
+// trackerSeq makes the names of the views registered by each status tracker unique.
+var trackerSeq atomic.Int64
+
+// operationView is a view registered by the status tracker on one of the operationMeasures.
+// The outcomes view counts the successful and failed operations, the last view holds the
+// number of failed items of the last operation.
+type operationView struct {
+	operationMeasure
+	last bool
+}
+
+// queueUsage is the current size and capacity of the sending queue of an exporter.
+type queueUsage struct {
+	size     int64
+	capacity int64
+}
+
+// componentStatus is the status of a single receiver or exporter.
+type componentStatus struct {
+	Status              healthStatus `json:"status"`
+	Error               string       `json:"error,omitempty"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	Pipelines           []string     `json:"pipelines,omitempty"`
+}
+
+// pipelineStatus is the status of a pipeline and its components.
+type pipelineStatus struct {
+	Status    healthStatus `json:"status"`
+	Receivers []string     `json:"receivers"`
+	Exporters []string     `json:"exporters"`
+}
+
+// healthReport is the JSON document served at the health path when component health is enabled.
+type healthReport struct {
+	Status    healthStatus               `json:"status"`
+	Pipelines map[string]pipelineStatus  `json:"pipelines"`
+	Receivers map[string]componentStatus `json:"receivers"`
+	Exporters map[string]componentStatus `json:"exporters"`
+}
+
+// operationCounts holds the outcomes of the operations of a component recorded by one measure.
+type operationCounts struct {
+	// succeeded and failed are the latest cumulative counts of operations, evaluatedSucceeded
+	// and evaluatedFailed the counts seen at the previous evaluation.
+	succeeded          int64
+	failed             int64
+	evaluatedSucceeded int64
+	evaluatedFailed    int64
+	lastFailed         bool
+	// consecutiveFailures is the number of failed operations since the last successful one.
+	consecutiveFailures int
+}
+
+type trackedComponent struct {
+	pipelines  map[string]struct{}
+	operations map[string]*operationCounts
+	failures   int
+	// succeeded is set once the component completed an operation successfully.
+	succeeded bool
+	queueFull bool
+}
+
+func newTrackedComponent() *trackedComponent {
+	return &trackedComponent{
+		pipelines:  map[string]struct{}{},
+		operations: map[string]*operationCounts{},
+	}
+}
+
+func (c *trackedComponent) operation(measure string) *operationCounts {
+	oc, ok := c.operations[measure]
+	if !ok {
+		oc = &operationCounts{}
+		c.operations[measure] = oc
+	}
+	return oc
+}
+
+// statusTracker implements the open census view exporter interface and derives the
+// status of receivers and exporters from the outcome of their operations.
+type statusTracker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	views            map[string]operationView
+	components       map[string]map[string]*trackedComponent
+	// linkByDataType attaches receivers to the pipeline of the data types they report
+	// when pipelines aren't configured.
+	linkByDataType bool
+}
+
+var _ view.Exporter = (*statusTracker)(nil)
+
+func newStatusTracker(failureThreshold int) *statusTracker {
+	st := &statusTracker{
+		failureThreshold: failureThreshold,
+		views:            map[string]operationView{},
+		components: map[string]map[string]*trackedComponent{
+			kindReceiver: {},
+			kindExporter: {},
+		},
+	}
+	seq := trackerSeq.Add(1)
+	for _, m := range operationMeasures {
+		st.views[fmt.Sprintf("health_check/%d/%s", seq, m.measure.Name())] = operationView{operationMeasure: m}
+		st.views[fmt.Sprintf("health_check/%d/%s/last", seq, m.measure.Name())] = operationView{operationMeasure: m, last: true}
+	}
+	return st
+}
+
+// ocViews returns the views to register for the tracker to receive the outcome of the operations.
+func (st *statusTracker) ocViews() []*view.View {
+	var views []*view.View
+	for name, ov := range st.views {
+		// A failed operation has at least 1 failed item, the first bucket counts the successful ones.
+		aggregation := view.Distribution(1)
+		if ov.last {
+			aggregation = view.LastValue()
+		}
+		views = append(views, &view.View{
+			Name:        name,
+			Measure:     ov.measure,
+			TagKeys:     []tag.Key{ov.tagKey},
+			Aggregation: aggregation,
+		})
+	}
+	return views
+}
+
+// register starts receiving the outcome of the operations of the components.
+func (st *statusTracker) register() error {
+	if err := view.Register(st.ocViews()...); err != nil {
+		return fmt.Errorf("failed to register the component health views: %w", err)
+	}
+	view.RegisterExporter(st)
+	return nil
+}
+
+func (st *statusTracker) unregister() {
+	view.UnregisterExporter(st)
+	view.Unregister(st.ocViews()...)
+}
+
+func (st *statusTracker) component(kind, id string) *trackedComponent {
+	c, ok := st.components[kind][id]
+	if !ok {
+		c = newTrackedComponent()
+		st.components[kind][id] = c
+	}
+	return c
+}
+
+// setPipelines registers the pipelines and the receivers and exporters they use. Without configured
+// pipelines, the exporters known by the host are attached to one pipeline per data type, and receivers
+// to the pipelines of the data types they report.
+func (st *statusTracker) setPipelines(pipelines map[component.ID]pipelineSettings, exporters map[component.DataType]map[component.ID]component.Component) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if len(pipelines) > 0 {
+		for pipelineID, p := range pipelines {
+			for _, id := range p.Receivers {
+				st.component(kindReceiver, id.String()).pipelines[pipelineID.String()] = struct{}{}
+			}
+			for _, id := range p.Exporters {
+				st.component(kindExporter, id.String()).pipelines[pipelineID.String()] = struct{}{}
+			}
+		}
+		return
+	}
+
+	st.linkByDataType = true
+	for dataType, exps := range exporters {
+		for id := range exps {
+			st.component(kindExporter, id.String()).pipelines[string(dataType)] = struct{}{}
+		}
+	}
+}
+
+// checkPipelines compares the configured pipelines with the exporters known by the host, returning a
+// description of each mismatch: an exporter of a configured pipeline that isn't an exporter of the pipeline
+// data type, or an exporter that is in no configured pipeline of its data type. The host doesn't expose
+// the receivers, so they can't be checked.
+func checkPipelines(pipelines map[component.ID]pipelineSettings, exporters map[component.DataType]map[component.ID]component.Component) []string {
+	if len(pipelines) == 0 {
+		return nil
+	}
+
+	var mismatches []string
+	configured := map[component.DataType]map[component.ID]bool{}
+	for pipelineID, p := range pipelines {
+		dataType := component.DataType(pipelineID.Type())
+		if configured[dataType] == nil {
+			configured[dataType] = map[component.ID]bool{}
+		}
+		for _, id := range p.Exporters {
+			configured[dataType][id] = true
+			if _, ok := exporters[dataType][id]; !ok {
+				mismatches = append(mismatches, fmt.Sprintf("exporter %q of pipeline %q is not a %s exporter of the collector", id, pipelineID, dataType))
+			}
+		}
+	}
+	for dataType, exps := range exporters {
+		for id := range exps {
+			if !configured[dataType][id] {
+				mismatches = append(mismatches, fmt.Sprintf("%s exporter %q of the collector is in no configured pipeline", dataType, id))
+			}
+		}
+	}
+	sort.Strings(mismatches)
+	return mismatches
+}
+
+// ExportView records the latest outcomes of the operations of each component.
+func (st *statusTracker) ExportView(vd *view.Data) {
+	ov, ok := st.views[vd.View.Name]
+	if !ok {
+		return
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for _, row := range vd.Rows {
+		var id string
+		for _, t := range row.Tags {
+			if t.Key == ov.tagKey {
+				id = t.Value
+			}
+		}
+		if id == "" {
+			continue
+		}
+		c := st.component(ov.kind, id)
+		if ov.kind == kindReceiver && st.linkByDataType {
+			c.pipelines[string(ov.dataType)] = struct{}{}
+		}
+		oc := c.operation(ov.measure.Name())
+		switch data := row.Data.(type) {
+		case *view.DistributionData:
+			if len(data.CountPerBucket) == 2 {
+				oc.succeeded = data.CountPerBucket[0]
+				oc.failed = data.CountPerBucket[1]
+			}
+		case *view.LastValueData:
+			oc.lastFailed = data.Value > 0
+		}
+	}
+}
+
+// evaluate updates the status of every component with the operations recorded since the previous evaluation.
+// Every failed operation increments the failure count and a successful one resets it. When the recorded
+// operations mix failures and successes, only the last operation is known to have failed or succeeded.
+// Exporters whose sending queue is full are unhealthy regardless of the failure count.
+func (st *statusTracker) evaluate(queues map[string]queueUsage) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for _, byID := range st.components {
+		for _, c := range byID {
+			c.failures = 0
+			for _, oc := range c.operations {
+				succeeded, failed := oc.succeeded-oc.evaluatedSucceeded, oc.failed-oc.evaluatedFailed
+				if succeeded < 0 || failed < 0 {
+					// The view was reset, count everything since.
+					succeeded, failed = oc.succeeded, oc.failed
+				}
+				oc.evaluatedSucceeded, oc.evaluatedFailed = oc.succeeded, oc.failed
+				switch {
+				case succeeded == 0:
+					oc.consecutiveFailures += int(failed)
+				case oc.lastFailed:
+					oc.consecutiveFailures = 1
+				default:
+					oc.consecutiveFailures = 0
+				}
+				if succeeded > 0 {
+					c.succeeded = true
+				}
+				if oc.consecutiveFailures > c.failures {
+					c.failures = oc.consecutiveFailures
+				}
+			}
+		}
+	}
+
+	for id, c := range st.components[kindExporter] {
+		q := queues[id]
+		c.queueFull = q.capacity > 0 && q.size >= q.capacity
+	}
+}
+
+func (st *statusTracker) statusOf(kind string, c *trackedComponent) componentStatus {
+	cs := componentStatus{
+		Status:              statusHealthy,
+		ConsecutiveFailures: c.failures,
+	}
+	switch {
+	case c.queueFull:
+		cs.Status = statusUnhealthy
+		cs.Error = "sending queue is full"
+	case c.failures >= st.failureThreshold:
+		cs.Status = statusUnhealthy
+		switch {
+		case kind == kindExporter:
+			cs.Error = fmt.Sprintf("the last %d sends failed", c.failures)
+		case !c.succeeded:
+			cs.Error = fmt.Sprintf("failed to start: the %d operations since the start failed", c.failures)
+		default:
+			cs.Error = fmt.Sprintf("the last %d operations failed", c.failures)
+		}
+	}
+	for p := range c.pipelines {
+		cs.Pipelines = append(cs.Pipelines, p)
+	}
+	sort.Strings(cs.Pipelines)
+	return cs
+}
+
+// report builds the health report. The aggregate status is starting until the pipelines are ready,
+// and unhealthy as soon as one component is unhealthy. A pipeline is unhealthy when one of its
+// receivers or exporters is unhealthy.
+func (st *statusTracker) report(ready bool) healthReport {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	r := healthReport{
+		Status:    statusHealthy,
+		Pipelines: map[string]pipelineStatus{},
+		Receivers: map[string]componentStatus{},
+		Exporters: map[string]componentStatus{},
+	}
+	for kind, byID := range st.components {
+		for id, c := range byID {
+			cs := st.statusOf(kind, c)
+			if cs.Status == statusUnhealthy {
+				r.Status = statusUnhealthy
+			}
+			if kind == kindReceiver {
+				r.Receivers[id] = cs
+			} else {
+				r.Exporters[id] = cs
+			}
+			for _, p := range cs.Pipelines {
+				ps, ok := r.Pipelines[p]
+				if !ok {
+					ps = pipelineStatus{Status: statusHealthy, Receivers: []string{}, Exporters: []string{}}
+				}
+				if kind == kindReceiver {
+					ps.Receivers = append(ps.Receivers, id)
+				} else {
+					ps.Exporters = append(ps.Exporters, id)
+				}
+				if cs.Status == statusUnhealthy {
+					ps.Status = statusUnhealthy
+				}
+				r.Pipelines[p] = ps
+			}
+		}
+	}
+	for p, ps := range r.Pipelines {
+		sort.Strings(ps.Receivers)
+		sort.Strings(ps.Exporters)
+		r.Pipelines[p] = ps
+	}
+	if !ready {
+		r.Status = statusStarting
+	}
+	return r
+}
+
+// readQueueUsage reads the size and capacity of the exporter sending queues from the
+// metrics registered by the exporter helper.
+func readQueueUsage() map[string]queueUsage {
+	queues := map[string]queueUsage{}
+	for _, producer := range metricproducer.GlobalManager().GetAll() {
+		for _, m := range producer.Read() {
+			if m.Descriptor.Name != queueSizeMetric && m.Descriptor.Name != queueCapacityMetric {
+				continue
+			}
+			for _, ts := range m.TimeSeries {
+				if len(ts.LabelValues) == 0 || len(ts.Points) == 0 {
+					continue
+				}
+				value, ok := ts.Points[len(ts.Points)-1].Value.(int64)
+				if !ok {
+					continue
+				}
+				id := ts.LabelValues[0].Value
+				q := queues[id]
+				if m.Descriptor.Name == queueSizeMetric {
+					q.size = value
+				} else {
+					q.capacity = value
+				}
+				queues[id] = q
+			}
+		}
+	}
+	return queues
+}
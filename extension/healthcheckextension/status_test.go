@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+)
+
+// newRegisteredTracker returns a status tracker whose views are registered for the duration of the test.
+func newRegisteredTracker(t *testing.T, failureThreshold int) *statusTracker {
+	st := newStatusTracker(failureThreshold)
+	require.NoError(t, st.register())
+	t.Cleanup(st.unregister)
+	return st
+}
+
+// recordOperations records operations the way obsreport does, with the number of failed items of each operation.
+func recordOperations(t *testing.T, measure, tagKey, id string, failedItems ...int64) {
+	ctx, err := tag.New(context.Background(), tag.Upsert(tag.MustNewKey(tagKey), id))
+	require.NoError(t, err)
+	m := stats.Int64(measure, "", stats.UnitDimensionless)
+	for _, failed := range failedItems {
+		stats.Record(ctx, m.M(failed))
+	}
+}
+
+// exportViews passes the current data of the tracker views to the tracker, as the view worker does periodically.
+func exportViews(t *testing.T, st *statusTracker) {
+	for _, v := range st.ocViews() {
+		rows, err := view.RetrieveData(v.Name)
+		require.NoError(t, err)
+		st.ExportView(&view.Data{View: v, Rows: rows})
+	}
+}
+
+func TestStatusTrackerExporterFailures(t *testing.T) {
+	st := newRegisteredTracker(t, 3)
+	st.setPipelines(nil, map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeTraces:  {component.NewID("otlp"): nil},
+		component.DataTypeMetrics: {component.NewID("otlp"): nil, component.NewID("prometheus"): nil},
+	})
+
+	recordOperations(t, "exporter/send_failed_spans", "exporter", "otlp", 0, 0)
+	exportViews(t, st)
+	st.evaluate(nil)
+	report := st.report(true)
+	assert.Equal(t, statusHealthy, report.Status)
+	assert.Equal(t, []string{"metrics", "traces"}, report.Exporters["otlp"].Pipelines)
+	assert.Equal(t, []string{"otlp", "prometheus"}, report.Pipelines["metrics"].Exporters)
+
+	// Failed sends are counted individually, not per interval.
+	recordOperations(t, "exporter/send_failed_spans", "exporter", "otlp", 5, 8)
+	exportViews(t, st)
+	st.evaluate(nil)
+	report = st.report(true)
+	assert.Equal(t, 2, report.Exporters["otlp"].ConsecutiveFailures)
+	assert.Equal(t, statusHealthy, report.Status)
+
+	recordOperations(t, "exporter/send_failed_spans", "exporter", "otlp", 1)
+	exportViews(t, st)
+	st.evaluate(nil)
+	report = st.report(true)
+	assert.Equal(t, statusUnhealthy, report.Status)
+	assert.Equal(t, statusUnhealthy, report.Exporters["otlp"].Status)
+	assert.Equal(t, "the last 3 sends failed", report.Exporters["otlp"].Error)
+	assert.Equal(t, statusUnhealthy, report.Pipelines["traces"].Status)
+	assert.Equal(t, statusUnhealthy, report.Pipelines["metrics"].Status)
+	assert.Equal(t, statusHealthy, report.Exporters["prometheus"].Status)
+
+	// An interval without sends keeps the status, a successful send resets it.
+	st.evaluate(nil)
+	assert.Equal(t, statusUnhealthy, st.report(true).Status)
+	recordOperations(t, "exporter/send_failed_spans", "exporter", "otlp", 0)
+	exportViews(t, st)
+	st.evaluate(nil)
+	report = st.report(true)
+	assert.Equal(t, statusHealthy, report.Status)
+	assert.Equal(t, 0, report.Exporters["otlp"].ConsecutiveFailures)
+
+	// Only the last send is known to have failed when an interval mixes successes and failures.
+	recordOperations(t, "exporter/send_failed_spans", "exporter", "otlp", 1, 1, 0, 1)
+	exportViews(t, st)
+	st.evaluate(nil)
+	assert.Equal(t, 1, st.report(true).Exporters["otlp"].ConsecutiveFailures)
+}
+
+func TestStatusTrackerQueueFull(t *testing.T) {
+	st := newStatusTracker(1)
+	st.setPipelines(nil, map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeLogs: {component.NewID("loki"): nil},
+	})
+
+	st.evaluate(map[string]queueUsage{"loki": {size: 100, capacity: 100}})
+	report := st.report(true)
+	assert.Equal(t, statusUnhealthy, report.Exporters["loki"].Status)
+	assert.Equal(t, "sending queue is full", report.Exporters["loki"].Error)
+	assert.Equal(t, statusUnhealthy, report.Pipelines["logs"].Status)
+
+	st.evaluate(map[string]queueUsage{"loki": {size: 10, capacity: 100}})
+	assert.Equal(t, statusHealthy, st.report(true).Status)
+}
+
+func TestStatusTrackerReceivers(t *testing.T) {
+	st := newRegisteredTracker(t, 2)
+	st.setPipelines(nil, nil)
+
+	recordOperations(t, "scraper/errored_metric_points", "receiver", "hostmetrics", 3, 2)
+	recordOperations(t, "receiver/refused_spans", "receiver", "otlp", 0)
+	exportViews(t, st)
+	st.evaluate(nil)
+
+	report := st.report(false)
+	assert.Equal(t, statusStarting, report.Status)
+	require.Len(t, report.Receivers, 2)
+	assert.Equal(t, statusUnhealthy, report.Receivers["hostmetrics"].Status)
+	assert.Equal(t, "failed to start: the 2 operations since the start failed", report.Receivers["hostmetrics"].Error)
+	assert.Equal(t, statusHealthy, report.Receivers["otlp"].Status)
+	assert.Equal(t, statusUnhealthy, st.report(true).Status)
+
+	// Receivers are attached to the pipelines of the data types they report.
+	assert.Equal(t, []string{"metrics"}, report.Receivers["hostmetrics"].Pipelines)
+	assert.Equal(t, []string{"hostmetrics"}, report.Pipelines["metrics"].Receivers)
+	assert.Equal(t, []string{"otlp"}, report.Pipelines["traces"].Receivers)
+
+	// A receiver failing after it succeeded once didn't fail to start.
+	recordOperations(t, "receiver/refused_spans", "receiver", "otlp", 1, 1)
+	exportViews(t, st)
+	st.evaluate(nil)
+	assert.Equal(t, "the last 2 operations failed", st.report(true).Receivers["otlp"].Error)
+}
+
+func TestCheckPipelines(t *testing.T) {
+	exporters := map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeTraces:  {component.NewIDWithName("otlp", "a"): nil, component.NewIDWithName("otlp", "b"): nil},
+		component.DataTypeMetrics: {component.NewIDWithName("otlp", "a"): nil},
+	}
+
+	assert.Empty(t, checkPipelines(nil, exporters))
+	assert.Empty(t, checkPipelines(map[component.ID]pipelineSettings{
+		component.NewIDWithName("traces", "a"):  {Exporters: []component.ID{component.NewIDWithName("otlp", "a")}},
+		component.NewIDWithName("traces", "b"):  {Exporters: []component.ID{component.NewIDWithName("otlp", "b")}},
+		component.NewIDWithName("metrics", "a"): {Exporters: []component.ID{component.NewIDWithName("otlp", "a")}},
+	}, exporters))
+
+	assert.Equal(t, []string{
+		`exporter "otlp/b" of pipeline "metrics" is not a metrics exporter of the collector`,
+		`metrics exporter "otlp/a" of the collector is in no configured pipeline`,
+		`traces exporter "otlp/b" of the collector is in no configured pipeline`,
+	}, checkPipelines(map[component.ID]pipelineSettings{
+		component.NewID("traces"):  {Exporters: []component.ID{component.NewIDWithName("otlp", "a")}},
+		component.NewID("metrics"): {Exporters: []component.ID{component.NewIDWithName("otlp", "b")}},
+	}, exporters))
+}
+
+func TestStatusTrackerConfiguredPipelines(t *testing.T) {
+	st := newRegisteredTracker(t, 1)
+	st.setPipelines(map[component.ID]pipelineSettings{
+		component.NewIDWithName("traces", "a"): {
+			Receivers: []component.ID{component.NewID("otlp")},
+			Exporters: []component.ID{component.NewIDWithName("otlp", "a")},
+		},
+		component.NewIDWithName("traces", "b"): {
+			Receivers: []component.ID{component.NewID("otlp")},
+			Exporters: []component.ID{component.NewIDWithName("otlp", "b")},
+		},
+	}, map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeTraces: {component.NewIDWithName("otlp", "a"): nil, component.NewIDWithName("otlp", "b"): nil},
+	})
+
+	recordOperations(t, "exporter/send_failed_spans", "exporter", "otlp/b", 10)
+	recordOperations(t, "receiver/refused_spans", "receiver", "otlp", 0)
+	exportViews(t, st)
+	st.evaluate(nil)
+
+	report := st.report(true)
+	assert.Equal(t, statusUnhealthy, report.Status)
+	require.Len(t, report.Pipelines, 2)
+	assert.Equal(t, pipelineStatus{Status: statusHealthy, Receivers: []string{"otlp"}, Exporters: []string{"otlp/a"}}, report.Pipelines["traces/a"])
+	assert.Equal(t, pipelineStatus{Status: statusUnhealthy, Receivers: []string{"otlp"}, Exporters: []string{"otlp/b"}}, report.Pipelines["traces/b"])
+	assert.Equal(t, []string{"traces/a", "traces/b"}, report.Receivers["otlp"].Pipelines)
+}
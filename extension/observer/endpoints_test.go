@@ -205,6 +205,86 @@ func TestEndpointEnv(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Kubernetes Service",
+			endpoint: Endpoint{
+				ID:     EndpointID("k8s_service_endpoint_id"),
+				Target: "service.namespace.svc:8080",
+				Details: &K8sService{
+					Name:      "service",
+					UID:       "service-uid",
+					Namespace: "namespace",
+					Annotations: map[string]string{
+						"annotation_key": "annotation_val",
+					},
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					ServiceType: "ClusterIP",
+					ClusterIP:   "10.0.0.1",
+					Port:        8080,
+					PortName:    "http",
+					Protocol:    "TCP",
+				},
+			},
+			want: EndpointEnv{
+				"type":         "k8s.service",
+				"id":           "k8s_service_endpoint_id",
+				"endpoint":     "service.namespace.svc:8080",
+				"name":         "service",
+				"uid":          "service-uid",
+				"namespace":    "namespace",
+				"service_type": "ClusterIP",
+				"cluster_ip":   "10.0.0.1",
+				"port":         uint16(8080),
+				"port_name":    "http",
+				"protocol":     "TCP",
+				"annotations": map[string]string{
+					"annotation_key": "annotation_val",
+				},
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+			},
+		},
+		{
+			name: "Kubernetes Ingress",
+			endpoint: Endpoint{
+				ID:     EndpointID("k8s_ingress_endpoint_id"),
+				Target: "https://example.com/api",
+				Details: &K8sIngress{
+					Name:      "ingress",
+					UID:       "ingress-uid",
+					Namespace: "namespace",
+					Annotations: map[string]string{
+						"annotation_key": "annotation_val",
+					},
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					Scheme: "https",
+					Host:   "example.com",
+					Path:   "/api",
+				},
+			},
+			want: EndpointEnv{
+				"type":      "k8s.ingress",
+				"id":        "k8s_ingress_endpoint_id",
+				"endpoint":  "https://example.com/api",
+				"name":      "ingress",
+				"uid":       "ingress-uid",
+				"namespace": "namespace",
+				"scheme":    "https",
+				"host":      "example.com",
+				"path":      "/api",
+				"annotations": map[string]string{
+					"annotation_key": "annotation_val",
+				},
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
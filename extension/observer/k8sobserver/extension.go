@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/extension"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/tools/cache"
 
@@ -36,16 +37,18 @@ var _ observer.Observable = (*k8sObserver)(nil)
 
 type k8sObserver struct {
 	*observer.EndpointsWatcher
-	telemetry         component.TelemetrySettings
-	podListerWatcher  cache.ListerWatcher
-	nodeListerWatcher cache.ListerWatcher
-	handler           *handler
-	once              *sync.Once
-	stop              chan struct{}
-	config            *Config
+	telemetry            component.TelemetrySettings
+	podListerWatcher     cache.ListerWatcher
+	nodeListerWatcher    cache.ListerWatcher
+	serviceListerWatcher cache.ListerWatcher
+	ingressListerWatcher cache.ListerWatcher
+	handler              *handler
+	once                 *sync.Once
+	stop                 chan struct{}
+	config               *Config
 }
 
-// Start will populate the cache.SharedInformers for pods and nodes as configured and run them as goroutines.
+// Start will populate the cache.SharedInformers for pods, nodes, services and ingresses as configured and run them as goroutines.
 func (k *k8sObserver) Start(ctx context.Context, host component.Host) error {
 	if k.once == nil {
 		return fmt.Errorf("cannot Start() partial k8sObserver (nil *sync.Once)")
@@ -71,6 +74,22 @@ func (k *k8sObserver) Start(ctx context.Context, host component.Host) error {
 				k.telemetry.Logger.Error("error adding event handler to node informer", zap.Error(err))
 			}
 		}
+		if k.serviceListerWatcher != nil {
+			k.telemetry.Logger.Debug("creating and starting service informer")
+			serviceInformer := cache.NewSharedInformer(k.serviceListerWatcher, &v1.Service{}, 0)
+			if _, err := serviceInformer.AddEventHandler(k.handler); err != nil {
+				k.telemetry.Logger.Error("error adding event handler to service informer", zap.Error(err))
+			}
+			go serviceInformer.Run(k.stop)
+		}
+		if k.ingressListerWatcher != nil {
+			k.telemetry.Logger.Debug("creating and starting ingress informer")
+			ingressInformer := cache.NewSharedInformer(k.ingressListerWatcher, &netv1.Ingress{}, 0)
+			if _, err := ingressInformer.AddEventHandler(k.handler); err != nil {
+				k.telemetry.Logger.Error("error adding event handler to ingress informer", zap.Error(err))
+			}
+			go ingressInformer.Run(k.stop)
+		}
 	})
 	return nil
 }
@@ -112,16 +131,30 @@ func newObserver(config *Config, set extension.CreateSettings) (extension.Extens
 		set.Logger.Debug("observing nodes")
 		nodeListerWatcher = cache.NewListWatchFromClient(restClient, "nodes", v1.NamespaceAll, nodeSelector)
 	}
+	var serviceListerWatcher cache.ListerWatcher
+	if config.ObserveServices {
+		set.Logger.Debug("observing services")
+		serviceListerWatcher = cache.NewListWatchFromClient(restClient, "services", v1.NamespaceAll, fields.Everything())
+	}
+
+	var ingressListerWatcher cache.ListerWatcher
+	if config.ObserveIngresses {
+		set.Logger.Debug("observing ingresses")
+		ingressListerWatcher = cache.NewListWatchFromClient(client.NetworkingV1().RESTClient(), "ingresses", v1.NamespaceAll, fields.Everything())
+	}
+
 	h := &handler{idNamespace: set.ID.String(), endpoints: &sync.Map{}, logger: set.TelemetrySettings.Logger}
 	obs := &k8sObserver{
-		EndpointsWatcher:  observer.NewEndpointsWatcher(h, time.Second, set.TelemetrySettings.Logger),
-		telemetry:         set.TelemetrySettings,
-		podListerWatcher:  podListerWatcher,
-		nodeListerWatcher: nodeListerWatcher,
-		stop:              make(chan struct{}),
-		config:            config,
-		handler:           h,
-		once:              &sync.Once{},
+		EndpointsWatcher:     observer.NewEndpointsWatcher(h, time.Second, set.TelemetrySettings.Logger),
+		telemetry:            set.TelemetrySettings,
+		podListerWatcher:     podListerWatcher,
+		nodeListerWatcher:    nodeListerWatcher,
+		serviceListerWatcher: serviceListerWatcher,
+		ingressListerWatcher: ingressListerWatcher,
+		stop:                 make(chan struct{}),
+		config:               config,
+		handler:              h,
+		once:                 &sync.Once{},
 	}
 
 	return obs, nil
@@ -229,3 +229,55 @@ func TestExtensionObserveNodes(t *testing.T) {
 
 	require.NoError(t, ext.Shutdown(context.Background()))
 }
+
+func TestExtensionObserveServices(t *testing.T) {
+	factory := NewFactory()
+	config := factory.CreateDefaultConfig().(*Config)
+	mockServiceHost(t, config)
+
+	set := extensiontest.NewNopCreateSettings()
+	set.ID = component.NewID(typeStr)
+	ext, err := newObserver(config, set)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	obs := ext.(*k8sObserver)
+	serviceListerWatcher := framework.NewFakeControllerSource()
+	obs.serviceListerWatcher = serviceListerWatcher
+
+	serviceListerWatcher.Add(service1V1)
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	obs.ListAndWatch(sink)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.added) == 1
+	})
+
+	assert.Equal(t, observer.Endpoint{
+		ID:     "k8s_observer/service1-UID/http(80)",
+		Target: "service1.default.svc:80",
+		Details: &observer.K8sService{
+			Name:        "service1",
+			UID:         "service1-UID",
+			Namespace:   "default",
+			Labels:      map[string]string{"env": "prod"},
+			Annotations: map[string]string{"monitoring": "true"},
+			ServiceType: "ClusterIP",
+			ClusterIP:   "10.0.0.1",
+			Port:        80,
+			PortName:    "http",
+			Protocol:    "TCP",
+		},
+	}, sink.added[0])
+
+	serviceListerWatcher.Delete(service1V1)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.removed) == 1
+	})
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
@@ -203,3 +203,87 @@ func TestNodeEndpointsChanged(t *testing.T) {
 		},
 	}, th.ListEndpoints())
 }
+
+func TestServiceEndpointsAdded(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1)
+	assert.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:     "test-1/service1-UID/http(80)",
+			Target: "service1.default.svc:80",
+			Details: &observer.K8sService{
+				Name:        "service1",
+				UID:         "service1-UID",
+				Namespace:   "default",
+				Labels:      map[string]string{"env": "prod"},
+				Annotations: map[string]string{"monitoring": "true"},
+				ServiceType: "ClusterIP",
+				ClusterIP:   "10.0.0.1",
+				Port:        80,
+				PortName:    "http",
+				Protocol:    "TCP",
+			},
+		},
+	}, th.ListEndpoints())
+}
+
+func TestServiceEndpointsRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1)
+	th.OnDelete(service1V1)
+	assert.Empty(t, th.ListEndpoints())
+}
+
+func TestServiceEndpointsChanged(t *testing.T) {
+	th := newTestHandler()
+	// Nothing changed.
+	th.OnUpdate(service1V1, service1V1)
+	require.Empty(t, th.ListEndpoints())
+
+	// Labels changed.
+	th.OnUpdate(service1V1, service1V2)
+	assert.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:     "test-1/service1-UID/http(80)",
+			Target: "service1.default.svc:80",
+			Details: &observer.K8sService{
+				Name:      "service1",
+				UID:       "service1-UID",
+				Namespace: "default",
+				Labels: map[string]string{
+					"env":             "prod",
+					"service-version": "2",
+				},
+				Annotations: map[string]string{"monitoring": "true"},
+				ServiceType: "ClusterIP",
+				ClusterIP:   "10.0.0.1",
+				Port:        80,
+				PortName:    "http",
+				Protocol:    "TCP",
+			},
+		},
+	}, th.ListEndpoints())
+}
+
+func TestIngressEndpointsAddedAndRemoved(t *testing.T) {
+	th := newTestHandler()
+	ingress := NewIngress("ingress1")
+	th.OnAdd(ingress)
+	endpoints := th.ListEndpoints()
+	require.Len(t, endpoints, 2)
+	assert.ElementsMatch(t,
+		[]observer.EndpointID{"test-1/ingress1-UID/secure.example.com/api", "test-1/ingress1-UID/example.com/"},
+		[]observer.EndpointID{endpoints[0].ID, endpoints[1].ID},
+	)
+
+	// One rule removed.
+	updatedIngress := ingress.DeepCopy()
+	updatedIngress.Spec.Rules = updatedIngress.Spec.Rules[:1]
+	th.OnUpdate(ingress, updatedIngress)
+	endpoints = th.ListEndpoints()
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, observer.EndpointID("test-1/ingress1-UID/secure.example.com/api"), endpoints[0].ID)
+
+	th.OnDelete(updatedIngress)
+	assert.Empty(t, th.ListEndpoints())
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestServiceObjectToK8sServiceEndpoints(t *testing.T) {
+	service := NewService("service1")
+	service.Spec.Ports = append(service.Spec.Ports, v1.ServicePort{Name: "dns", Port: 53, Protocol: v1.ProtocolUDP})
+
+	details := func(port uint16, portName, protocol string) *observer.K8sService {
+		return &observer.K8sService{
+			Name:        "service1",
+			UID:         "service1-UID",
+			Namespace:   "default",
+			Labels:      map[string]string{"env": "prod"},
+			Annotations: map[string]string{"monitoring": "true"},
+			ServiceType: "ClusterIP",
+			ClusterIP:   "10.0.0.1",
+			Port:        port,
+			PortName:    portName,
+			Protocol:    protocol,
+		}
+	}
+	expectedEndpoints := []observer.Endpoint{
+		{
+			ID:      "namespace/service1-UID/http(80)",
+			Target:  "service1.default.svc:80",
+			Details: details(80, "http", "TCP"),
+		},
+		{
+			ID:      "namespace/service1-UID/dns(53)",
+			Target:  "service1.default.svc:53",
+			Details: details(53, "dns", "UDP"),
+		},
+	}
+
+	endpoints := convertServiceToEndpoints("namespace", service)
+	require.Equal(t, expectedEndpoints, endpoints)
+}
+
+func TestServiceWithoutPortsHasNoEndpoints(t *testing.T) {
+	service := NewService("service1")
+	service.Spec.Ports = nil
+	require.Empty(t, convertServiceToEndpoints("namespace", service))
+}
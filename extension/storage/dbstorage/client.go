@@ -19,35 +19,58 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	// Postgres driver
 	_ "github.com/jackc/pgx/v4/stdlib"
 	// SQLite driver
 	_ "github.com/mattn/go-sqlite3"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	// Pure Go SQLite driver
+	_ "modernc.org/sqlite"
 )
 
 const (
-	createTable     = "create table if not exists %s (key text primary key, value blob)"
-	getQueryText    = "select value from %s where key=?"
-	setQueryText    = "insert into %s(key, value) values(?,?) on conflict(key) do update set value=?"
-	deleteQueryText = "delete from %s where key=?"
+	createTable       = "create table if not exists %s (key text primary key, value blob, expires_at integer)"
+	checkExpiresAt    = "select expires_at from %s where 1=0"
+	addExpiresAt      = "alter table %s add column expires_at integer"
+	getQueryText      = "select value from %s where key=? and (expires_at is null or expires_at>?)"
+	setQueryText      = "insert into %s(key, value, expires_at) values(?,?,?) on conflict(key) do update set value=?, expires_at=?"
+	deleteQueryText   = "delete from %s where key=?"
+	deleteExpiredText = "delete from %s where expires_at is not null and expires_at<=?"
 )
 
 type dbStorageClient struct {
-	db          *sql.DB
-	getQuery    *sql.Stmt
-	setQuery    *sql.Stmt
-	deleteQuery *sql.Stmt
+	db                 *sql.DB
+	logger             *zap.Logger
+	ttl                time.Duration
+	getQuery           *sql.Stmt
+	setQuery           *sql.Stmt
+	deleteQuery        *sql.Stmt
+	deleteExpiredQuery *sql.Stmt
+
+	closeOnce sync.Once
+	stopCh    chan struct{}
+	doneCh    chan struct{}
 }
 
-func newClient(ctx context.Context, db *sql.DB, tableName string) (*dbStorageClient, error) {
+func newClient(ctx context.Context, logger *zap.Logger, db *sql.DB, tableName string, ttl time.Duration, cleanupInterval time.Duration) (*dbStorageClient, error) {
 	var err error
 	_, err = db.ExecContext(ctx, fmt.Sprintf(createTable, tableName))
 	if err != nil {
 		return nil, err
 	}
 
+	// Tables created by previous versions have no expiry column
+	if _, err = db.ExecContext(ctx, fmt.Sprintf(checkExpiresAt, tableName)); err != nil {
+		if _, err = db.ExecContext(ctx, fmt.Sprintf(addExpiresAt, tableName)); err != nil {
+			return nil, err
+		}
+	}
+
 	selectQuery, err := db.PrepareContext(ctx, fmt.Sprintf(getQueryText, tableName))
 	if err != nil {
 		return nil, err
@@ -60,31 +83,36 @@ func newClient(ctx context.Context, db *sql.DB, tableName string) (*dbStorageCli
 	if err != nil {
 		return nil, err
 	}
-	return &dbStorageClient{db, selectQuery, setQuery, deleteQuery}, nil
-}
-
-// Get will retrieve data from storage that corresponds to the specified key
-func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
-	rows, err := c.getQuery.QueryContext(ctx, key)
+	deleteExpiredQuery, err := db.PrepareContext(ctx, fmt.Sprintf(deleteExpiredText, tableName))
 	if err != nil {
 		return nil, err
 	}
-	if !rows.Next() {
-		return nil, nil
+
+	client := &dbStorageClient{
+		db:                 db,
+		logger:             logger,
+		ttl:                ttl,
+		getQuery:           selectQuery,
+		setQuery:           setQuery,
+		deleteQuery:        deleteQuery,
+		deleteExpiredQuery: deleteExpiredQuery,
 	}
-	var result []byte
-	err = rows.Scan(&result)
-	if err != nil {
-		return result, err
+	if ttl > 0 && cleanupInterval > 0 {
+		client.stopCh = make(chan struct{})
+		client.doneCh = make(chan struct{})
+		go client.cleanupLoop(cleanupInterval)
 	}
-	err = rows.Close()
-	return result, err
+	return client, nil
+}
+
+// Get will retrieve data from storage that corresponds to the specified key
+func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	return c.get(ctx, c.getQuery, key)
 }
 
 // Set will store data. The data can be retrieved using the same key
 func (c *dbStorageClient) Set(ctx context.Context, key string, value []byte) error {
-	_, err := c.setQuery.ExecContext(ctx, key, value, value)
-	return err
+	return c.set(ctx, c.setQuery, key, value)
 }
 
 // Delete will delete data associated with the specified key
@@ -93,30 +121,45 @@ func (c *dbStorageClient) Delete(ctx context.Context, key string) error {
 	return err
 }
 
-// Batch executes the specified operations in order. Get operation results are updated in place
+// Batch executes the specified operations in order, in a single transaction.
+// Get operation results are updated in place
 func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
-	var err error
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	getQuery := tx.StmtContext(ctx, c.getQuery)
+	setQuery := tx.StmtContext(ctx, c.setQuery)
+	deleteQuery := tx.StmtContext(ctx, c.deleteQuery)
+
 	for _, op := range ops {
 		switch op.Type {
 		case storage.Get:
-			op.Value, err = c.Get(ctx, op.Key)
+			op.Value, err = c.get(ctx, getQuery, op.Key)
 		case storage.Set:
-			err = c.Set(ctx, op.Key, op.Value)
+			err = c.set(ctx, setQuery, op.Key, op.Value)
 		case storage.Delete:
-			err = c.Delete(ctx, op.Key)
+			_, err = deleteQuery.ExecContext(ctx, op.Key)
 		default:
-			return errors.New("wrong operation type")
+			err = errors.New("wrong operation type")
 		}
 
 		if err != nil {
-			return err
+			return multierr.Append(err, tx.Rollback())
 		}
 	}
-	return err
+	return tx.Commit()
 }
 
-// Close will close the database
+// Close will stop the removal of expired keys and close the prepared statements
 func (c *dbStorageClient) Close(_ context.Context) error {
+	c.closeOnce.Do(func() {
+		if c.stopCh != nil {
+			close(c.stopCh)
+			<-c.doneCh
+		}
+	})
 	if err := c.setQuery.Close(); err != nil {
 		return err
 	}
@@ -126,5 +169,58 @@ func (c *dbStorageClient) Close(_ context.Context) error {
 	if err := c.getQuery.Close(); err != nil {
 		return err
 	}
+	if err := c.deleteExpiredQuery.Close(); err != nil {
+		return err
+	}
 	return nil
 }
+
+func (c *dbStorageClient) get(ctx context.Context, query *sql.Stmt, key string) ([]byte, error) {
+	rows, err := query.QueryContext(ctx, key, time.Now().UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	var result []byte
+	err = rows.Scan(&result)
+	if err != nil {
+		return result, err
+	}
+	err = rows.Close()
+	return result, err
+}
+
+func (c *dbStorageClient) set(ctx context.Context, query *sql.Stmt, key string, value []byte) error {
+	var expiresAt sql.NullInt64
+	if c.ttl > 0 {
+		expiresAt = sql.NullInt64{Int64: time.Now().Add(c.ttl).UnixNano(), Valid: true}
+	}
+	_, err := query.ExecContext(ctx, key, value, expiresAt, value, expiresAt)
+	return err
+}
+
+// deleteExpired removes the keys whose TTL has elapsed
+func (c *dbStorageClient) deleteExpired(ctx context.Context) error {
+	_, err := c.deleteExpiredQuery.ExecContext(ctx, time.Now().UnixNano())
+	return err
+}
+
+func (c *dbStorageClient) cleanupLoop(interval time.Duration) {
+	defer close(c.doneCh)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.deleteExpired(context.Background()); err != nil {
+				c.logger.Warn("failed to delete expired keys", zap.Error(err))
+			}
+		case <-c.stopCh:
+			return
+		}
+	}
+}
@@ -16,12 +16,18 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"errors"
+	"time"
 )
 
 // Config defines configuration for dbstorage extension.
 type Config struct {
 	DriverName string `mapstructure:"driver,omitempty"`
 	DataSource string `mapstructure:"datasource,omitempty"`
+	// TTL is the time after which a key expires once it was last set.
+	// Keys never expire when TTL is zero.
+	TTL time.Duration `mapstructure:"ttl,omitempty"`
+	// CleanupInterval is the time between two removals of expired keys.
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval,omitempty"`
 }
 
 func (cfg *Config) Validate() error {
@@ -31,6 +37,12 @@ func (cfg *Config) Validate() error {
 	if cfg.DriverName == "" {
 		return errors.New("missing driver name")
 	}
+	if cfg.TTL < 0 {
+		return errors.New("ttl must not be negative")
+	}
+	if cfg.TTL > 0 && cfg.CleanupInterval <= 0 {
+		return errors.New("cleanup_interval must be positive when ttl is set")
+	}
 
 	return nil
 }
@@ -21,6 +21,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -41,11 +42,26 @@ func TestConfigValidate(t *testing.T) {
 			Config{DriverName: "foo"},
 			errors.New("missing datasource"),
 		},
+		{
+			"Negative ttl",
+			Config{DriverName: "foo", DataSource: "bar", TTL: -time.Second},
+			errors.New("ttl must not be negative"),
+		},
+		{
+			"Missing cleanup interval",
+			Config{DriverName: "foo", DataSource: "bar", TTL: time.Second},
+			errors.New("cleanup_interval must be positive when ttl is set"),
+		},
 		{
 			"valid",
 			Config{DriverName: "foo", DataSource: "bar"},
 			nil,
 		},
+		{
+			"valid with ttl",
+			Config{DriverName: "foo", DataSource: "bar", TTL: time.Hour, CleanupInterval: time.Minute},
+			nil,
+		},
 	}
 
 	for _, test := range tests {
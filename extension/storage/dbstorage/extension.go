@@ -18,7 +18,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -26,11 +28,16 @@ import (
 	"go.uber.org/zap"
 )
 
+// invalidTableNameChars matches the characters that cannot be used in an unquoted table name
+var invalidTableNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
 type databaseStorage struct {
-	driverName     string
-	datasourceName string
-	logger         *zap.Logger
-	db             *sql.DB
+	driverName      string
+	datasourceName  string
+	ttl             time.Duration
+	cleanupInterval time.Duration
+	logger          *zap.Logger
+	db              *sql.DB
 }
 
 // Ensure this storage extension implements the appropriate interface
@@ -38,9 +45,11 @@ var _ storage.Extension = (*databaseStorage)(nil)
 
 func newDBStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
 	return &databaseStorage{
-		driverName:     config.DriverName,
-		datasourceName: config.DataSource,
-		logger:         logger,
+		driverName:      config.DriverName,
+		datasourceName:  config.DataSource,
+		ttl:             config.TTL,
+		cleanupInterval: config.CleanupInterval,
+		logger:          logger,
 	}, nil
 }
 
@@ -66,7 +75,7 @@ func (ds *databaseStorage) Shutdown(context.Context) error {
 	return ds.db.Close()
 }
 
-// GetClient returns a storage client for an individual component
+// GetClient returns a storage client for an individual component, backed by its own table
 func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, ent component.ID, name string) (storage.Client, error) {
 	var fullName string
 	if name == "" {
@@ -75,7 +84,8 @@ func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, e
 		fullName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
 	}
 	fullName = strings.ReplaceAll(fullName, " ", "")
-	return newClient(ctx, ds.db, fullName)
+	fullName = invalidTableNameChars.ReplaceAllString(fullName, "_")
+	return newClient(ctx, ds.logger, ds.db, fullName, ds.ttl, ds.cleanupInterval)
 }
 
 func kindString(k component.Kind) string {
@@ -20,9 +20,11 @@ package dbstorage
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -30,6 +32,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
 func TestExtensionIntegrity(t *testing.T) {
@@ -112,11 +116,152 @@ func TestExtensionIntegrity(t *testing.T) {
 	wg.Wait()
 }
 
+func TestClientOperations(t *testing.T) {
+	for _, driver := range []string{"sqlite3", "sqlite"} {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			client := newTestClient(t, newTestConfig(t, driver), newTestEntity("my-receiver.v1"))
+
+			require.NoError(t, client.Set(ctx, "foo", []byte("bar")))
+			value, err := client.Get(ctx, "foo")
+			require.NoError(t, err)
+			require.Equal(t, []byte("bar"), value)
+
+			require.NoError(t, client.Set(ctx, "foo", []byte("baz")))
+			value, err = client.Get(ctx, "foo")
+			require.NoError(t, err)
+			require.Equal(t, []byte("baz"), value)
+
+			require.NoError(t, client.Delete(ctx, "foo"))
+			value, err = client.Get(ctx, "foo")
+			require.NoError(t, err)
+			require.Nil(t, value)
+
+			set := storage.SetOperation("foo", []byte("bar"))
+			get := storage.GetOperation("foo")
+			del := storage.DeleteOperation("foo")
+			getNil := storage.GetOperation("foo")
+			require.NoError(t, client.Batch(ctx, set, get, del, getNil))
+			require.Equal(t, []byte("bar"), get.Value)
+			require.Nil(t, getNil.Value)
+		})
+	}
+}
+
+func TestBatchIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, newTestConfig(t, "sqlite3"), newTestEntity("atomic"))
+
+	require.NoError(t, client.Set(ctx, "existing", []byte("before")))
+	invalid := storage.GetOperation("invalid")
+	invalid.Type = 42
+	err := client.Batch(ctx,
+		storage.SetOperation("existing", []byte("after")),
+		storage.SetOperation("new", []byte("value")),
+		invalid,
+	)
+	require.Error(t, err)
+
+	value, err := client.Get(ctx, "existing")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("before"), value)
+	value, err = client.Get(ctx, "new")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestClientTTL(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig(t, "sqlite")
+	cfg.TTL = 50 * time.Millisecond
+	cfg.CleanupInterval = time.Hour
+	client := newTestClient(t, cfg, newTestEntity("ttl")).(*dbStorageClient)
+
+	require.NoError(t, client.Set(ctx, "foo", []byte("bar")))
+	value, err := client.Get(ctx, "foo")
+	require.NoError(t, err)
+	require.Equal(t, []byte("bar"), value)
+
+	require.Eventually(t, func() bool {
+		value, err = client.Get(ctx, "foo")
+		return err == nil && value == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	countRows := func() int {
+		var count int
+		require.NoError(t, client.db.QueryRowContext(ctx, "select count(*) from extension_nop_ttl").Scan(&count))
+		return count
+	}
+	require.Equal(t, 1, countRows())
+	require.NoError(t, client.deleteExpired(ctx))
+	require.Equal(t, 0, countRows())
+}
+
+func TestClientCleanupLoop(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig(t, "sqlite")
+	cfg.TTL = time.Millisecond
+	cfg.CleanupInterval = 10 * time.Millisecond
+	client := newTestClient(t, cfg, newTestEntity("cleanup")).(*dbStorageClient)
+
+	require.NoError(t, client.Set(ctx, "foo", []byte("bar")))
+	require.Eventually(t, func() bool {
+		var count int
+		err := client.db.QueryRowContext(ctx, "select count(*) from extension_nop_cleanup").Scan(&count)
+		return err == nil && count == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClientMigratesTableWithoutExpiry(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig(t, "sqlite3")
+
+	db, err := sql.Open(cfg.DriverName, cfg.DataSource)
+	require.NoError(t, err)
+	_, err = db.Exec("create table extension_nop_legacy (key text primary key, value blob)")
+	require.NoError(t, err)
+	_, err = db.Exec("insert into extension_nop_legacy(key, value) values('foo', 'bar')")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	client := newTestClient(t, cfg, newTestEntity("legacy"))
+	value, err := client.Get(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("bar"), value)
+}
+
+// newTestClient starts the extension through a storage host and returns a client for the given component.
+func newTestClient(t *testing.T, cfg *Config, id component.ID) storage.Client {
+	ctx := context.Background()
+	extID := component.NewID(typeStr)
+	ext, err := NewFactory().CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	host := storagetest.NewStorageHost().WithExtension(extID, ext)
+
+	require.NoError(t, ext.Start(ctx, host))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(ctx)) })
+
+	se, ok := host.GetExtensions()[extID].(storage.Extension)
+	require.True(t, ok)
+	client, err := se.GetClient(ctx, component.KindExtension, id, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close(ctx)) })
+	return client
+}
+
+func newTestConfig(t *testing.T, driver string) *Config {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.DriverName = driver
+	cfg.DataSource = fmt.Sprintf("file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL", t.TempDir())
+	if driver == "sqlite" {
+		cfg.DataSource = fmt.Sprintf("file:%s/foo.db?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)", t.TempDir())
+	}
+	return cfg
+}
+
 func newTestExtension(t *testing.T) storage.Extension {
 	f := NewFactory()
-	cfg := f.CreateDefaultConfig().(*Config)
-	cfg.DriverName = "sqlite3"
-	cfg.DataSource = fmt.Sprintf("file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL", t.TempDir())
+	cfg := newTestConfig(t, "sqlite3")
 
 	extension, err := f.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
 	require.NoError(t, err)
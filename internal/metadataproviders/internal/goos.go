@@ -24,3 +24,16 @@ func GOOSToOSType(goos string) string {
 	}
 	return goos
 }
+
+// GOARCHtoHostArch maps a runtime.GOARCH-like value to host.arch style.
+func GOARCHtoHostArch(goarch string) string {
+	switch goarch {
+	case "386":
+		return "x86"
+	case "arm":
+		return "arm32"
+	case "ppc64le":
+		return "ppc64"
+	}
+	return goarch
+}
@@ -27,3 +27,12 @@ func TestGOOSToOsType(t *testing.T) {
 	assert.Equal(t, "dragonflybsd", GOOSToOSType("dragonfly"))
 	assert.Equal(t, "z_os", GOOSToOSType("zos"))
 }
+
+func TestGOARCHtoHostArch(t *testing.T) {
+	assert.Equal(t, "amd64", GOARCHtoHostArch("amd64"))
+	assert.Equal(t, "arm64", GOARCHtoHostArch("arm64"))
+	assert.Equal(t, "x86", GOARCHtoHostArch("386"))
+	assert.Equal(t, "arm32", GOARCHtoHostArch("arm"))
+	assert.Equal(t, "ppc64", GOARCHtoHostArch("ppc64le"))
+	assert.Equal(t, "s390x", GOARCHtoHostArch("s390x"))
+}
@@ -15,6 +15,7 @@
 package system // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/system"
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
@@ -23,6 +24,8 @@ import (
 
 	"github.com/Showmax/go-fqdn"
 	"github.com/panta/machineid"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/host"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/internal"
 )
@@ -65,6 +68,24 @@ type Provider interface {
 
 	// HostID returns Host Unique Identifier
 	HostID() (string, error)
+
+	// HostArch returns the host's architecture
+	HostArch() (string, error)
+
+	// OSDescription returns a human readable description of the operating system
+	OSDescription(ctx context.Context) (string, error)
+
+	// OSVersion returns the version of the operating system
+	OSVersion(ctx context.Context) (string, error)
+
+	// HostIPs returns the addresses of the host's non-loopback network interfaces
+	HostIPs() ([]net.IP, error)
+
+	// HostMACs returns the hardware addresses of the host's non-loopback network interfaces
+	HostMACs() ([]net.HardwareAddr, error)
+
+	// CPUInfo returns the description of the host's CPUs
+	CPUInfo(ctx context.Context) ([]cpu.InfoStat, error)
 }
 
 type systemMetadataProvider struct {
@@ -131,3 +152,65 @@ func (p systemMetadataProvider) reverseLookup(ipAddresses []string) (string, err
 func (p systemMetadataProvider) HostID() (string, error) {
 	return machineid.ID()
 }
+
+func (systemMetadataProvider) HostArch() (string, error) {
+	return internal.GOARCHtoHostArch(runtime.GOARCH), nil
+}
+
+func (systemMetadataProvider) OSDescription(ctx context.Context) (string, error) {
+	info, err := host.InfoWithContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("OSDescription failed to get host info: %w", err)
+	}
+	return fmt.Sprintf("%s %s (%s %s)", info.Platform, info.PlatformVersion, info.OS, info.KernelVersion), nil
+}
+
+func (systemMetadataProvider) OSVersion(ctx context.Context) (string, error) {
+	return host.KernelVersionWithContext(ctx)
+}
+
+func (systemMetadataProvider) HostIPs() ([]net.IP, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []net.IP
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 || iface.Flags&net.FlagUp == 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.IsLinkLocalUnicast() {
+				continue
+			}
+			ips = append(ips, ipNet.IP)
+		}
+	}
+	return ips, nil
+}
+
+func (systemMetadataProvider) HostMACs() ([]net.HardwareAddr, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var macs []net.HardwareAddr
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 || len(iface.HardwareAddr) == 0 {
+			continue
+		}
+		macs = append(macs, iface.HardwareAddr)
+	}
+	return macs, nil
+}
+
+func (systemMetadataProvider) CPUInfo(ctx context.Context) ([]cpu.InfoStat, error) {
+	return cpu.InfoWithContext(ctx)
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exphistogram provides helpers to downscale and merge the buckets of exponential histograms.
+package exphistogram // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metrics/exphistogram"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Downscale returns the offset and counts of buckets whose scale is reduced by the given amount,
+// merging the 2^by buckets that map to the same bucket at the lower scale.
+func Downscale(offset int32, counts []uint64, by int32) (int32, []uint64) {
+	if by <= 0 || len(counts) == 0 {
+		return offset, counts
+	}
+
+	// Arithmetic shifts round towards negative infinity, as required for negative indices.
+	newOffset := offset >> by
+	last := (offset + int32(len(counts)) - 1) >> by
+	merged := make([]uint64, last-newOffset+1)
+	for i, count := range counts {
+		merged[((offset+int32(i))>>by)-newOffset] += count
+	}
+	return newOffset, merged
+}
+
+// DownscaleBuckets reduces the scale of exponential histogram buckets by the given amount.
+func DownscaleBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, by int32) {
+	if by <= 0 || buckets.BucketCounts().Len() == 0 {
+		return
+	}
+	offset, counts := Downscale(buckets.Offset(), buckets.BucketCounts().AsRaw(), by)
+	buckets.SetOffset(offset)
+	buckets.BucketCounts().FromRaw(counts)
+}
+
+// MergeBuckets adds the counts of the from buckets to the to buckets. Both must have the same scale.
+func MergeBuckets(to, from pmetric.ExponentialHistogramDataPointBuckets) {
+	if from.BucketCounts().Len() == 0 {
+		return
+	}
+	if to.BucketCounts().Len() == 0 {
+		from.CopyTo(to)
+		return
+	}
+
+	first := to.Offset()
+	if from.Offset() < first {
+		first = from.Offset()
+	}
+	end := to.Offset() + int32(to.BucketCounts().Len())
+	if fromEnd := from.Offset() + int32(from.BucketCounts().Len()); fromEnd > end {
+		end = fromEnd
+	}
+
+	merged := make([]uint64, end-first)
+	for i := 0; i < to.BucketCounts().Len(); i++ {
+		merged[to.Offset()+int32(i)-first] += to.BucketCounts().At(i)
+	}
+	for i := 0; i < from.BucketCounts().Len(); i++ {
+		merged[from.Offset()+int32(i)-first] += from.BucketCounts().At(i)
+	}
+	to.SetOffset(first)
+	to.BucketCounts().FromRaw(merged)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exphistogram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestDownscale(t *testing.T) {
+	// Indices -3..1 map to -2, -1, -1, 0, 0 at the lower scale.
+	offset, counts := Downscale(-3, []uint64{1, 2, 3, 4, 5}, 1)
+	assert.Equal(t, int32(-2), offset)
+	assert.Equal(t, []uint64{1, 5, 9}, counts)
+
+	// Indices 1..8 map to 0, 0, 0, 1, 1, 1, 1, 2 two scales lower.
+	offset, counts = Downscale(1, []uint64{1, 1, 1, 1, 1, 1, 1, 1}, 2)
+	assert.Equal(t, int32(0), offset)
+	assert.Equal(t, []uint64{3, 4, 1}, counts)
+
+	offset, counts = Downscale(3, []uint64{1, 2}, 0)
+	assert.Equal(t, int32(3), offset)
+	assert.Equal(t, []uint64{1, 2}, counts)
+}
+
+func TestDownscaleBuckets(t *testing.T) {
+	buckets := pmetric.NewExponentialHistogramDataPointBuckets()
+	buckets.SetOffset(-3)
+	buckets.BucketCounts().FromRaw([]uint64{1, 2, 3, 4, 5})
+
+	DownscaleBuckets(buckets, 1)
+	assert.Equal(t, int32(-2), buckets.Offset())
+	assert.Equal(t, []uint64{1, 5, 9}, buckets.BucketCounts().AsRaw())
+}
+
+func TestMergeBuckets(t *testing.T) {
+	to := pmetric.NewExponentialHistogramDataPointBuckets()
+	to.SetOffset(2)
+	to.BucketCounts().FromRaw([]uint64{1, 1})
+	from := pmetric.NewExponentialHistogramDataPointBuckets()
+	from.SetOffset(0)
+	from.BucketCounts().FromRaw([]uint64{2, 0, 3})
+
+	MergeBuckets(to, from)
+	assert.Equal(t, int32(0), to.Offset())
+	assert.Equal(t, []uint64{2, 0, 4, 1}, to.BucketCounts().AsRaw())
+
+	empty := pmetric.NewExponentialHistogramDataPointBuckets()
+	MergeBuckets(empty, from)
+	assert.Equal(t, int32(0), empty.Offset())
+	assert.Equal(t, []uint64{2, 0, 3}, empty.BucketCounts().AsRaw())
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package identity identifies the streams of metric data points across batches.
+package identity // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metrics/identity"
+
+import (
+	"bytes"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+const (
+	a   = int32('A')
+	sep = byte(0x1E)
+)
+
+// Stream identifies the data points of a metric of one resource and scope sharing the same attributes.
+// Unlike the identity of cumulative streams, the start timestamp is not part of it, so that delta
+// points, which each have their own start timestamp, belong to the same stream.
+type Stream struct {
+	Resource               pcommon.Resource
+	InstrumentationLibrary pcommon.InstrumentationScope
+	MetricType             pmetric.MetricType
+	MetricIsMonotonic      bool
+	AggregationTemporality pmetric.AggregationTemporality
+	MetricName             string
+	MetricUnit             string
+	Attributes             pcommon.Map
+	MetricValueType        pmetric.NumberDataPointValueType
+}
+
+// WriteResource writes the part of the identity identifying the resource of the stream.
+func (s *Stream) WriteResource(b *bytes.Buffer) {
+	if s.Resource.Attributes().Len() > 0 {
+		resourceHash := pdatautil.MapHash(s.Resource.Attributes())
+		b.Write(resourceHash[:])
+	}
+}
+
+// WriteScope writes the part of the identity identifying the resource and scope of the stream.
+func (s *Stream) WriteScope(b *bytes.Buffer) {
+	s.WriteResource(b)
+	b.WriteByte(sep)
+	b.WriteString(s.InstrumentationLibrary.Name())
+	b.WriteByte(sep)
+	b.WriteString(s.InstrumentationLibrary.Version())
+	if s.InstrumentationLibrary.Attributes().Len() > 0 {
+		b.WriteByte(sep)
+		scopeHash := pdatautil.MapHash(s.InstrumentationLibrary.Attributes())
+		b.Write(scopeHash[:])
+	}
+}
+
+// WriteMetric writes the part of the identity identifying the resource, scope and metric of the stream.
+func (s *Stream) WriteMetric(b *bytes.Buffer) {
+	s.WriteScope(b)
+	b.WriteByte(sep)
+	b.WriteRune(a + int32(s.MetricType))
+	b.WriteRune(a + int32(s.AggregationTemporality))
+	if s.MetricIsMonotonic {
+		b.WriteByte('Y')
+	} else {
+		b.WriteByte('N')
+	}
+	b.WriteByte(sep)
+	b.WriteString(s.MetricName)
+	b.WriteByte(sep)
+	b.WriteString(s.MetricUnit)
+}
+
+// Write writes the identity of the stream.
+func (s *Stream) Write(b *bytes.Buffer) {
+	s.WriteMetric(b)
+	b.WriteByte(sep)
+	b.WriteRune(a + int32(s.MetricValueType))
+	if s.Attributes.Len() > 0 {
+		b.WriteByte(sep)
+		attrsHash := pdatautil.MapHash(s.Attributes)
+		b.Write(attrsHash[:])
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newStream() Stream {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("scope")
+	scope.SetVersion("v1")
+	attributes := pcommon.NewMap()
+	attributes.PutStr("label", "value")
+	return Stream{
+		Resource:               resource,
+		InstrumentationLibrary: scope,
+		MetricType:             pmetric.MetricTypeSum,
+		MetricIsMonotonic:      true,
+		AggregationTemporality: pmetric.AggregationTemporalityDelta,
+		MetricName:             "requests",
+		MetricUnit:             "1",
+		Attributes:             attributes,
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+	}
+}
+
+func key(write func(*bytes.Buffer)) string {
+	b := &bytes.Buffer{}
+	write(b)
+	return b.String()
+}
+
+func TestStreamWrite(t *testing.T) {
+	base := newStream()
+	baseKey := key(base.Write)
+
+	// The parts of the identity are prefixes of each other.
+	assert.Contains(t, key(base.WriteScope), key(base.WriteResource))
+	assert.Contains(t, key(base.WriteMetric), key(base.WriteScope))
+	assert.Contains(t, baseKey, key(base.WriteMetric))
+
+	tests := []struct {
+		name   string
+		change func(*Stream)
+	}{
+		{name: "resource", change: func(s *Stream) { s.Resource.Attributes().PutStr("service.name", "cart") }},
+		{name: "scope name", change: func(s *Stream) { s.InstrumentationLibrary.SetName("other") }},
+		{name: "scope version", change: func(s *Stream) { s.InstrumentationLibrary.SetVersion("v2") }},
+		{name: "scope attributes", change: func(s *Stream) { s.InstrumentationLibrary.Attributes().PutBool("scope", true) }},
+		{name: "type", change: func(s *Stream) { s.MetricType = pmetric.MetricTypeGauge }},
+		{name: "monotonic", change: func(s *Stream) { s.MetricIsMonotonic = false }},
+		{name: "temporality", change: func(s *Stream) { s.AggregationTemporality = pmetric.AggregationTemporalityCumulative }},
+		{name: "name", change: func(s *Stream) { s.MetricName = "other" }},
+		{name: "unit", change: func(s *Stream) { s.MetricUnit = "ms" }},
+		{name: "attributes", change: func(s *Stream) { s.Attributes.PutStr("label", "other") }},
+		{name: "value type", change: func(s *Stream) { s.MetricValueType = pmetric.NumberDataPointValueTypeDouble }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStream()
+			tt.change(&s)
+			assert.NotEqual(t, baseKey, key(s.Write))
+		})
+	}
+
+	same := newStream()
+	assert.Equal(t, baseKey, key(same.Write))
+}
@@ -52,6 +52,20 @@ func (scs *SharedComponents) GetOrAdd(key interface{}, create func() component.C
 	return newComp
 }
 
+// GetOrAddWithError returns the already created instance if exists, otherwise creates a new instance
+// and adds it to the map of references. The instance is only added when create succeeds, so that a
+// failed creation is retried by the next call.
+func (scs *SharedComponents) GetOrAddWithError(key interface{}, create func() (component.Component, error)) (*SharedComponent, error) {
+	if c, ok := scs.comps[key]; ok {
+		return c, nil
+	}
+	comp, err := create()
+	if err != nil {
+		return nil, err
+	}
+	return scs.GetOrAdd(key, func() component.Component { return comp }), nil
+}
+
 // SharedComponent ensures that the wrapped component is started and stopped only once.
 // When stopped it is removed from the SharedComponents map.
 type SharedComponent struct {
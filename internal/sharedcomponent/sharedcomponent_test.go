@@ -52,6 +52,30 @@ func TestSharedComponents_GetOrAdd(t *testing.T) {
 	assert.NotSame(t, got, comps.GetOrAdd(id, createNop))
 }
 
+func TestSharedComponents_GetOrAddWithError(t *testing.T) {
+	wantErr := errors.New("my error")
+	comps := NewSharedComponents()
+	_, err := comps.GetOrAddWithError(id, func() (component.Component, error) { return nil, wantErr })
+	assert.Equal(t, wantErr, err)
+	assert.Len(t, comps.comps, 0)
+
+	nop := &mockComponent{}
+	createNop := func() (component.Component, error) { return nop, nil }
+	got, err := comps.GetOrAddWithError(id, createNop)
+	assert.NoError(t, err)
+	assert.Len(t, comps.comps, 1)
+	assert.Same(t, nop, got.Unwrap())
+
+	created := false
+	again, err := comps.GetOrAddWithError(id, func() (component.Component, error) {
+		created = true
+		return nop, nil
+	})
+	assert.NoError(t, err)
+	assert.Same(t, got, again)
+	assert.False(t, created, "the shared instance must be reused without creating a new one")
+}
+
 func TestSharedComponent(t *testing.T) {
 	wantErr := errors.New("my error")
 	calledStart := 0
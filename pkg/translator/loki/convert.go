@@ -83,10 +83,22 @@ func convertAttributesAndMerge(logAttrs pcommon.Map, resAttrs pcommon.Map) model
 	return out
 }
 
+// convertDefaultLabels returns the labels configured to be set on every record,
+// which the hints may override.
+func convertDefaultLabels(logAttrs pcommon.Map, resAttrs pcommon.Map, o options) model.LabelSet {
+	out := model.LabelSet{}
+	out = out.Merge(selectAttributesAsLabels(resAttrs, o.resourceLabels))
+	out = out.Merge(selectAttributesAsLabels(logAttrs, o.attributeLabels))
+	return out
+}
+
 func convertAttributesToLabels(attributes pcommon.Map, attrsToSelect pcommon.Value) model.LabelSet {
+	return selectAttributesAsLabels(attributes, parseAttributeNames(attrsToSelect))
+}
+
+func selectAttributesAsLabels(attributes pcommon.Map, attrs []string) model.LabelSet {
 	out := model.LabelSet{}
 
-	attrs := parseAttributeNames(attrsToSelect)
 	for _, attr := range attrs {
 		attr = strings.TrimSpace(attr)
 
@@ -29,6 +29,10 @@ import (
 type PushRequest struct {
 	*push.PushRequest
 	Report *PushReport
+	// StructuredMetadata holds the structured metadata of each entry of the
+	// streams, indexed like the streams and their entries. It is only set with
+	// the WithStructuredMetadata option; use Marshal to encode it.
+	StructuredMetadata [][]model.LabelSet
 }
 
 // PushReport contains the summary for the outcome of a LogsToLoki operation
@@ -59,7 +63,10 @@ const (
 // batch or send only the data that could be parsed. The caller can use the PushReport
 // to make this decision, as it includes all of the errors that were encountered,
 // as well as the number of items dropped and submitted.
-func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
+// The options allow setting labels by default, without hints, and sending the
+// remaining attributes as structured metadata.
+func LogsToLokiRequests(ld plog.Logs, opts ...Option) map[string]PushRequest {
+	o := newOptions(opts)
 	groups := map[string]pushRequestGroup{}
 
 	rls := ld.ResourceLogs()
@@ -76,13 +83,14 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 				group, ok := groups[tenant]
 				if !ok {
 					group = pushRequestGroup{
-						report:  &PushReport{},
-						streams: make(map[string]*push.Stream),
+						report:   &PushReport{},
+						streams:  make(map[string]*push.Stream),
+						metadata: make(map[string][]model.LabelSet),
 					}
 					groups[tenant] = group
 				}
 
-				entry, err := LogToLokiEntry(log, resource, scope)
+				entry, err := logToLokiEntry(log, resource, scope, o)
 				if err != nil {
 					// Couldn't convert so dropping log.
 					group.report.Errors = append(group.report.Errors, fmt.Errorf("failed to convert, dropping log: %w", err))
@@ -102,6 +110,13 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 
 				// create the stream name based on the labels
 				labels := processed.String()
+				if o.structuredMetadata {
+					metadata := model.LabelSet{}
+					for name, value := range entry.StructuredMetadata {
+						metadata[model.LabelName(prometheustranslator.NormalizeLabel(string(name)))] = value
+					}
+					group.metadata[labels] = append(group.metadata[labels], metadata)
+				}
 				if stream, ok := group.streams[labels]; ok {
 					stream.Entries = append(stream.Entries, *entry.Entry)
 					continue
@@ -121,14 +136,23 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 			Streams: make([]push.Stream, len(g.streams)),
 		}
 
+		var metadata [][]model.LabelSet
+		if o.structuredMetadata {
+			metadata = make([][]model.LabelSet, len(g.streams))
+		}
+
 		i := 0
-		for _, stream := range g.streams {
+		for labels, stream := range g.streams {
 			pr.Streams[i] = *stream
+			if metadata != nil {
+				metadata[i] = g.metadata[labels]
+			}
 			i++
 		}
 		requests[tenant] = PushRequest{
-			PushRequest: pr,
-			Report:      g.report,
+			PushRequest:        pr,
+			Report:             g.report,
+			StructuredMetadata: metadata,
 		}
 	}
 	return requests
@@ -138,10 +162,16 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 type PushEntry struct {
 	Entry  *push.Entry
 	Labels model.LabelSet
+	// StructuredMetadata is only set with the WithStructuredMetadata option.
+	StructuredMetadata model.LabelSet
 }
 
 // LogToLokiEntry converts LogRecord into Loki log entry enriched with labels and tenant
-func LogToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.InstrumentationScope) (*PushEntry, error) {
+func LogToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.InstrumentationScope, opts ...Option) (*PushEntry, error) {
+	return logToLokiEntry(lr, rl, scope, newOptions(opts))
+}
+
+func logToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.InstrumentationScope, o options) (*PushEntry, error) {
 	// we may remove attributes, so change only our version
 	log := plog.NewLogRecord()
 	lr.CopyTo(log)
@@ -155,19 +185,29 @@ func LogToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.Instru
 
 	format := getFormatFromFormatHint(log.Attributes(), resource.Attributes())
 
-	mergedLabels := convertAttributesAndMerge(log.Attributes(), resource.Attributes())
+	mergedLabels := convertDefaultLabels(log.Attributes(), resource.Attributes(), o).
+		Merge(convertAttributesAndMerge(log.Attributes(), resource.Attributes()))
 	// remove the attributes that were promoted to labels
 	removeAttributes(log.Attributes(), mergedLabels)
 	removeAttributes(resource.Attributes(), mergedLabels)
 
+	var metadata model.LabelSet
+	if o.structuredMetadata {
+		// the remaining attributes are sent along the line instead of in it
+		metadata = convertAttributesToStructuredMetadata(log.Attributes(), resource.Attributes())
+		log.Attributes().Clear()
+		resource.Attributes().Clear()
+	}
+
 	entry, err := convertLogToLokiEntry(log, resource, format, scope)
 	if err != nil {
 		return nil, err
 	}
 
 	return &PushEntry{
-		Entry:  entry,
-		Labels: mergedLabels,
+		Entry:              entry,
+		Labels:             mergedLabels,
+		StructuredMetadata: metadata,
 	}, nil
 }
 
@@ -207,8 +247,9 @@ func GetTenantFromTenantHint(logAttr pcommon.Map, resourceAttr pcommon.Map) stri
 }
 
 type pushRequestGroup struct {
-	streams map[string]*push.Stream
-	report  *PushReport
+	streams  map[string]*push.Stream
+	metadata map[string][]model.LabelSet
+	report   *PushReport
 }
 
 // LogsToLoki converts a Logs pipeline data into a Loki PushRequest.
@@ -641,9 +641,70 @@ func TestLogToLokiEntry(t *testing.T) {
 		attrs                map[string]interface{}
 		hints                map[string]interface{}
 		instrumentationScope *instrumentationScope
+		opts                 []Option
 		expected             *PushEntry
 		err                  error
 	}{
+		{
+			name:      "with default labels",
+			timestamp: time.Unix(0, 1677592916000000000),
+			res: map[string]interface{}{
+				"service.name": "checkout",
+				"region.az":    "eu-west-1a",
+			},
+			attrs: map[string]interface{}{
+				"http.method": "GET",
+				"http.status": 200,
+			},
+			opts: []Option{
+				WithDefaultResourceLabels("service.name"),
+				WithDefaultAttributeLabels("http.method", "missing"),
+			},
+			expected: &PushEntry{
+				Entry: &push.Entry{
+					Timestamp: time.Unix(0, 1677592916000000000),
+					Line:      `{"attributes":{"http.status":200},"resources":{"region.az":"eu-west-1a"}}`,
+				},
+				Labels: model.LabelSet{
+					"exporter":     "OTLP",
+					"job":          "checkout",
+					"service.name": "checkout",
+					"http.method":  "GET",
+				},
+			},
+		},
+		{
+			name:      "with structured metadata",
+			timestamp: time.Unix(0, 1677592916000000000),
+			res: map[string]interface{}{
+				"service.name": "checkout",
+				"region.az":    "eu-west-1a",
+			},
+			attrs: map[string]interface{}{
+				"http.method": "GET",
+				"http.status": 200,
+			},
+			hints: map[string]interface{}{
+				hintAttributes: "http.method",
+			},
+			opts: []Option{WithStructuredMetadata()},
+			expected: &PushEntry{
+				Entry: &push.Entry{
+					Timestamp: time.Unix(0, 1677592916000000000),
+					Line:      `{}`,
+				},
+				Labels: model.LabelSet{
+					"exporter":    "OTLP",
+					"job":         "checkout",
+					"http.method": "GET",
+				},
+				StructuredMetadata: model.LabelSet{
+					"service.name": "checkout",
+					"region.az":    "eu-west-1a",
+					"http.status":  "200",
+				},
+			},
+		},
 		{
 			name:      "with attribute to label and regular attribute",
 			timestamp: time.Unix(0, 1677592916000000000),
@@ -803,7 +864,7 @@ func TestLogToLokiEntry(t *testing.T) {
 				lr.Attributes().PutStr(levelAttributeName, tt.levelAttribute)
 			}
 
-			log, err := LogToLokiEntry(lr, resource, scope)
+			log, err := LogToLokiEntry(lr, resource, scope, tt.opts...)
 			assert.Equal(t, tt.err, err)
 			assert.Equal(t, tt.expected, log)
 		})
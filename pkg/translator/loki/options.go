@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+
+// Option customizes the conversion of log records into Loki entries.
+type Option func(*options)
+
+type options struct {
+	resourceLabels     []string
+	attributeLabels    []string
+	structuredMetadata bool
+}
+
+func newOptions(opts []Option) options {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithDefaultResourceLabels promotes the given resource attributes to labels
+// for every log record, in addition to the ones listed in the
+// "loki.resource.labels" hint.
+func WithDefaultResourceLabels(attributes ...string) Option {
+	return func(o *options) {
+		o.resourceLabels = append(o.resourceLabels, attributes...)
+	}
+}
+
+// WithDefaultAttributeLabels promotes the given log attributes to labels for
+// every log record, in addition to the ones listed in the
+// "loki.attribute.labels" hint.
+func WithDefaultAttributeLabels(attributes ...string) Option {
+	return func(o *options) {
+		o.attributeLabels = append(o.attributeLabels, attributes...)
+	}
+}
+
+// WithStructuredMetadata sends the resource and log attributes that aren't
+// promoted to labels as structured metadata of the entries, instead of
+// encoding them in the log line.
+func WithStructuredMetadata() Option {
+	return func(o *options) {
+		o.structuredMetadata = true
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+
+import (
+	"sort"
+
+	"github.com/prometheus/common/model"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the Loki push API messages, see
+// https://github.com/grafana/loki/blob/main/pkg/push/push.proto
+const (
+	pushRequestStreamsField  protowire.Number = 1
+	streamLabelsField        protowire.Number = 1
+	streamEntriesField       protowire.Number = 2
+	streamHashField          protowire.Number = 3
+	entryStructuredMetaField protowire.Number = 3
+	labelPairNameField       protowire.Number = 1
+	labelPairValueField      protowire.Number = 2
+)
+
+// convertAttributesToStructuredMetadata returns the resource and log attributes as
+// structured metadata, the log attributes taking precedence.
+func convertAttributesToStructuredMetadata(logAttrs pcommon.Map, resAttrs pcommon.Map) model.LabelSet {
+	out := model.LabelSet{}
+	resAttrs.Range(func(k string, v pcommon.Value) bool {
+		out[model.LabelName(k)] = model.LabelValue(v.AsString())
+		return true
+	})
+	logAttrs.Range(func(k string, v pcommon.Value) bool {
+		out[model.LabelName(k)] = model.LabelValue(v.AsString())
+		return true
+	})
+	return out
+}
+
+// Marshal encodes the request in the protobuf format of the Loki push API. The
+// push types don't have the structured metadata of the entries, so it is
+// appended to the encoded entries, as the structuredMetadata field supported
+// by Loki 2.9 and newer.
+func (r PushRequest) Marshal() ([]byte, error) {
+	if len(r.StructuredMetadata) == 0 {
+		return r.PushRequest.Marshal()
+	}
+
+	var buf []byte
+	for i, stream := range r.Streams {
+		var s []byte
+		s = protowire.AppendTag(s, streamLabelsField, protowire.BytesType)
+		s = protowire.AppendString(s, stream.Labels)
+		for j := range stream.Entries {
+			entry, err := stream.Entries[j].Marshal()
+			if err != nil {
+				return nil, err
+			}
+			if i < len(r.StructuredMetadata) && j < len(r.StructuredMetadata[i]) {
+				entry = appendStructuredMetadata(entry, r.StructuredMetadata[i][j])
+			}
+			s = protowire.AppendTag(s, streamEntriesField, protowire.BytesType)
+			s = protowire.AppendBytes(s, entry)
+		}
+		if stream.Hash != 0 {
+			s = protowire.AppendTag(s, streamHashField, protowire.VarintType)
+			s = protowire.AppendVarint(s, stream.Hash)
+		}
+		buf = protowire.AppendTag(buf, pushRequestStreamsField, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, s)
+	}
+	return buf, nil
+}
+
+// appendStructuredMetadata appends the metadata, sorted by name, to the encoded entry.
+func appendStructuredMetadata(entry []byte, metadata model.LabelSet) []byte {
+	names := make([]string, 0, len(metadata))
+	for name := range metadata {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var pair []byte
+		pair = protowire.AppendTag(pair, labelPairNameField, protowire.BytesType)
+		pair = protowire.AppendString(pair, name)
+		pair = protowire.AppendTag(pair, labelPairValueField, protowire.BytesType)
+		pair = protowire.AppendString(pair, string(metadata[model.LabelName(name)]))
+		entry = protowire.AppendTag(entry, entryStructuredMetaField, protowire.BytesType)
+		entry = protowire.AppendBytes(entry, pair)
+	}
+	return entry
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/loki/pkg/push"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestLogsToLokiRequestsWithStructuredMetadata(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	logs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, status := range []int64{200, 500} {
+		lr := logs.AppendEmpty()
+		lr.SetTimestamp(1677592916000000000)
+		lr.Body().SetStr("request")
+		lr.Attributes().PutInt("http.status", status)
+	}
+
+	requests := LogsToLokiRequests(ld, WithStructuredMetadata())
+	require.Len(t, requests, 1)
+	request := requests[""]
+	require.Len(t, request.Streams, 1)
+	assert.Equal(t, `{exporter="OTLP", job="checkout"}`, request.Streams[0].Labels)
+	for _, entry := range request.Streams[0].Entries {
+		assert.Equal(t, `{"body":"request"}`, entry.Line)
+	}
+	assert.Equal(t, [][]model.LabelSet{{
+		{"service_name": "checkout", "http_status": "200"},
+		{"service_name": "checkout", "http_status": "500"},
+	}}, request.StructuredMetadata)
+}
+
+func TestPushRequestMarshal(t *testing.T) {
+	request := PushRequest{
+		PushRequest: &push.PushRequest{
+			Streams: []push.Stream{{
+				Labels: `{job="checkout"}`,
+				Entries: []push.Entry{
+					{Timestamp: time.Unix(0, 1677592916000000000), Line: "first"},
+					{Timestamp: time.Unix(0, 1677592917000000000), Line: "second"},
+				},
+			}},
+		},
+		StructuredMetadata: [][]model.LabelSet{{
+			{"trace_id": "abc", "http_status": "200"},
+			{},
+		}},
+	}
+
+	buf, err := request.Marshal()
+	require.NoError(t, err)
+
+	// the push types ignore the structured metadata
+	decoded := &push.PushRequest{}
+	require.NoError(t, decoded.Unmarshal(buf))
+	assert.Equal(t, request.Streams[0].Labels, decoded.Streams[0].Labels)
+	require.Len(t, decoded.Streams[0].Entries, 2)
+	assert.Equal(t, "first", decoded.Streams[0].Entries[0].Line)
+	assert.Equal(t, "second", decoded.Streams[0].Entries[1].Line)
+
+	entries := fields(t, fields(t, buf)[pushRequestStreamsField][0])[streamEntriesField]
+	require.Len(t, entries, 2)
+	var metadata [][2]string
+	for _, pair := range fields(t, entries[0])[entryStructuredMetaField] {
+		f := fields(t, pair)
+		metadata = append(metadata, [2]string{string(f[labelPairNameField][0]), string(f[labelPairValueField][0])})
+	}
+	assert.Equal(t, [][2]string{{"http_status", "200"}, {"trace_id", "abc"}}, metadata)
+	assert.Empty(t, fields(t, entries[1])[entryStructuredMetaField])
+}
+
+func TestPushRequestMarshalWithoutStructuredMetadata(t *testing.T) {
+	request := PushRequest{
+		PushRequest: &push.PushRequest{
+			Streams: []push.Stream{{
+				Labels:  `{job="checkout"}`,
+				Entries: []push.Entry{{Timestamp: time.Unix(0, 1677592916000000000), Line: "first"}},
+			}},
+		},
+	}
+
+	buf, err := request.Marshal()
+	require.NoError(t, err)
+	expected, err := request.PushRequest.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, expected, buf)
+}
+
+// fields returns the values of the length-delimited fields of the encoded message, by field number.
+func fields(t *testing.T, b []byte) map[protowire.Number][][]byte {
+	out := map[protowire.Number][][]byte{}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			require.GreaterOrEqual(t, n, 0)
+			b = b[n:]
+			continue
+		}
+		v, n := protowire.ConsumeBytes(b)
+		require.GreaterOrEqual(t, n, 0)
+		out[num] = append(out[num], v)
+		b = b[n:]
+	}
+	return out
+}
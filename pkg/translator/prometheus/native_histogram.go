@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// BucketSpan is a span of consecutive buckets of a native histogram. The offset of the first span is the index of
+// its first bucket, the offsets of the following spans are the number of empty buckets since the previous span.
+type BucketSpan struct {
+	Offset int32
+	Length uint32
+}
+
+// ConvertNativeHistogramBuckets expands the sparse buckets of a native histogram, given by their spans and absolute
+// counts, into the dense buckets of an exponential histogram. The index of the first bucket is shifted by one, as
+// the Prometheus bucket of index i covers (base^(i-1), base^i] while the OTLP one covers (base^i, base^(i+1)].
+func ConvertNativeHistogramBuckets(spans []BucketSpan, counts []float64, dest pmetric.ExponentialHistogramDataPointBuckets) {
+	if len(spans) == 0 {
+		return
+	}
+
+	var bucketCounts []uint64
+	pos := 0
+	for i, span := range spans {
+		if pos >= len(counts) {
+			break
+		}
+		// the offset of the first span is the index of the first bucket, the other ones are gaps.
+		if i > 0 {
+			for j := int32(0); j < span.Offset; j++ {
+				bucketCounts = append(bucketCounts, 0)
+			}
+		}
+		for j := uint32(0); j < span.Length && pos < len(counts); j++ {
+			bucketCounts = append(bucketCounts, uint64(counts[pos]))
+			pos++
+		}
+	}
+
+	dest.SetOffset(spans[0].Offset - 1)
+	dest.BucketCounts().FromRaw(bucketCounts)
+}
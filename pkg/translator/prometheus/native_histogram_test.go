@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestConvertNativeHistogramBuckets(t *testing.T) {
+	tests := []struct {
+		name           string
+		spans          []BucketSpan
+		counts         []float64
+		expectedOffset int32
+		expectedCounts []uint64
+	}{
+		{
+			name: "no spans",
+		},
+		{
+			name:           "one span",
+			spans:          []BucketSpan{{Offset: 2, Length: 3}},
+			counts:         []float64{1, 2, 3},
+			expectedOffset: 1,
+			expectedCounts: []uint64{1, 2, 3},
+		},
+		{
+			name:           "spans with a gap",
+			spans:          []BucketSpan{{Offset: -1, Length: 2}, {Offset: 2, Length: 1}},
+			counts:         []float64{4, 5, 6},
+			expectedOffset: -2,
+			expectedCounts: []uint64{4, 5, 0, 0, 6},
+		},
+		{
+			name:           "fewer counts than buckets",
+			spans:          []BucketSpan{{Offset: 0, Length: 2}, {Offset: 1000, Length: 2}},
+			counts:         []float64{1, 2},
+			expectedOffset: -1,
+			expectedCounts: []uint64{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := pmetric.NewExponentialHistogramDataPointBuckets()
+			ConvertNativeHistogramBuckets(tt.spans, tt.counts, dest)
+			assert.Equal(t, tt.expectedOffset, dest.Offset())
+			assert.Equal(t, tt.expectedCounts, dest.BucketCounts().AsRaw())
+		})
+	}
+}
@@ -188,6 +188,41 @@ func normalizeName(metric pmetric.Metric, namespace string) string {
 	return normalizedName
 }
 
+// Build a Prometheus-compliant unit for the specified metric, as sent in the metric metadata
+//
+// The unit is translated with the same rules as the unit suffix of normalized metric names,
+// e.g. "By/s" becomes "bytes_per_second". Annotations (units between curly braces) are dropped.
+func BuildPromCompliantUnit(metric pmetric.Metric) string {
+	// Metrics with unit "1" are ratios, for gauges only, see normalizeName
+	if metric.Unit() == "1" {
+		if metric.Type() == pmetric.MetricTypeGauge {
+			return "ratio"
+		}
+		return ""
+	}
+
+	var unitTokens []string
+	otelUnitTokens := strings.SplitN(metric.Unit(), "/", 2)
+
+	mainUnitOtel := strings.TrimSpace(otelUnitTokens[0])
+	if mainUnitOtel != "" && !strings.ContainsAny(mainUnitOtel, "{}") {
+		if mainUnitProm := CleanUpString(unitMapGetOrDefault(mainUnitOtel)); mainUnitProm != "" {
+			unitTokens = append(unitTokens, mainUnitProm)
+		}
+	}
+
+	if len(otelUnitTokens) > 1 {
+		perUnitOtel := strings.TrimSpace(otelUnitTokens[1])
+		if perUnitOtel != "" && !strings.ContainsAny(perUnitOtel, "{}") {
+			if perUnitProm := CleanUpString(perUnitMapGetOrDefault(perUnitOtel)); perUnitProm != "" {
+				unitTokens = append(unitTokens, "per", perUnitProm)
+			}
+		}
+	}
+
+	return strings.Join(unitTokens, "_")
+}
+
 type Normalizer struct {
 	gate *featuregate.Gate
 }
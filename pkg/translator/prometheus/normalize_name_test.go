@@ -258,3 +258,16 @@ func TestBuildPromCompliantNameWithoutNormalize(t *testing.T) {
 	require.Equal(t, ":foo::bar", BuildPromCompliantName(createCounter(":foo::bar", ""), ""))
 
 }
+
+func TestBuildPromCompliantUnit(t *testing.T) {
+
+	require.Equal(t, "seconds", BuildPromCompliantUnit(createGauge("system.cpu.time", "s")))
+	require.Equal(t, "bytes_per_second", BuildPromCompliantUnit(createCounter("network.io", "By/s")))
+	require.Equal(t, "ratio", BuildPromCompliantUnit(createGauge("system.memory.utilization", "1")))
+	require.Equal(t, "", BuildPromCompliantUnit(createCounter("system.processes.created", "1")))
+	require.Equal(t, "", BuildPromCompliantUnit(createCounter("system.processes.created", "{processes}")))
+	require.Equal(t, "packets_per_second", BuildPromCompliantUnit(createGauge("network.packets", "packets/s")))
+	require.Equal(t, "", BuildPromCompliantUnit(createGauge("network.packets", "{packets}/{interval}")))
+	require.Equal(t, "", BuildPromCompliantUnit(createGauge("system.disk.operations", "")))
+
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestOtelMetricsToMetadata(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, resource := range []string{"a", "b"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", resource)
+		metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+		gauge := metrics.AppendEmpty()
+		gauge.SetName("system.memory.usage")
+		gauge.SetDescription("Bytes of memory in use.")
+		gauge.SetUnit("By")
+		gauge.SetEmptyGauge()
+
+		counter := metrics.AppendEmpty()
+		counter.SetName("http.server.requests")
+		counter.SetDescription("Number of requests.")
+		counter.SetUnit("{requests}")
+		counter.SetEmptySum().SetIsMonotonic(true)
+		counter.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		upDownCounter := metrics.AppendEmpty()
+		upDownCounter.SetName("http.server.active_requests")
+		upDownCounter.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		delta := metrics.AppendEmpty()
+		delta.SetName("http.server.delta")
+		delta.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+
+		histogram := metrics.AppendEmpty()
+		histogram.SetName("http.server.duration")
+		histogram.SetDescription("Duration of the requests.")
+		histogram.SetUnit("s")
+		histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		exponentialHistogram := metrics.AppendEmpty()
+		exponentialHistogram.SetName("http.client.duration")
+		exponentialHistogram.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		summary := metrics.AppendEmpty()
+		summary.SetName("rpc.latency")
+		summary.SetEmptySummary()
+	}
+
+	metadata := OtelMetricsToMetadata(md, Settings{Namespace: "otel"})
+	assert.Equal(t, []prompb.MetricMetadata{
+		{
+			Type:             prompb.MetricMetadata_GAUGE,
+			MetricFamilyName: "otel_system_memory_usage_bytes",
+			Help:             "Bytes of memory in use.",
+			Unit:             "bytes",
+		},
+		{
+			Type:             prompb.MetricMetadata_COUNTER,
+			MetricFamilyName: "otel_http_server_requests_total",
+			Help:             "Number of requests.",
+		},
+		{
+			Type:             prompb.MetricMetadata_GAUGE,
+			MetricFamilyName: "otel_http_server_active_requests",
+		},
+		{
+			Type:             prompb.MetricMetadata_HISTOGRAM,
+			MetricFamilyName: "otel_http_server_duration_seconds",
+			Help:             "Duration of the requests.",
+			Unit:             "seconds",
+		},
+		{
+			Type:             prompb.MetricMetadata_HISTOGRAM,
+			MetricFamilyName: "otel_http_client_duration",
+		},
+		{
+			Type:             prompb.MetricMetadata_SUMMARY,
+			MetricFamilyName: "otel_rpc_latency",
+		},
+	}, metadata)
+}
+
+func TestOtelMetricsToMetadataEmpty(t *testing.T) {
+	assert.Empty(t, OtelMetricsToMetadata(pmetric.NewMetrics(), Settings{}))
+}
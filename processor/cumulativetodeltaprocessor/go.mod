@@ -4,6 +4,7 @@ go 1.19
 
 require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter v0.75.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/metrics v0.75.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil v0.75.0
 	github.com/stretchr/testify v1.8.2
 	go.opentelemetry.io/collector v0.75.0
@@ -54,4 +55,6 @@ retract v0.65.0
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl => ../../pkg/ottl
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/metrics => ../../internal/metrics
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil => ../../pkg/pdatautil
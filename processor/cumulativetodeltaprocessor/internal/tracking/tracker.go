@@ -41,10 +41,11 @@ type State struct {
 }
 
 type DeltaValue struct {
-	StartTimestamp pcommon.Timestamp
-	FloatValue     float64
-	IntValue       int64
-	HistogramValue *HistogramPoint
+	StartTimestamp            pcommon.Timestamp
+	FloatValue                float64
+	IntValue                  int64
+	HistogramValue            *HistogramPoint
+	ExponentialHistogramValue *ExponentialHistogramPoint
 }
 
 func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration) *MetricTracker {
@@ -119,6 +120,21 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 		}
 
 		out.HistogramValue = &delta
+	case pmetric.MetricTypeExponentialHistogram:
+		value := metricPoint.ExponentialHistogramValue
+		prevValue := state.PrevPoint.ExponentialHistogramValue
+		if math.IsNaN(value.Sum) {
+			value.Sum = prevValue.Sum
+		}
+
+		delta := value.Clone()
+
+		// Calculate deltas unless the histogram was reset
+		if reset := delta.subtract(prevValue); reset {
+			delta = value.Clone()
+		}
+
+		out.ExponentialHistogramValue = &delta
 	case pmetric.MetricTypeSum:
 		if metricID.IsFloatVal() {
 			value := metricPoint.FloatValue
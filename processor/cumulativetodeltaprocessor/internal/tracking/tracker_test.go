@@ -245,3 +245,123 @@ func Test_metricTracker_sweeper(t *testing.T) {
 		t.Errorf("Sweeper did not terminate.")
 	}
 }
+
+func TestMetricTracker_ConvertExponentialHistogram(t *testing.T) {
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeExponentialHistogram,
+		MetricIsMonotonic:      true,
+		Attributes:             pcommon.NewMap(),
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+	}
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+
+	tests := []struct {
+		name    string
+		value   ExponentialHistogramPoint
+		wantOut ExponentialHistogramPoint
+		noOut   bool
+	}{
+		{
+			name: "Initial Value Omitted",
+			value: ExponentialHistogramPoint{
+				Count:     12,
+				Sum:       40,
+				ZeroCount: 2,
+				Scale:     1,
+				Positive:  ExponentialBuckets{Offset: 2, BucketCounts: []uint64{3, 4}},
+				Negative:  ExponentialBuckets{Offset: -1, BucketCounts: []uint64{3}},
+			},
+			noOut: true,
+		},
+		{
+			name: "Offset Change Aligned",
+			value: ExponentialHistogramPoint{
+				Count:     20,
+				Sum:       65,
+				ZeroCount: 3,
+				Scale:     1,
+				Positive:  ExponentialBuckets{Offset: 1, BucketCounts: []uint64{2, 5, 4, 1}},
+				Negative:  ExponentialBuckets{Offset: -1, BucketCounts: []uint64{5}},
+			},
+			wantOut: ExponentialHistogramPoint{
+				Count:     8,
+				Sum:       25,
+				ZeroCount: 1,
+				Scale:     1,
+				Positive:  ExponentialBuckets{Offset: 1, BucketCounts: []uint64{2, 2, 0, 1}},
+				Negative:  ExponentialBuckets{Offset: -1, BucketCounts: []uint64{2}},
+			},
+		},
+		{
+			name: "Scale Change Downscales Previous",
+			value: ExponentialHistogramPoint{
+				Count:     25,
+				Sum:       80,
+				ZeroCount: 3,
+				Scale:     0,
+				Positive:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{4, 12, 1}},
+				Negative:  ExponentialBuckets{Offset: -1, BucketCounts: []uint64{5}},
+			},
+			wantOut: ExponentialHistogramPoint{
+				Count:     5,
+				Sum:       15,
+				ZeroCount: 0,
+				Scale:     0,
+				Positive:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{2, 3, 0}},
+				Negative:  ExponentialBuckets{Offset: -1, BucketCounts: []uint64{0}},
+			},
+		},
+		{
+			name: "Lower Count Restarts",
+			value: ExponentialHistogramPoint{
+				Count:    5,
+				Sum:      10,
+				Scale:    0,
+				Positive: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{5}},
+			},
+			wantOut: ExponentialHistogramPoint{
+				Count:    5,
+				Sum:      10,
+				Scale:    0,
+				Positive: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{5}},
+				Negative: ExponentialBuckets{BucketCounts: []uint64{}},
+			},
+		},
+		{
+			name: "Lower Bucket Restarts",
+			value: ExponentialHistogramPoint{
+				Count:    6,
+				Sum:      12,
+				Scale:    0,
+				Positive: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 5}},
+			},
+			wantOut: ExponentialHistogramPoint{
+				Count:    6,
+				Sum:      12,
+				Scale:    0,
+				Positive: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 5}},
+				Negative: ExponentialBuckets{BucketCounts: []uint64{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := tt.value
+			gotOut, valid := m.Convert(MetricPoint{
+				Identity: mi,
+				Value: ValuePoint{
+					ObservedTimestamp:         10,
+					ExponentialHistogramValue: &value,
+				},
+			})
+			if tt.noOut {
+				return
+			}
+			require.True(t, valid)
+			assert.Equal(t, tt.wantOut, *gotOut.ExponentialHistogramValue)
+		})
+	}
+}
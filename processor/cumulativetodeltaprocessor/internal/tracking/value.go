@@ -14,13 +14,18 @@
 
 package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
 
-import "go.opentelemetry.io/collector/pdata/pcommon"
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metrics/exphistogram"
+)
 
 type ValuePoint struct {
-	ObservedTimestamp pcommon.Timestamp
-	FloatValue        float64
-	IntValue          int64
-	HistogramValue    *HistogramPoint
+	ObservedTimestamp         pcommon.Timestamp
+	FloatValue                float64
+	IntValue                  int64
+	HistogramValue            *HistogramPoint
+	ExponentialHistogramValue *ExponentialHistogramPoint
 }
 
 type HistogramPoint struct {
@@ -39,3 +44,111 @@ func (point *HistogramPoint) Clone() HistogramPoint {
 		Buckets: bucketValues,
 	}
 }
+
+type ExponentialHistogramPoint struct {
+	Count     uint64
+	Sum       float64
+	Scale     int32
+	ZeroCount uint64
+	Positive  ExponentialBuckets
+	Negative  ExponentialBuckets
+}
+
+type ExponentialBuckets struct {
+	Offset       int32
+	BucketCounts []uint64
+}
+
+func (point *ExponentialHistogramPoint) Clone() ExponentialHistogramPoint {
+	return ExponentialHistogramPoint{
+		Count:     point.Count,
+		Sum:       point.Sum,
+		Scale:     point.Scale,
+		ZeroCount: point.ZeroCount,
+		Positive:  point.Positive.Clone(),
+		Negative:  point.Negative.Clone(),
+	}
+}
+
+func (buckets ExponentialBuckets) Clone() ExponentialBuckets {
+	bucketCounts := make([]uint64, len(buckets.BucketCounts))
+	copy(bucketCounts, buckets.BucketCounts)
+
+	return ExponentialBuckets{
+		Offset:       buckets.Offset,
+		BucketCounts: bucketCounts,
+	}
+}
+
+// downscale merges the buckets so they match a scale lower by scaleDelta.
+func (buckets ExponentialBuckets) downscale(scaleDelta int32) ExponentialBuckets {
+	offset, bucketCounts := exphistogram.Downscale(buckets.Offset, buckets.BucketCounts, scaleDelta)
+	return ExponentialBuckets{
+		Offset:       offset,
+		BucketCounts: bucketCounts,
+	}
+}
+
+// subtract returns the difference between the buckets and prev, aligned on their offsets.
+// The second return value is false if any bucket of prev holds more than the matching bucket.
+func (buckets ExponentialBuckets) subtract(prev ExponentialBuckets) (ExponentialBuckets, bool) {
+	if len(prev.BucketCounts) == 0 {
+		return buckets.Clone(), true
+	}
+	if len(buckets.BucketCounts) == 0 {
+		buckets.Offset = prev.Offset
+	}
+	offset := buckets.Offset
+	if prev.Offset < offset {
+		offset = prev.Offset
+	}
+	end := buckets.Offset + int32(len(buckets.BucketCounts))
+	if prevEnd := prev.Offset + int32(len(prev.BucketCounts)); prevEnd > end {
+		end = prevEnd
+	}
+	bucketCounts := make([]uint64, end-offset)
+	for i, count := range buckets.BucketCounts {
+		bucketCounts[buckets.Offset+int32(i)-offset] = count
+	}
+	for i, count := range prev.BucketCounts {
+		index := prev.Offset + int32(i) - offset
+		if bucketCounts[index] < count {
+			return buckets, false
+		}
+		bucketCounts[index] -= count
+	}
+	return ExponentialBuckets{
+		Offset:       offset,
+		BucketCounts: bucketCounts,
+	}, true
+}
+
+// subtract turns the point into the delta since prev. Both points are first brought to
+// the lower of the two scales, so the delta is reported at that scale. It returns true
+// if prev holds more observations than the point, meaning the histogram was reset.
+func (point *ExponentialHistogramPoint) subtract(prev *ExponentialHistogramPoint) (reset bool) {
+	if point.Count < prev.Count || point.ZeroCount < prev.ZeroCount {
+		return true
+	}
+
+	scale := point.Scale
+	if prev.Scale < scale {
+		scale = prev.Scale
+	}
+	positive, ok := point.Positive.downscale(point.Scale - scale).subtract(prev.Positive.downscale(prev.Scale - scale))
+	if !ok {
+		return true
+	}
+	negative, ok := point.Negative.downscale(point.Scale - scale).subtract(prev.Negative.downscale(prev.Scale - scale))
+	if !ok {
+		return true
+	}
+
+	point.Count -= prev.Count
+	point.Sum -= prev.Sum
+	point.ZeroCount -= prev.ZeroCount
+	point.Scale = scale
+	point.Positive = positive
+	point.Negative = negative
+	return false
+}
@@ -102,6 +102,30 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 
 					ctdp.convertHistogramDataPoints(ms.DataPoints(), baseIdentity)
 
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+						return false
+					}
+
+					if ms.DataPoints().Len() == 0 {
+						return false
+					}
+
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricType:             m.Type(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+						MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+					}
+
+					ctdp.convertExponentialHistogramDataPoints(ms.DataPoints(), baseIdentity)
+
 					ms.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
 					return ms.DataPoints().Len() == 0
 				default:
@@ -211,3 +235,61 @@ func (ctdp *cumulativeToDeltaProcessor) convertHistogramDataPoints(in interface{
 		})
 	}
 }
+
+func (ctdp *cumulativeToDeltaProcessor) convertExponentialHistogramDataPoints(in interface{}, baseIdentity tracking.MetricIdentity) {
+	if dps, ok := in.(pmetric.ExponentialHistogramDataPointSlice); ok {
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			id := baseIdentity
+			id.StartTimestamp = dp.StartTimestamp()
+			id.Attributes = dp.Attributes()
+
+			if dp.Flags().NoRecordedValue() {
+				// drop points with no value
+				return true
+			}
+
+			point := tracking.ValuePoint{
+				ObservedTimestamp: dp.Timestamp(),
+				ExponentialHistogramValue: &tracking.ExponentialHistogramPoint{
+					Count:     dp.Count(),
+					Sum:       dp.Sum(),
+					Scale:     dp.Scale(),
+					ZeroCount: dp.ZeroCount(),
+					Positive: tracking.ExponentialBuckets{
+						Offset:       dp.Positive().Offset(),
+						BucketCounts: dp.Positive().BucketCounts().AsRaw(),
+					},
+					Negative: tracking.ExponentialBuckets{
+						Offset:       dp.Negative().Offset(),
+						BucketCounts: dp.Negative().BucketCounts().AsRaw(),
+					},
+				},
+			}
+
+			trackingPoint := tracking.MetricPoint{
+				Identity: id,
+				Value:    point,
+			}
+			delta, valid := ctdp.deltaCalculator.Convert(trackingPoint)
+			if !valid {
+				return true
+			}
+
+			value := delta.ExponentialHistogramValue
+			dp.SetStartTimestamp(delta.StartTimestamp)
+			dp.SetCount(value.Count)
+			if dp.HasSum() && !math.IsNaN(dp.Sum()) {
+				dp.SetSum(value.Sum)
+			}
+			dp.SetScale(value.Scale)
+			dp.SetZeroCount(value.ZeroCount)
+			dp.Positive().SetOffset(value.Positive.Offset)
+			dp.Positive().BucketCounts().FromRaw(value.Positive.BucketCounts)
+			dp.Negative().SetOffset(value.Negative.Offset)
+			dp.Negative().BucketCounts().FromRaw(value.Negative.BucketCounts)
+			dp.RemoveMin()
+			dp.RemoveMax()
+			return false
+		})
+	}
+}
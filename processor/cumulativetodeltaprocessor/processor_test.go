@@ -57,6 +57,17 @@ type testHistogramMetric struct {
 	flags         [][]pmetric.DataPointFlags
 }
 
+type testExponentialHistogramMetric struct {
+	metricNames   []string
+	metricCounts  [][]uint64
+	metricSums    [][]float64
+	metricScales  [][]int32
+	metricOffsets [][]int32
+	metricBuckets [][][]uint64
+	isCumulative  []bool
+	flags         [][]pmetric.DataPointFlags
+}
+
 type cumulativeToDeltaTest struct {
 	name       string
 	include    MatchMetrics
@@ -291,6 +302,93 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 				isCumulative: []bool{false, true},
 			}),
 		},
+		{
+			name: "cumulative_to_delta_exponential_histogram_one_positive",
+			include: MatchMetrics{
+				Metrics: []string{"metric_1"},
+				Config: filterset.Config{
+					MatchType:    "strict",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1", "metric_2"},
+				metricCounts:  [][]uint64{{0, 10, 20, 30}, {4}},
+				metricSums:    [][]float64{{0, 10, 20, 30}, {4}},
+				metricScales:  [][]int32{{0, 0, 0, -1}, {0}},
+				metricOffsets: [][]int32{{0, 0, -1, -1}, {0}},
+				metricBuckets: [][][]uint64{
+					{{0, 0}, {5, 5}, {2, 8, 10}, {4, 26}},
+					{{2, 2}},
+				},
+				isCumulative: []bool{true, true},
+			}),
+			outMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1", "metric_2"},
+				metricCounts:  [][]uint64{{10, 10, 10}, {4}},
+				metricSums:    [][]float64{{10, 10, 10}, {4}},
+				metricScales:  [][]int32{{0, 0, -1}, {0}},
+				metricOffsets: [][]int32{{0, -1, -1}, {0}},
+				metricBuckets: [][][]uint64{
+					{{5, 5}, {2, 3, 5}, {2, 8}},
+					{{2, 2}},
+				},
+				isCumulative: []bool{false, true},
+			}),
+		},
+		{
+			name: "cumulative_to_delta_exponential_histogram_reset",
+			inMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1"},
+				metricCounts:  [][]uint64{{10, 4, 6}},
+				metricSums:    [][]float64{{10, 4, 6}},
+				metricScales:  [][]int32{{0, 0, 0}},
+				metricOffsets: [][]int32{{0, 0, 0}},
+				metricBuckets: [][][]uint64{
+					{{5, 5}, {2, 2}, {3, 3}},
+				},
+				isCumulative: []bool{true},
+			}),
+			outMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1"},
+				metricCounts:  [][]uint64{{4, 2}},
+				metricSums:    [][]float64{{4, 2}},
+				metricScales:  [][]int32{{0, 0}},
+				metricOffsets: [][]int32{{0, 0}},
+				metricBuckets: [][][]uint64{
+					{{2, 2}, {1, 1}},
+				},
+				isCumulative: []bool{false},
+			}),
+		},
+		{
+			name: "cumulative_to_delta_exponential_histogram_novalue",
+			inMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1"},
+				metricCounts:  [][]uint64{{0, 10, 0, 30}},
+				metricSums:    [][]float64{{0, 10, 0, 30}},
+				metricScales:  [][]int32{{0, 0, 0, 0}},
+				metricOffsets: [][]int32{{0, 0, 0, 0}},
+				metricBuckets: [][][]uint64{
+					{{0, 0}, {5, 5}, {0, 0}, {15, 15}},
+				},
+				isCumulative: []bool{true},
+				flags: [][]pmetric.DataPointFlags{
+					{zeroFlag, zeroFlag, noValueFlag, zeroFlag},
+				},
+			}),
+			outMetrics: generateTestExponentialHistogramMetrics(testExponentialHistogramMetric{
+				metricNames:   []string{"metric_1"},
+				metricCounts:  [][]uint64{{10, 20}},
+				metricSums:    [][]float64{{10, 20}},
+				metricScales:  [][]int32{{0, 0}},
+				metricOffsets: [][]int32{{0, 0}},
+				metricBuckets: [][][]uint64{
+					{{5, 5}, {10, 10}},
+				},
+				isCumulative: []bool{false},
+			}),
+		},
 		{
 			name: "cumulative_to_delta_histogram_novalue",
 			inMetrics: generateTestHistogramMetrics(testHistogramMetric{
@@ -538,6 +636,24 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 						require.Equal(t, eDataPoints.At(j).Flags(), aDataPoints.At(j).Flags())
 					}
 				}
+
+				if eM.Type() == pmetric.MetricTypeExponentialHistogram {
+					eDataPoints := eM.ExponentialHistogram().DataPoints()
+					aDataPoints := aM.ExponentialHistogram().DataPoints()
+
+					require.Equal(t, eDataPoints.Len(), aDataPoints.Len())
+					require.Equal(t, eM.ExponentialHistogram().AggregationTemporality(), aM.ExponentialHistogram().AggregationTemporality())
+
+					for j := 0; j < eDataPoints.Len(); j++ {
+						require.Equal(t, eDataPoints.At(j).Count(), aDataPoints.At(j).Count())
+						require.Equal(t, eDataPoints.At(j).Sum(), aDataPoints.At(j).Sum())
+						require.Equal(t, eDataPoints.At(j).Scale(), aDataPoints.At(j).Scale())
+						require.Equal(t, eDataPoints.At(j).ZeroCount(), aDataPoints.At(j).ZeroCount())
+						require.Equal(t, eDataPoints.At(j).Positive().Offset(), aDataPoints.At(j).Positive().Offset())
+						require.Equal(t, eDataPoints.At(j).Positive().BucketCounts(), aDataPoints.At(j).Positive().BucketCounts())
+						require.Equal(t, eDataPoints.At(j).Flags(), aDataPoints.At(j).Flags())
+					}
+				}
 			}
 
 			require.NoError(t, mgp.Shutdown(ctx))
@@ -624,6 +740,40 @@ func generateTestHistogramMetrics(tm testHistogramMetric) pmetric.Metrics {
 	return md
 }
 
+func generateTestExponentialHistogramMetrics(tm testExponentialHistogramMetric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	now := time.Now()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for i, name := range tm.metricNames {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		hist := m.SetEmptyExponentialHistogram()
+
+		if tm.isCumulative[i] {
+			hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		} else {
+			hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		}
+
+		for index, count := range tm.metricCounts[i] {
+			dp := hist.DataPoints().AppendEmpty()
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(10 * time.Second)))
+			dp.SetCount(count)
+			dp.SetSum(tm.metricSums[i][index])
+			dp.SetScale(tm.metricScales[i][index])
+			dp.Positive().SetOffset(tm.metricOffsets[i][index])
+			dp.Positive().BucketCounts().FromRaw(tm.metricBuckets[i][index])
+			if len(tm.flags) > i && len(tm.flags[i]) > index {
+				dp.SetFlags(tm.flags[i][index])
+			}
+		}
+	}
+
+	return md
+}
+
 func BenchmarkConsumeMetrics(b *testing.B) {
 	c := consumertest.NewNop()
 	params := processor.CreateSettings{
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	// MaxStaleness is the time a stream is kept after its last point was received. Set to 0 to retain streams indefinitely.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+
+	// MaxStreams is the maximum number of streams tracked at the same time. Points of new streams
+	// are dropped once it is reached. Set to 0 to track an unlimited number of streams.
+	MaxStreams int `mapstructure:"max_streams"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (config *Config) Validate() error {
+	if config.MaxStaleness < 0 {
+		return errors.New("max_staleness must not be negative")
+	}
+	if config.MaxStreams < 0 {
+		return errors.New("max_streams must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,355 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/tracking"
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metrics/exphistogram"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metrics/identity"
+)
+
+// Allocate a minimum of 64 bytes to the builder initially
+const initialBytes = 64
+
+var identityBufferPool = sync.Pool{
+	New: func() interface{} {
+		return bytes.NewBuffer(make([]byte, initialBytes))
+	},
+}
+
+type action int
+
+const (
+	// actionDrop drops the point.
+	actionDrop action = iota
+	// actionReset starts a new cumulative series from the point.
+	actionReset
+	// actionAccumulate adds the point to the cumulative series.
+	actionAccumulate
+)
+
+// stream holds the accumulated value of a stream. Only the data point matching
+// the metric type of the stream is used.
+type stream struct {
+	start    pcommon.Timestamp
+	last     pcommon.Timestamp
+	lastSeen time.Time
+
+	number       pmetric.NumberDataPoint
+	histogram    pmetric.HistogramDataPoint
+	expHistogram pmetric.ExponentialHistogramDataPoint
+}
+
+// MetricTracker accumulates delta points into cumulative points, per stream.
+type MetricTracker struct {
+	logger       *zap.Logger
+	maxStreams   int
+	maxStaleness time.Duration
+	now          func() time.Time
+
+	mu      sync.Mutex
+	streams map[string]*stream
+}
+
+// NewMetricTracker returns a tracker holding at most maxStreams streams, or an unlimited number if
+// maxStreams is 0. Streams that did not receive any point during maxStaleness are removed, unless
+// maxStaleness is 0.
+func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStreams int, maxStaleness time.Duration) *MetricTracker {
+	t := &MetricTracker{
+		logger:       logger,
+		maxStreams:   maxStreams,
+		maxStaleness: maxStaleness,
+		now:          time.Now,
+		streams:      map[string]*stream{},
+	}
+	if maxStaleness > 0 {
+		go t.sweeper(ctx)
+	}
+	return t
+}
+
+func key(id identity.Stream) string {
+	b := identityBufferPool.Get().(*bytes.Buffer)
+	b.Reset()
+	id.Write(b)
+	k := b.String()
+	identityBufferPool.Put(b)
+	return k
+}
+
+// begin looks up the stream of a point and decides how the point is handled.
+// Points at or before the last accumulated point, or starting before it, are out of order and dropped.
+// Points starting after the last accumulated point leave a gap of unknown values: a new series is started.
+func (t *MetricTracker) begin(k string, start, ts pcommon.Timestamp) (*stream, action) {
+	s, ok := t.streams[k]
+	if !ok {
+		if t.maxStreams > 0 && len(t.streams) >= t.maxStreams {
+			t.logger.Debug("dropping point of new stream, the maximum number of streams is reached", zap.Int("max_streams", t.maxStreams))
+			return nil, actionDrop
+		}
+		s = &stream{}
+		t.streams[k] = s
+		return s, actionReset
+	}
+
+	switch {
+	case ts <= s.last, start != 0 && start < s.last:
+		t.logger.Debug("dropping out of order point", zap.Stringer("timestamp", ts), zap.Stringer("last", s.last))
+		return s, actionDrop
+	case start > s.last:
+		t.logger.Debug("gap between points, starting a new series", zap.Stringer("start", start), zap.Stringer("last", s.last))
+		return s, actionReset
+	}
+	return s, actionAccumulate
+}
+
+// end records the point as the last one of the stream.
+func (t *MetricTracker) end(s *stream, act action, start, ts pcommon.Timestamp) {
+	if act == actionReset {
+		s.start = start
+		if s.start == 0 {
+			s.start = ts
+		}
+	}
+	s.last = ts
+	s.lastSeen = t.now()
+}
+
+// forget removes the stream of a point flagged with no recorded value, which marks the end of the stream.
+func (t *MetricTracker) forget(k string, dp interface{ SetStartTimestamp(pcommon.Timestamp) }) {
+	if s, ok := t.streams[k]; ok {
+		dp.SetStartTimestamp(s.start)
+		delete(t.streams, k)
+	}
+}
+
+// ConvertSum converts a delta sum point in place to its cumulative value.
+// It returns false when the point must be dropped.
+func (t *MetricTracker) ConvertSum(id identity.Stream, dp pmetric.NumberDataPoint) bool {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeDouble && math.IsNaN(dp.DoubleValue()) {
+		return false
+	}
+
+	k := key(id)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if dp.Flags().NoRecordedValue() {
+		t.forget(k, dp)
+		return true
+	}
+
+	s, act := t.begin(k, dp.StartTimestamp(), dp.Timestamp())
+	switch act {
+	case actionDrop:
+		return false
+	case actionReset:
+		s.number = pmetric.NewNumberDataPoint()
+		dp.CopyTo(s.number)
+	case actionAccumulate:
+		if dp.ValueType() == pmetric.NumberDataPointValueTypeDouble {
+			s.number.SetDoubleValue(s.number.DoubleValue() + dp.DoubleValue())
+			dp.SetDoubleValue(s.number.DoubleValue())
+		} else {
+			s.number.SetIntValue(s.number.IntValue() + dp.IntValue())
+			dp.SetIntValue(s.number.IntValue())
+		}
+	}
+	t.end(s, act, dp.StartTimestamp(), dp.Timestamp())
+	dp.SetStartTimestamp(s.start)
+	return true
+}
+
+// ConvertHistogram converts a delta histogram point in place to its cumulative value.
+// A change of the bucket boundaries starts a new series.
+// It returns false when the point must be dropped.
+func (t *MetricTracker) ConvertHistogram(id identity.Stream, dp pmetric.HistogramDataPoint) bool {
+	k := key(id)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if dp.Flags().NoRecordedValue() {
+		t.forget(k, dp)
+		return true
+	}
+
+	s, act := t.begin(k, dp.StartTimestamp(), dp.Timestamp())
+	if act == actionAccumulate && !equalBounds(s.histogram.ExplicitBounds(), dp.ExplicitBounds()) {
+		act = actionReset
+	}
+	switch act {
+	case actionDrop:
+		return false
+	case actionReset:
+		s.histogram = pmetric.NewHistogramDataPoint()
+		dp.CopyTo(s.histogram)
+	case actionAccumulate:
+		acc := s.histogram
+		acc.SetCount(acc.Count() + dp.Count())
+		if acc.HasSum() && dp.HasSum() {
+			acc.SetSum(acc.Sum() + dp.Sum())
+		} else {
+			acc.RemoveSum()
+		}
+		if acc.HasMin() && dp.HasMin() {
+			acc.SetMin(math.Min(acc.Min(), dp.Min()))
+		} else {
+			acc.RemoveMin()
+		}
+		if acc.HasMax() && dp.HasMax() {
+			acc.SetMax(math.Max(acc.Max(), dp.Max()))
+		} else {
+			acc.RemoveMax()
+		}
+		for i := 0; i < acc.BucketCounts().Len() && i < dp.BucketCounts().Len(); i++ {
+			acc.BucketCounts().SetAt(i, acc.BucketCounts().At(i)+dp.BucketCounts().At(i))
+		}
+
+		dp.SetCount(acc.Count())
+		if acc.HasSum() {
+			dp.SetSum(acc.Sum())
+		} else {
+			dp.RemoveSum()
+		}
+		if acc.HasMin() {
+			dp.SetMin(acc.Min())
+		} else {
+			dp.RemoveMin()
+		}
+		if acc.HasMax() {
+			dp.SetMax(acc.Max())
+		} else {
+			dp.RemoveMax()
+		}
+		acc.BucketCounts().CopyTo(dp.BucketCounts())
+	}
+	t.end(s, act, dp.StartTimestamp(), dp.Timestamp())
+	dp.SetStartTimestamp(s.start)
+	return true
+}
+
+// ConvertExponentialHistogram converts a delta exponential histogram point in place to its cumulative value.
+// Points of different scales are merged at the smallest scale.
+// It returns false when the point must be dropped.
+func (t *MetricTracker) ConvertExponentialHistogram(id identity.Stream, dp pmetric.ExponentialHistogramDataPoint) bool {
+	k := key(id)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if dp.Flags().NoRecordedValue() {
+		t.forget(k, dp)
+		return true
+	}
+
+	s, act := t.begin(k, dp.StartTimestamp(), dp.Timestamp())
+	switch act {
+	case actionDrop:
+		return false
+	case actionReset:
+		s.expHistogram = pmetric.NewExponentialHistogramDataPoint()
+		dp.CopyTo(s.expHistogram)
+	case actionAccumulate:
+		acc := s.expHistogram
+		if dp.Scale() > acc.Scale() {
+			exphistogram.DownscaleBuckets(dp.Positive(), dp.Scale()-acc.Scale())
+			exphistogram.DownscaleBuckets(dp.Negative(), dp.Scale()-acc.Scale())
+			dp.SetScale(acc.Scale())
+		} else if acc.Scale() > dp.Scale() {
+			exphistogram.DownscaleBuckets(acc.Positive(), acc.Scale()-dp.Scale())
+			exphistogram.DownscaleBuckets(acc.Negative(), acc.Scale()-dp.Scale())
+			acc.SetScale(dp.Scale())
+		}
+		acc.SetCount(acc.Count() + dp.Count())
+		acc.SetZeroCount(acc.ZeroCount() + dp.ZeroCount())
+		acc.SetSum(acc.Sum() + dp.Sum())
+		if acc.HasMin() && dp.HasMin() {
+			acc.SetMin(math.Min(acc.Min(), dp.Min()))
+		} else {
+			acc.RemoveMin()
+		}
+		if acc.HasMax() && dp.HasMax() {
+			acc.SetMax(math.Max(acc.Max(), dp.Max()))
+		} else {
+			acc.RemoveMax()
+		}
+		exphistogram.MergeBuckets(acc.Positive(), dp.Positive())
+		exphistogram.MergeBuckets(acc.Negative(), dp.Negative())
+
+		dp.SetCount(acc.Count())
+		dp.SetZeroCount(acc.ZeroCount())
+		dp.SetSum(acc.Sum())
+		if acc.HasMin() {
+			dp.SetMin(acc.Min())
+		} else {
+			dp.RemoveMin()
+		}
+		if acc.HasMax() {
+			dp.SetMax(acc.Max())
+		} else {
+			dp.RemoveMax()
+		}
+		acc.Positive().CopyTo(dp.Positive())
+		acc.Negative().CopyTo(dp.Negative())
+	}
+	t.end(s, act, dp.StartTimestamp(), dp.Timestamp())
+	dp.SetStartTimestamp(s.start)
+	return true
+}
+
+func equalBounds(a, b pcommon.Float64Slice) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		if a.At(i) != b.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *MetricTracker) removeStale(staleBefore time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for k, s := range t.streams {
+		if s.lastSeen.Before(staleBefore) {
+			t.logger.Debug("removing stale stream", zap.String("key", k))
+			delete(t.streams, k)
+		}
+	}
+}
+
+func (t *MetricTracker) sweeper(ctx context.Context) {
+	ticker := time.NewTicker(t.maxStaleness)
+	for {
+		select {
+		case currentTime := <-ticker.C:
+			t.removeStale(currentTime.Add(-t.maxStaleness))
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
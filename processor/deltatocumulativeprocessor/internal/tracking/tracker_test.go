@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metrics/identity"
+)
+
+func sumIdentity(name string) identity.Stream {
+	return identity.Stream{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSum,
+		MetricIsMonotonic:      true,
+		AggregationTemporality: pmetric.AggregationTemporalityDelta,
+		MetricName:             name,
+		Attributes:             pcommon.NewMap(),
+		MetricValueType:        pmetric.NumberDataPointValueTypeDouble,
+	}
+}
+
+func doublePoint(start, ts pcommon.Timestamp, value float64) pmetric.NumberDataPoint {
+	dp := pmetric.NewNumberDataPoint()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(value)
+	return dp
+}
+
+func TestMetricTracker_ConvertSum(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	id := sumIdentity("requests")
+
+	dp := doublePoint(10, 20, 1.5)
+	require.True(t, m.ConvertSum(id, dp))
+	assert.Equal(t, 1.5, dp.DoubleValue())
+
+	dp = doublePoint(20, 30, 2)
+	require.True(t, m.ConvertSum(id, dp))
+	assert.Equal(t, 3.5, dp.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+
+	// NaN values are dropped without changing the accumulated value.
+	assert.False(t, m.ConvertSum(id, doublePoint(30, 40, math.NaN())))
+	dp = doublePoint(30, 40, 1)
+	require.True(t, m.ConvertSum(id, dp))
+	assert.Equal(t, 4.5, dp.DoubleValue())
+
+	// A point with no recorded value ends the stream.
+	dp = doublePoint(40, 50, 0)
+	dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	require.True(t, m.ConvertSum(id, dp))
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+	assert.Empty(t, m.streams)
+
+	dp = doublePoint(50, 60, 1)
+	require.True(t, m.ConvertSum(id, dp))
+	assert.Equal(t, 1.0, dp.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(50), dp.StartTimestamp())
+}
+
+func TestMetricTracker_RemoveStale(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	require.True(t, m.ConvertSum(sumIdentity("old"), doublePoint(10, 20, 1)))
+	m.now = func() time.Time { return now.Add(time.Minute) }
+	require.True(t, m.ConvertSum(sumIdentity("new"), doublePoint(10, 20, 1)))
+
+	m.removeStale(now.Add(30 * time.Second))
+	require.Len(t, m.streams, 1)
+
+	// The removed stream starts a new series.
+	dp := doublePoint(20, 30, 2)
+	require.True(t, m.ConvertSum(sumIdentity("old"), dp))
+	assert.Equal(t, 2.0, dp.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(20), dp.StartTimestamp())
+}
+
+func TestMetricTracker_Sweeper(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewMetricTracker(ctx, zap.NewNop(), 0, 10*time.Millisecond)
+	require.True(t, m.ConvertSum(sumIdentity("requests"), doublePoint(10, 20, 1)))
+
+	assert.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return len(m.streams) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
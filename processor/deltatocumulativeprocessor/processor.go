@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/tracking"
+)
+
+type deltaToCumulativeProcessor struct {
+	logger     *zap.Logger
+	tracker    *tracking.MetricTracker
+	cancelFunc context.CancelFunc
+}
+
+func newDeltaToCumulativeProcessor(config *Config, logger *zap.Logger) *deltaToCumulativeProcessor {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &deltaToCumulativeProcessor{
+		logger:     logger,
+		tracker:    tracking.NewMetricTracker(ctx, logger, config.MaxStreams, config.MaxStaleness),
+		cancelFunc: cancel,
+	}
+}
+
+// processMetrics implements the ProcessMetricsFunc type.
+func (dtcp *deltaToCumulativeProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(ilm pmetric.ScopeMetrics) bool {
+			ilm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				baseIdentity := identity.Stream{
+					Resource:               rm.Resource(),
+					InstrumentationLibrary: ilm.Scope(),
+					MetricType:             m.Type(),
+					AggregationTemporality: pmetric.AggregationTemporalityDelta,
+					MetricName:             m.Name(),
+					MetricUnit:             m.Unit(),
+				}
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					ms := m.Sum()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+					baseIdentity.MetricIsMonotonic = ms.IsMonotonic()
+					ms.DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+						id := baseIdentity
+						id.Attributes = dp.Attributes()
+						id.MetricValueType = dp.ValueType()
+						return !dtcp.tracker.ConvertSum(id, dp)
+					})
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeHistogram:
+					ms := m.Histogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+					baseIdentity.MetricIsMonotonic = true
+					ms.DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+						id := baseIdentity
+						id.Attributes = dp.Attributes()
+						return !dtcp.tracker.ConvertHistogram(id, dp)
+					})
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+					baseIdentity.MetricIsMonotonic = true
+					ms.DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+						id := baseIdentity
+						id.Attributes = dp.Attributes()
+						return !dtcp.tracker.ConvertExponentialHistogram(id, dp)
+					})
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				default:
+					return false
+				}
+			})
+			return ilm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return md, nil
+}
+
+func (dtcp *deltaToCumulativeProcessor) shutdown(context.Context) error {
+	dtcp.cancelFunc()
+	return nil
+}
@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+type sumPoint struct {
+	start, ts pcommon.Timestamp
+	value     int64
+	attr      string
+}
+
+func sumMetrics(temporality pmetric.AggregationTemporality, points ...sumPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(temporality)
+	for _, p := range points {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(p.start)
+		dp.SetTimestamp(p.ts)
+		dp.SetIntValue(p.value)
+		if p.attr != "" {
+			dp.Attributes().PutStr("path", p.attr)
+		}
+	}
+	return md
+}
+
+func newTestProcessor(t *testing.T, cfg *Config) (func(pmetric.Metrics) pmetric.NumberDataPointSlice, *consumertest.MetricsSink) {
+	sink := new(consumertest.MetricsSink)
+	mp, err := createMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), nil))
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+	consume := func(md pmetric.Metrics) pmetric.NumberDataPointSlice {
+		require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+		all := sink.AllMetrics()
+		last := all[len(all)-1]
+		if last.ResourceMetrics().Len() == 0 {
+			return pmetric.NewNumberDataPointSlice()
+		}
+		m := last.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+		assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+		return m.Sum().DataPoints()
+	}
+	return consume, sink
+}
+
+func TestProcessorSums(t *testing.T) {
+	consume, _ := newTestProcessor(t, createDefaultConfig().(*Config))
+
+	steps := []struct {
+		name     string
+		in       []sumPoint
+		expected []sumPoint
+	}{
+		{
+			name:     "first points start the series",
+			in:       []sumPoint{{start: 10, ts: 20, value: 3, attr: "/a"}, {start: 10, ts: 20, value: 5, attr: "/b"}},
+			expected: []sumPoint{{start: 10, ts: 20, value: 3, attr: "/a"}, {start: 10, ts: 20, value: 5, attr: "/b"}},
+		},
+		{
+			name:     "consecutive points are accumulated",
+			in:       []sumPoint{{start: 20, ts: 30, value: 4, attr: "/a"}},
+			expected: []sumPoint{{start: 10, ts: 30, value: 7, attr: "/a"}},
+		},
+		{
+			name:     "points without start timestamp are accumulated",
+			in:       []sumPoint{{ts: 40, value: 1, attr: "/a"}},
+			expected: []sumPoint{{start: 10, ts: 40, value: 8, attr: "/a"}},
+		},
+		{
+			name:     "out of order and overlapping points are dropped",
+			in:       []sumPoint{{start: 20, ts: 30, value: 4, attr: "/a"}, {start: 35, ts: 45, value: 4, attr: "/a"}},
+			expected: []sumPoint{},
+		},
+		{
+			name:     "a gap starts a new series",
+			in:       []sumPoint{{start: 50, ts: 60, value: 2, attr: "/a"}, {start: 20, ts: 60, value: 1, attr: "/b"}},
+			expected: []sumPoint{{start: 50, ts: 60, value: 2, attr: "/a"}, {start: 10, ts: 60, value: 6, attr: "/b"}},
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			dps := consume(sumMetrics(pmetric.AggregationTemporalityDelta, step.in...))
+			require.Equal(t, len(step.expected), dps.Len())
+			for i, expected := range step.expected {
+				dp := dps.At(i)
+				assert.Equal(t, expected.start, dp.StartTimestamp())
+				assert.Equal(t, expected.ts, dp.Timestamp())
+				assert.Equal(t, expected.value, dp.IntValue())
+				path, _ := dp.Attributes().Get("path")
+				assert.Equal(t, expected.attr, path.Str())
+			}
+		})
+	}
+}
+
+func TestProcessorCumulativeUnchanged(t *testing.T) {
+	consume, _ := newTestProcessor(t, createDefaultConfig().(*Config))
+
+	dps := consume(sumMetrics(pmetric.AggregationTemporalityCumulative, sumPoint{start: 10, ts: 20, value: 3}))
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, int64(3), dps.At(0).IntValue())
+	dps = consume(sumMetrics(pmetric.AggregationTemporalityCumulative, sumPoint{start: 10, ts: 30, value: 5}))
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, int64(5), dps.At(0).IntValue())
+}
+
+func TestProcessorMaxStreams(t *testing.T) {
+	consume, _ := newTestProcessor(t, &Config{MaxStreams: 1, MaxStaleness: time.Minute})
+
+	dps := consume(sumMetrics(pmetric.AggregationTemporalityDelta,
+		sumPoint{start: 10, ts: 20, value: 3, attr: "/a"},
+		sumPoint{start: 10, ts: 20, value: 5, attr: "/b"},
+	))
+	require.Equal(t, 1, dps.Len())
+	path, _ := dps.At(0).Attributes().Get("path")
+	assert.Equal(t, "/a", path.Str())
+
+	// Existing streams are still accumulated.
+	dps = consume(sumMetrics(pmetric.AggregationTemporalityDelta, sumPoint{start: 20, ts: 30, value: 1, attr: "/a"}))
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, int64(4), dps.At(0).IntValue())
+}
+
+func TestProcessorHistograms(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	mp, err := createMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), createDefaultConfig(), sink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), nil))
+	defer func() { require.NoError(t, mp.Shutdown(context.Background())) }()
+
+	histogram := func(start, ts pcommon.Timestamp, bounds []float64, counts []uint64, sum, min, max float64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("latency")
+		h := m.SetEmptyHistogram()
+		h.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := h.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.ExplicitBounds().FromRaw(bounds)
+		dp.BucketCounts().FromRaw(counts)
+		var count uint64
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(sum)
+		dp.SetMin(min)
+		dp.SetMax(max)
+		return md
+	}
+	last := func() pmetric.Histogram {
+		all := sink.AllMetrics()
+		return all[len(all)-1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram()
+	}
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), histogram(10, 20, []float64{1, 10}, []uint64{1, 2, 0}, 12, 0.5, 8)))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), histogram(20, 30, []float64{1, 10}, []uint64{0, 1, 1}, 25, 2, 20)))
+	h := last()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, h.AggregationTemporality())
+	dp := h.DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, 37.0, dp.Sum())
+	assert.Equal(t, 0.5, dp.Min())
+	assert.Equal(t, 20.0, dp.Max())
+	assert.Equal(t, []uint64{1, 3, 1}, dp.BucketCounts().AsRaw())
+
+	// New bucket boundaries start a new series.
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), histogram(30, 40, []float64{5}, []uint64{1, 1}, 7, 1, 6)))
+	dp = last().DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(30), dp.StartTimestamp())
+	assert.Equal(t, uint64(2), dp.Count())
+	assert.Equal(t, []uint64{1, 1}, dp.BucketCounts().AsRaw())
+}
+
+func TestProcessorExponentialHistograms(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	mp, err := createMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), createDefaultConfig(), sink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), nil))
+	defer func() { require.NoError(t, mp.Shutdown(context.Background())) }()
+
+	expHistogram := func(start, ts pcommon.Timestamp, scale int32, offset int32, counts []uint64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("latency")
+		h := m.SetEmptyExponentialHistogram()
+		h.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := h.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.SetScale(scale)
+		dp.SetZeroCount(1)
+		dp.Positive().SetOffset(offset)
+		dp.Positive().BucketCounts().FromRaw(counts)
+		count := uint64(1)
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(10)
+		return md
+	}
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), expHistogram(10, 20, 1, 0, []uint64{1, 2})))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), expHistogram(20, 30, 2, 1, []uint64{1, 1, 1})))
+	all := sink.AllMetrics()
+	h := all[len(all)-1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, h.AggregationTemporality())
+	dp := h.DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(8), dp.Count())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	assert.Equal(t, 20.0, dp.Sum())
+	// Buckets 1, 2 and 3 at scale 2 map to buckets 0, 1 and 1 at scale 1.
+	assert.Equal(t, int32(0), dp.Positive().Offset())
+	assert.Equal(t, []uint64{2, 4}, dp.Positive().BucketCounts().AsRaw())
+}
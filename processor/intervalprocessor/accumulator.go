@@ -0,0 +1,477 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"bytes"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metrics/exphistogram"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metrics/identity"
+)
+
+// accumulator holds one data point per stream for the current interval. Streams are identified
+// by their identity.Stream, ignoring the value type so that int and double points are merged.
+type accumulator struct {
+	md pmetric.Metrics
+
+	resources map[string]pmetric.ResourceMetrics
+	scopes    map[string]pmetric.ScopeMetrics
+	metrics   map[string]pmetric.Metric
+
+	numbers               map[string]pmetric.NumberDataPoint
+	histograms            map[string]pmetric.HistogramDataPoint
+	exponentialHistograms map[string]pmetric.ExponentialHistogramDataPoint
+	summaries             map[string]pmetric.SummaryDataPoint
+
+	// closedHistograms holds, per stream, the delta histogram points closed during the interval
+	// because the bucket layout of the stream changed, in the order they were closed.
+	closedHistograms map[string][]closedHistogram
+}
+
+type closedHistogram struct {
+	point pmetric.HistogramDataPoint
+	end   pcommon.Timestamp
+}
+
+func newAccumulator() *accumulator {
+	return &accumulator{
+		md:                    pmetric.NewMetrics(),
+		resources:             map[string]pmetric.ResourceMetrics{},
+		scopes:                map[string]pmetric.ScopeMetrics{},
+		metrics:               map[string]pmetric.Metric{},
+		numbers:               map[string]pmetric.NumberDataPoint{},
+		histograms:            map[string]pmetric.HistogramDataPoint{},
+		exponentialHistograms: map[string]pmetric.ExponentialHistogramDataPoint{},
+		summaries:             map[string]pmetric.SummaryDataPoint{},
+		closedHistograms:      map[string][]closedHistogram{},
+	}
+}
+
+func metricIdentity(rm pmetric.ResourceMetrics, sm pmetric.ScopeMetrics, m pmetric.Metric) identity.Stream {
+	id := identity.Stream{
+		Resource:               rm.Resource(),
+		InstrumentationLibrary: sm.Scope(),
+		MetricType:             m.Type(),
+		MetricName:             m.Name(),
+		MetricUnit:             m.Unit(),
+	}
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		id.AggregationTemporality = m.Sum().AggregationTemporality()
+		id.MetricIsMonotonic = m.Sum().IsMonotonic()
+	case pmetric.MetricTypeHistogram:
+		id.AggregationTemporality = m.Histogram().AggregationTemporality()
+	case pmetric.MetricTypeExponentialHistogram:
+		id.AggregationTemporality = m.ExponentialHistogram().AggregationTemporality()
+	}
+	return id
+}
+
+func pointKey(id identity.Stream, attributes pcommon.Map) string {
+	id.Attributes = attributes
+	b := &bytes.Buffer{}
+	id.Write(b)
+	return b.String()
+}
+
+// metric returns the metric of the current interval the points of the stream are merged into,
+// creating it and its resource and scope if needed.
+func (a *accumulator) metric(id identity.Stream, rm pmetric.ResourceMetrics, sm pmetric.ScopeMetrics, m pmetric.Metric) pmetric.Metric {
+	b := &bytes.Buffer{}
+	id.WriteResource(b)
+	resourceKey := b.String()
+	res, ok := a.resources[resourceKey]
+	if !ok {
+		res = a.md.ResourceMetrics().AppendEmpty()
+		rm.Resource().CopyTo(res.Resource())
+		res.SetSchemaUrl(rm.SchemaUrl())
+		a.resources[resourceKey] = res
+	}
+
+	b.Reset()
+	id.WriteScope(b)
+	scopeKey := b.String()
+	scope, ok := a.scopes[scopeKey]
+	if !ok {
+		scope = res.ScopeMetrics().AppendEmpty()
+		sm.Scope().CopyTo(scope.Scope())
+		scope.SetSchemaUrl(sm.SchemaUrl())
+		a.scopes[scopeKey] = scope
+	}
+
+	b.Reset()
+	id.WriteMetric(b)
+	metricKey := b.String()
+	metric, ok := a.metrics[metricKey]
+	if !ok {
+		metric = scope.Metrics().AppendEmpty()
+		metric.SetName(m.Name())
+		metric.SetDescription(m.Description())
+		metric.SetUnit(m.Unit())
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			metric.SetEmptyGauge()
+		case pmetric.MetricTypeSum:
+			sum := metric.SetEmptySum()
+			sum.SetAggregationTemporality(id.AggregationTemporality)
+			sum.SetIsMonotonic(id.MetricIsMonotonic)
+		case pmetric.MetricTypeHistogram:
+			metric.SetEmptyHistogram().SetAggregationTemporality(id.AggregationTemporality)
+		case pmetric.MetricTypeExponentialHistogram:
+			metric.SetEmptyExponentialHistogram().SetAggregationTemporality(id.AggregationTemporality)
+		case pmetric.MetricTypeSummary:
+			metric.SetEmptySummary()
+		}
+		a.metrics[metricKey] = metric
+	}
+	return metric
+}
+
+// add merges the data points of md into the streams of the current interval.
+func (a *accumulator) add(md pmetric.Metrics) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				if m.Type() == pmetric.MetricTypeEmpty {
+					continue
+				}
+				id := metricIdentity(rm, sm, m)
+				a.addMetric(id, a.metric(id, rm, sm, m), m)
+			}
+		}
+	}
+}
+
+func (a *accumulator) addMetric(id identity.Stream, to, from pmetric.Metric) {
+	switch from.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := from.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.addNumber(id, to.Gauge().DataPoints(), dps.At(i), false)
+		}
+	case pmetric.MetricTypeSum:
+		delta := from.Sum().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+		dps := from.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.addNumber(id, to.Sum().DataPoints(), dps.At(i), delta)
+		}
+	case pmetric.MetricTypeHistogram:
+		delta := from.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+		dps := from.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.addHistogram(id, to.Histogram().DataPoints(), dps.At(i), delta)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		delta := from.ExponentialHistogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+		dps := from.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.addExponentialHistogram(id, to.ExponentialHistogram().DataPoints(), dps.At(i), delta)
+		}
+	case pmetric.MetricTypeSummary:
+		dps := from.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			key := pointKey(id, dps.At(i).Attributes())
+			existing, ok := a.summaries[key]
+			if !ok {
+				existing = to.Summary().DataPoints().AppendEmpty()
+				a.summaries[key] = existing
+			} else if dps.At(i).Timestamp() < existing.Timestamp() {
+				continue
+			}
+			dps.At(i).CopyTo(existing)
+		}
+	}
+}
+
+// addNumber keeps the latest point of gauges and cumulative sums, and adds up the points of delta sums.
+func (a *accumulator) addNumber(id identity.Stream, to pmetric.NumberDataPointSlice, dp pmetric.NumberDataPoint, delta bool) {
+	key := pointKey(id, dp.Attributes())
+	existing, ok := a.numbers[key]
+	if !ok {
+		if delta && dp.Flags().NoRecordedValue() {
+			return
+		}
+		existing = to.AppendEmpty()
+		dp.CopyTo(existing)
+		a.numbers[key] = existing
+		return
+	}
+	if !delta {
+		if dp.Timestamp() >= existing.Timestamp() {
+			dp.CopyTo(existing)
+		}
+		return
+	}
+	if dp.Flags().NoRecordedValue() {
+		return
+	}
+
+	if existing.ValueType() == pmetric.NumberDataPointValueTypeInt && dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		existing.SetIntValue(existing.IntValue() + dp.IntValue())
+	} else {
+		existing.SetDoubleValue(numberValue(existing) + numberValue(dp))
+	}
+	mergeTimestamps(existing, dp)
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+type timestamped interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}
+
+func mergeTimestamps(to, from timestamped) {
+	if from.StartTimestamp() < to.StartTimestamp() {
+		to.SetStartTimestamp(from.StartTimestamp())
+	}
+	if from.Timestamp() > to.Timestamp() {
+		to.SetTimestamp(from.Timestamp())
+	}
+}
+
+// addHistogram keeps the latest point of cumulative histograms, and merges the points of delta histograms.
+// A delta point whose bucket layout differs from the merged point can't be added to it: the merged
+// point is closed and the stream continues with a new point, both being exported for the interval.
+func (a *accumulator) addHistogram(id identity.Stream, to pmetric.HistogramDataPointSlice, dp pmetric.HistogramDataPoint, delta bool) {
+	key := pointKey(id, dp.Attributes())
+	existing, ok := a.histograms[key]
+	if !ok {
+		if delta && dp.Flags().NoRecordedValue() {
+			return
+		}
+		existing = to.AppendEmpty()
+		dp.CopyTo(existing)
+		a.histograms[key] = existing
+		return
+	}
+	if !delta {
+		if dp.Timestamp() >= existing.Timestamp() {
+			dp.CopyTo(existing)
+		}
+		return
+	}
+	if dp.Flags().NoRecordedValue() {
+		return
+	}
+	if !equalBounds(existing.ExplicitBounds(), dp.ExplicitBounds()) || existing.BucketCounts().Len() != dp.BucketCounts().Len() {
+		a.closedHistograms[key] = append(a.closedHistograms[key], closedHistogram{point: existing, end: existing.Timestamp()})
+		existing = to.AppendEmpty()
+		dp.CopyTo(existing)
+		a.histograms[key] = existing
+		return
+	}
+
+	existing.SetCount(existing.Count() + dp.Count())
+	if existing.HasSum() && dp.HasSum() {
+		existing.SetSum(existing.Sum() + dp.Sum())
+	} else {
+		existing.RemoveSum()
+	}
+	mergeMinMax(existing, dp)
+	for i := 0; i < dp.BucketCounts().Len(); i++ {
+		existing.BucketCounts().SetAt(i, existing.BucketCounts().At(i)+dp.BucketCounts().At(i))
+	}
+	mergeTimestamps(existing, dp)
+}
+
+func equalBounds(a, b pcommon.Float64Slice) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		if a.At(i) != b.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
+type minMax interface {
+	HasMin() bool
+	Min() float64
+	SetMin(float64)
+	RemoveMin()
+	HasMax() bool
+	Max() float64
+	SetMax(float64)
+	RemoveMax()
+}
+
+func mergeMinMax(to, from minMax) {
+	switch {
+	case !to.HasMin() || !from.HasMin():
+		to.RemoveMin()
+	case from.Min() < to.Min():
+		to.SetMin(from.Min())
+	}
+	switch {
+	case !to.HasMax() || !from.HasMax():
+		to.RemoveMax()
+	case from.Max() > to.Max():
+		to.SetMax(from.Max())
+	}
+}
+
+// addExponentialHistogram keeps the latest point of cumulative exponential histograms, and merges the points
+// of delta exponential histograms at the smallest scale of the two.
+func (a *accumulator) addExponentialHistogram(id identity.Stream, to pmetric.ExponentialHistogramDataPointSlice, dp pmetric.ExponentialHistogramDataPoint, delta bool) {
+	key := pointKey(id, dp.Attributes())
+	existing, ok := a.exponentialHistograms[key]
+	if !ok {
+		if delta && dp.Flags().NoRecordedValue() {
+			return
+		}
+		existing = to.AppendEmpty()
+		dp.CopyTo(existing)
+		a.exponentialHistograms[key] = existing
+		return
+	}
+	if !delta {
+		if dp.Timestamp() >= existing.Timestamp() {
+			dp.CopyTo(existing)
+		}
+		return
+	}
+	if dp.Flags().NoRecordedValue() {
+		return
+	}
+
+	from := pmetric.NewExponentialHistogramDataPoint()
+	dp.CopyTo(from)
+	if from.Scale() < existing.Scale() {
+		exphistogram.DownscaleBuckets(existing.Positive(), existing.Scale()-from.Scale())
+		exphistogram.DownscaleBuckets(existing.Negative(), existing.Scale()-from.Scale())
+		existing.SetScale(from.Scale())
+	} else {
+		exphistogram.DownscaleBuckets(from.Positive(), from.Scale()-existing.Scale())
+		exphistogram.DownscaleBuckets(from.Negative(), from.Scale()-existing.Scale())
+	}
+
+	existing.SetCount(existing.Count() + from.Count())
+	existing.SetZeroCount(existing.ZeroCount() + from.ZeroCount())
+	if existing.HasSum() && from.HasSum() {
+		existing.SetSum(existing.Sum() + from.Sum())
+	} else {
+		existing.RemoveSum()
+	}
+	mergeMinMax(existing, from)
+	exphistogram.MergeBuckets(existing.Positive(), from.Positive())
+	exphistogram.MergeBuckets(existing.Negative(), from.Negative())
+	mergeTimestamps(existing, from)
+}
+
+// flush aligns the timestamps of all streams on the interval and returns them. Delta points cover
+// the whole interval, or split it when the bucket layout of a histogram stream changed. Cumulative
+// points and gauges keep their start timestamp.
+func (a *accumulator) flush(start, end time.Time) pmetric.Metrics {
+	startTimestamp := pcommon.NewTimestampFromTime(start)
+	endTimestamp := pcommon.NewTimestampFromTime(end)
+	for _, m := range a.metrics {
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			dps := m.Gauge().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				dps.At(i).SetTimestamp(endTimestamp)
+			}
+		case pmetric.MetricTypeSum:
+			dps := m.Sum().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				alignTimestamps(dps.At(i), m.Sum().AggregationTemporality(), startTimestamp, endTimestamp)
+			}
+		case pmetric.MetricTypeHistogram:
+			dps := m.Histogram().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				alignTimestamps(dps.At(i), m.Histogram().AggregationTemporality(), startTimestamp, endTimestamp)
+			}
+		case pmetric.MetricTypeExponentialHistogram:
+			dps := m.ExponentialHistogram().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				alignTimestamps(dps.At(i), m.ExponentialHistogram().AggregationTemporality(), startTimestamp, endTimestamp)
+			}
+		case pmetric.MetricTypeSummary:
+			dps := m.Summary().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				dps.At(i).SetTimestamp(endTimestamp)
+			}
+		}
+	}
+
+	// Split the interval between the consecutive points of the streams whose bucket layout changed.
+	for key, closed := range a.closedHistograms {
+		start := startTimestamp
+		for _, c := range closed {
+			end := c.end
+			if end < start {
+				end = start
+			} else if end > endTimestamp {
+				end = endTimestamp
+			}
+			c.point.SetStartTimestamp(start)
+			c.point.SetTimestamp(end)
+			start = end
+		}
+		a.histograms[key].SetStartTimestamp(start)
+	}
+
+	// Drop the metrics whose points were all skipped.
+	a.md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return dataPointCount(m) == 0
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return a.md
+}
+
+func dataPointCount(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
+
+func alignTimestamps(dp timestamped, temporality pmetric.AggregationTemporality, start, end pcommon.Timestamp) {
+	if temporality == pmetric.AggregationTemporalityDelta {
+		dp.SetStartTimestamp(start)
+	}
+	dp.SetTimestamp(end)
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
+)
+
+var _ processor.Metrics = (*intervalProcessor)(nil)
+
+// intervalProcessor buffers the metrics it receives and exports the aggregated streams
+// once per interval, at the interval boundaries.
+type intervalProcessor struct {
+	logger       *zap.Logger
+	interval     time.Duration
+	nextConsumer consumer.Metrics
+
+	mu          sync.Mutex
+	accumulator *accumulator
+
+	cancelFunc context.CancelFunc
+	done       chan struct{}
+}
+
+func newIntervalProcessor(config *Config, logger *zap.Logger, nextConsumer consumer.Metrics) *intervalProcessor {
+	return &intervalProcessor{
+		logger:       logger,
+		interval:     config.Interval,
+		nextConsumer: nextConsumer,
+		accumulator:  newAccumulator(),
+	}
+}
+
+func (p *intervalProcessor) Start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancelFunc = cancel
+	p.done = make(chan struct{})
+	go p.run(ctx, p.done)
+	return nil
+}
+
+// Shutdown stops the export loop and exports the streams aggregated since the last boundary.
+func (p *intervalProcessor) Shutdown(ctx context.Context) error {
+	if p.cancelFunc == nil {
+		return nil
+	}
+	p.cancelFunc()
+	<-p.done
+	return p.export(ctx, p.nextBoundary(time.Now()))
+}
+
+func (p *intervalProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeMetrics adds the metrics to the current interval. Nothing is forwarded until the interval ends.
+func (p *intervalProcessor) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.accumulator.add(md)
+	return nil
+}
+
+func (p *intervalProcessor) nextBoundary(now time.Time) time.Time {
+	return now.Truncate(p.interval).Add(p.interval)
+}
+
+func (p *intervalProcessor) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	for {
+		boundary := p.nextBoundary(time.Now())
+		timer := time.NewTimer(time.Until(boundary))
+		select {
+		case <-timer.C:
+			if err := p.export(ctx, boundary); err != nil {
+				p.logger.Error("failed to export aggregated metrics", zap.Error(err))
+			}
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// export sends the streams aggregated during the interval ending at boundary to the next consumer,
+// and starts a new interval.
+func (p *intervalProcessor) export(ctx context.Context, boundary time.Time) error {
+	p.mu.Lock()
+	acc := p.accumulator
+	p.accumulator = newAccumulator()
+	p.mu.Unlock()
+
+	md := acc.flush(boundary.Add(-p.interval), boundary)
+	if md.DataPointCount() == 0 {
+		return nil
+	}
+	return p.nextConsumer.ConsumeMetrics(ctx, md)
+}
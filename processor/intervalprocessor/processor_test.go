@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type testPoint struct {
+	timestamp int64
+	value     int64
+	flags     pmetric.DataPointFlags
+}
+
+func generateMetrics(name string, fill func(m pmetric.Metric)) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host.name", "host")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName(name)
+	fill(m)
+	return md
+}
+
+func numberMetrics(name string, metricType pmetric.MetricType, temporality pmetric.AggregationTemporality, points ...testPoint) []pmetric.Metrics {
+	var batches []pmetric.Metrics
+	for _, p := range points {
+		p := p
+		batches = append(batches, generateMetrics(name, func(m pmetric.Metric) {
+			var dps pmetric.NumberDataPointSlice
+			if metricType == pmetric.MetricTypeGauge {
+				dps = m.SetEmptyGauge().DataPoints()
+			} else {
+				sum := m.SetEmptySum()
+				sum.SetAggregationTemporality(temporality)
+				sum.SetIsMonotonic(true)
+				dps = sum.DataPoints()
+			}
+			dp := dps.AppendEmpty()
+			dp.Attributes().PutStr("label", "value")
+			dp.SetStartTimestamp(pcommon.Timestamp(1))
+			dp.SetTimestamp(pcommon.Timestamp(p.timestamp))
+			dp.SetIntValue(p.value)
+			dp.SetFlags(p.flags)
+		}))
+	}
+	return batches
+}
+
+func numberPoints(t *testing.T, m pmetric.Metric) pmetric.NumberDataPointSlice {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints()
+	}
+	t.Fatalf("unexpected metric type %v", m.Type())
+	return pmetric.NumberDataPointSlice{}
+}
+
+func TestIntervalProcessorNumbers(t *testing.T) {
+	boundary := time.Unix(120, 0)
+	start := pcommon.NewTimestampFromTime(time.Unix(60, 0))
+	end := pcommon.NewTimestampFromTime(boundary)
+
+	tests := []struct {
+		name          string
+		in            []pmetric.Metrics
+		wantValue     int64
+		wantStart     pcommon.Timestamp
+		wantNoMetrics bool
+	}{
+		{
+			name:      "gauge keeps the latest value",
+			in:        numberMetrics("gauge", pmetric.MetricTypeGauge, 0, testPoint{timestamp: 20, value: 5}, testPoint{timestamp: 10, value: 3}, testPoint{timestamp: 30, value: 7}),
+			wantValue: 7,
+			wantStart: 1,
+		},
+		{
+			name:      "cumulative sum keeps the latest value",
+			in:        numberMetrics("sum", pmetric.MetricTypeSum, pmetric.AggregationTemporalityCumulative, testPoint{timestamp: 10, value: 10}, testPoint{timestamp: 20, value: 25}),
+			wantValue: 25,
+			wantStart: 1,
+		},
+		{
+			name:      "delta sum adds the values",
+			in:        numberMetrics("sum", pmetric.MetricTypeSum, pmetric.AggregationTemporalityDelta, testPoint{timestamp: 10, value: 10}, testPoint{timestamp: 20, value: 25}),
+			wantValue: 35,
+			wantStart: start,
+		},
+		{
+			name: "delta sum skips points without value",
+			in: numberMetrics("sum", pmetric.MetricTypeSum, pmetric.AggregationTemporalityDelta,
+				testPoint{timestamp: 10, value: 10},
+				testPoint{timestamp: 20, value: 0, flags: pmetric.DefaultDataPointFlags.WithNoRecordedValue(true)},
+				testPoint{timestamp: 30, value: 5}),
+			wantValue: 15,
+			wantStart: start,
+		},
+		{
+			name: "delta sum without values is not exported",
+			in: numberMetrics("sum", pmetric.MetricTypeSum, pmetric.AggregationTemporalityDelta,
+				testPoint{timestamp: 10, value: 0, flags: pmetric.DefaultDataPointFlags.WithNoRecordedValue(true)}),
+			wantNoMetrics: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			p := newIntervalProcessor(&Config{Interval: time.Minute}, zap.NewNop(), sink)
+			for _, md := range tt.in {
+				require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+			}
+			assert.Empty(t, sink.AllMetrics())
+
+			require.NoError(t, p.export(context.Background(), boundary))
+			if tt.wantNoMetrics {
+				assert.Empty(t, sink.AllMetrics())
+				return
+			}
+			require.Len(t, sink.AllMetrics(), 1)
+			md := sink.AllMetrics()[0]
+			require.Equal(t, 1, md.DataPointCount())
+
+			rm := md.ResourceMetrics().At(0)
+			host, _ := rm.Resource().Attributes().Get("host.name")
+			assert.Equal(t, "host", host.Str())
+			assert.Equal(t, "scope", rm.ScopeMetrics().At(0).Scope().Name())
+
+			dp := numberPoints(t, rm.ScopeMetrics().At(0).Metrics().At(0)).At(0)
+			assert.Equal(t, tt.wantValue, dp.IntValue())
+			assert.Equal(t, tt.wantStart, dp.StartTimestamp())
+			assert.Equal(t, end, dp.Timestamp())
+			label, _ := dp.Attributes().Get("label")
+			assert.Equal(t, "value", label.Str())
+
+			// The next interval starts empty.
+			require.NoError(t, p.export(context.Background(), boundary.Add(time.Minute)))
+			assert.Len(t, sink.AllMetrics(), 1)
+		})
+	}
+}
+
+func TestIntervalProcessorDeltaHistograms(t *testing.T) {
+	histogram := func(count uint64, sum, min, max float64, bounds []float64, buckets []uint64) pmetric.Metrics {
+		return generateMetrics("histogram", func(m pmetric.Metric) {
+			h := m.SetEmptyHistogram()
+			h.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			dp := h.DataPoints().AppendEmpty()
+			dp.SetCount(count)
+			dp.SetSum(sum)
+			dp.SetMin(min)
+			dp.SetMax(max)
+			dp.ExplicitBounds().FromRaw(bounds)
+			dp.BucketCounts().FromRaw(buckets)
+		})
+	}
+
+	sink := new(consumertest.MetricsSink)
+	p := newIntervalProcessor(&Config{Interval: time.Minute}, zap.NewNop(), sink)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), histogram(3, 6, 1, 3, []float64{2}, []uint64{1, 2})))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), histogram(2, 10, 0.5, 9.5, []float64{2}, []uint64{1, 1})))
+	require.NoError(t, p.export(context.Background(), time.Unix(120, 0)))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	dp := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, 16.0, dp.Sum())
+	assert.Equal(t, 0.5, dp.Min())
+	assert.Equal(t, 9.5, dp.Max())
+	assert.Equal(t, []uint64{2, 3}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(60, 0)), dp.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(120, 0)), dp.Timestamp())
+}
+
+func TestIntervalProcessorDeltaHistogramsLayoutChange(t *testing.T) {
+	histogram := func(timestamp int64, count uint64, bounds []float64, buckets []uint64) pmetric.Metrics {
+		return generateMetrics("histogram", func(m pmetric.Metric) {
+			h := m.SetEmptyHistogram()
+			h.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			dp := h.DataPoints().AppendEmpty()
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(timestamp, 0)))
+			dp.SetCount(count)
+			dp.ExplicitBounds().FromRaw(bounds)
+			dp.BucketCounts().FromRaw(buckets)
+		})
+	}
+
+	sink := new(consumertest.MetricsSink)
+	p := newIntervalProcessor(&Config{Interval: time.Minute}, zap.NewNop(), sink)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), histogram(70, 3, []float64{2}, []uint64{1, 2})))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), histogram(80, 2, []float64{2}, []uint64{1, 1})))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), histogram(90, 6, []float64{1, 5}, []uint64{1, 2, 3})))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), histogram(100, 4, []float64{1, 5}, []uint64{2, 1, 1})))
+	require.NoError(t, p.export(context.Background(), time.Unix(120, 0)))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	dps := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints()
+	require.Equal(t, 2, dps.Len())
+
+	first := dps.At(0)
+	assert.Equal(t, uint64(5), first.Count())
+	assert.Equal(t, []float64{2}, first.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{2, 3}, first.BucketCounts().AsRaw())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(60, 0)), first.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(80, 0)), first.Timestamp())
+
+	second := dps.At(1)
+	assert.Equal(t, uint64(10), second.Count())
+	assert.Equal(t, []float64{1, 5}, second.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{3, 3, 4}, second.BucketCounts().AsRaw())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(80, 0)), second.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(120, 0)), second.Timestamp())
+}
+
+func TestIntervalProcessorDeltaExponentialHistograms(t *testing.T) {
+	histogram := func(count uint64, scale, offset int32, buckets []uint64) pmetric.Metrics {
+		return generateMetrics("exponential_histogram", func(m pmetric.Metric) {
+			h := m.SetEmptyExponentialHistogram()
+			h.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			dp := h.DataPoints().AppendEmpty()
+			dp.SetCount(count)
+			dp.SetZeroCount(1)
+			dp.SetScale(scale)
+			dp.Positive().SetOffset(offset)
+			dp.Positive().BucketCounts().FromRaw(buckets)
+		})
+	}
+
+	sink := new(consumertest.MetricsSink)
+	p := newIntervalProcessor(&Config{Interval: time.Minute}, zap.NewNop(), sink)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), histogram(5, 1, 1, []uint64{1, 2, 1})))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), histogram(4, 0, 1, []uint64{1, 2})))
+	require.NoError(t, p.export(context.Background(), time.Unix(120, 0)))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	dp := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, uint64(9), dp.Count())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	assert.Equal(t, int32(0), dp.Scale())
+	assert.Equal(t, int32(0), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 4, 2}, dp.Positive().BucketCounts().AsRaw())
+}
+
+func TestIntervalProcessorExportsOnShutdown(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newIntervalProcessor(&Config{Interval: time.Hour}, zap.NewNop(), sink)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	for _, md := range numberMetrics("gauge", pmetric.MetricTypeGauge, 0, testPoint{timestamp: 10, value: 1}) {
+		require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	}
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	dp := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	assert.Equal(t, int64(0), dp.Timestamp().AsTime().UnixNano()%int64(time.Hour))
+}
+
+func TestIntervalProcessorExportsOnInterval(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newIntervalProcessor(&Config{Interval: 10 * time.Millisecond}, zap.NewNop(), sink)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	for _, md := range numberMetrics("gauge", pmetric.MetricTypeGauge, 0, testPoint{timestamp: 10, value: 1}) {
+		require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	}
+	assert.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) == 1
+	}, time.Second, time.Millisecond)
+}
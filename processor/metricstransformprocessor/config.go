@@ -47,6 +47,9 @@ const (
 
 	// SubmatchCaseFieldName is the mapstructure field name for SubmatchCase field
 	SubmatchCaseFieldName = "submatch_case"
+
+	// PercentilesFieldName is the mapstructure field name for Percentiles field
+	PercentilesFieldName = "percentiles"
 )
 
 // Config defines configuration for Resource processor.
@@ -139,6 +142,10 @@ type Operation struct {
 
 	// LabelValue identifies the exact label value to operate on
 	LabelValue string `mapstructure:"label_value"`
+
+	// Percentiles is the list of percentiles, in the (0, 100] range, estimated from histograms.
+	// Defaults to 50, 90 and 99 when the operation is experimental_histogram_percentiles.
+	Percentiles []float64 `mapstructure:"percentiles"`
 }
 
 // ValueAction renames label values.
@@ -213,9 +220,16 @@ const (
 	// Metric has to match the FilterConfig with all its data points if used with Update ConfigAction,
 	// otherwise the operation will be ignored.
 	AggregateLabelValues OperationAction = "aggregate_label_values"
+
+	// HistogramPercentiles replaces the histogram or exponential histogram data points with gauge data points
+	// estimating the percentiles in Operation.Percentiles. Each gauge data point keeps the attributes of
+	// its histogram data point, and the percentile is added under the Operation.Label attribute.
+	// Metric has to match the FilterConfig with all its data points if used with Update ConfigAction,
+	// otherwise the operation will be ignored.
+	HistogramPercentiles OperationAction = "experimental_histogram_percentiles"
 )
 
-var operationActions = []OperationAction{AddLabel, UpdateLabel, DeleteLabelValue, ToggleScalarDataType, ScaleValue, AggregateLabels, AggregateLabelValues}
+var operationActions = []OperationAction{AddLabel, UpdateLabel, DeleteLabelValue, ToggleScalarDataType, ScaleValue, AggregateLabels, AggregateLabelValues, HistogramPercentiles}
 
 func (oa OperationAction) isValid() bool {
 	for _, operationAction := range operationActions {
@@ -130,6 +130,12 @@ func validateConfiguration(config *Config) error {
 				return fmt.Errorf("operation %v: missing required field %q while %q is %v", i+1, ScaleFieldName, ActionFieldName, ScaleValue)
 			}
 
+			for _, p := range op.Percentiles {
+				if p <= 0 || p > 100 {
+					return fmt.Errorf("operation %v: %q values must be in the (0, 100] range, got %v", i+1, PercentilesFieldName, p)
+				}
+			}
+
 			if op.AggregationType != "" && !op.AggregationType.isValid() {
 				return fmt.Errorf("operation %v: %q must be in %q", i+1, AggregationTypeFieldName, aggregationTypes)
 			}
@@ -174,6 +180,13 @@ func buildHelperConfig(config *Config, version string) ([]internalTransform, err
 				mtpOp.labelSetMap = sliceToSet(op.LabelSet)
 			} else if op.Action == AggregateLabelValues {
 				mtpOp.aggregatedValuesSet = sliceToSet(op.AggregatedValues)
+			} else if op.Action == HistogramPercentiles {
+				if len(op.Percentiles) == 0 {
+					mtpOp.configOperation.Percentiles = defaultPercentiles
+				}
+				if op.Label == "" {
+					mtpOp.configOperation.Label = defaultPercentileLabel
+				}
 			}
 			helperT.Operations[j] = mtpOp
 		}
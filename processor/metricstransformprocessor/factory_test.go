@@ -89,6 +89,11 @@ func TestCreateProcessors(t *testing.T) {
 			succeed:      false,
 			errorMessage: fmt.Sprintf("operation %v: missing required field %q while %q is %v", 1, ScaleFieldName, ActionFieldName, ScaleValue),
 		},
+		{
+			configName:   "config_invalid_percentiles.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("operation %v: %q values must be in the (0, 100] range, got %v", 1, PercentilesFieldName, 150),
+		},
 		{
 			configName:   "config_invalid_regexp.yaml",
 			succeed:      false,
@@ -127,6 +127,24 @@ func (b builder) addHistogramDatapointWithMinMaxAndExemplars(start, ts pcommon.T
 	return b
 }
 
+func (b builder) addExponentialHistogramDatapoint(start, ts pcommon.Timestamp, count uint64, sum float64, scale int32,
+	zeroCount uint64, offset int32, buckets []uint64, attrValues ...string) builder {
+	if b.metric.Type() != pmetric.MetricTypeExponentialHistogram {
+		panic(b.metric.Type().String())
+	}
+	dp := b.metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	b.setAttrs(dp.Attributes(), attrValues)
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetScale(scale)
+	dp.SetZeroCount(zeroCount)
+	dp.Positive().SetOffset(offset)
+	dp.Positive().BucketCounts().FromRaw(buckets)
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	return b
+}
+
 // setUnit sets the unit of this metric
 func (b builder) setUnit(unit string) builder {
 	b.metric.SetUnit(unit)
@@ -463,7 +463,7 @@ func copyMetricDetails(from, to pmetric.Metric) {
 	case pmetric.MetricTypeHistogram:
 		to.SetEmptyHistogram().SetAggregationTemporality(from.Histogram().AggregationTemporality())
 	case pmetric.MetricTypeExponentialHistogram:
-		to.SetEmptyExponentialHistogram().SetAggregationTemporality(from.Histogram().AggregationTemporality())
+		to.SetEmptyExponentialHistogram().SetAggregationTemporality(from.ExponentialHistogram().AggregationTemporality())
 	case pmetric.MetricTypeSummary:
 		to.SetEmptySummary()
 	}
@@ -566,6 +566,10 @@ func transformMetric(metric pmetric.Metric, transform internalTransform) bool {
 			if canChangeMetric {
 				deleteLabelValueOp(metric, op)
 			}
+		case HistogramPercentiles:
+			if canChangeMetric {
+				histogramPercentilesOp(metric, op)
+			}
 		}
 	}
 
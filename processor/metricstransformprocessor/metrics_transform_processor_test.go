@@ -75,6 +75,27 @@ func TestMetricsTransformProcessor(t *testing.T) {
 	}
 }
 
+func TestExponentialHistogramQuantile(t *testing.T) {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetCount(8)
+	dp.SetScale(0)
+	dp.SetZeroCount(2)
+	// (-4, -2]: 2
+	dp.Negative().SetOffset(1)
+	dp.Negative().BucketCounts().FromRaw([]uint64{2})
+	// (1, 2]: 2, (2, 4]: 2
+	dp.Positive().SetOffset(0)
+	dp.Positive().BucketCounts().FromRaw([]uint64{2, 2})
+
+	assert.InDelta(t, -3, exponentialHistogramQuantile(dp, 0.125), 1e-9)
+	assert.InDelta(t, 0, exponentialHistogramQuantile(dp, 0.5), 1e-9)
+	assert.InDelta(t, 1.5, exponentialHistogramQuantile(dp, 0.625), 1e-9)
+	assert.InDelta(t, 4, exponentialHistogramQuantile(dp, 1), 1e-9)
+
+	dp.SetMax(3)
+	assert.InDelta(t, 3, exponentialHistogramQuantile(dp, 1), 1e-9)
+}
+
 func sortDataPoints(m pmetric.Metric) pmetric.Metric {
 	switch m.Type() {
 	case pmetric.MetricTypeSum:
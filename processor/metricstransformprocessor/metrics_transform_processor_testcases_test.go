@@ -562,6 +562,134 @@ var (
 					build(),
 			},
 		},
+		{
+			name: "metric_label_aggregation_sum_histogram_different_bounds_update",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{include: "metric1"},
+					Action:              Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:          AggregateLabels,
+								AggregationType: Sum,
+								LabelSet:        []string{"label1"},
+							},
+							labelSetMap: map[string]bool{"label1": true},
+						},
+					},
+				},
+			},
+			in: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeHistogram, "metric1", "label1", "label2").
+					addHistogramDatapoint(0, 1, 3, 6, []float64{1, 2, 3}, []uint64{0, 1, 1, 1}, "label1-value1",
+						"label2-value1").
+					addHistogramDatapoint(0, 1, 3, 9, []float64{2}, []uint64{1, 2}, "label1-value1",
+						"label2-value2").
+					build(),
+			},
+			out: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeHistogram, "metric1", "label1").
+					addHistogramDatapoint(0, 1, 6, 15, []float64{2}, []uint64{2, 4}, "label1-value1").
+					build(),
+			},
+		},
+		{
+			name: "metric_label_aggregation_sum_histogram_non_nesting_bounds_update",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{include: "metric1"},
+					Action:              Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:          AggregateLabels,
+								AggregationType: Sum,
+								LabelSet:        []string{"label1"},
+							},
+							labelSetMap: map[string]bool{"label1": true},
+						},
+					},
+				},
+			},
+			in: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeHistogram, "metric1", "label1", "label2").
+					addHistogramDatapoint(0, 1, 3, 6, []float64{1, 2, 3}, []uint64{0, 1, 1, 1}, "label1-value1",
+						"label2-value1").
+					addHistogramDatapoint(0, 1, 3, 9, []float64{2, 3, 4}, []uint64{1, 1, 0, 1}, "label1-value1",
+						"label2-value2").
+					addHistogramDatapoint(0, 1, 2, 2, []float64{0, 2}, []uint64{0, 1, 1}, "label1-value1",
+						"label2-value3").
+					build(),
+			},
+			out: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeHistogram, "metric1", "label1").
+					addHistogramDatapoint(0, 1, 8, 17, []float64{2}, []uint64{3, 5}, "label1-value1").
+					build(),
+			},
+		},
+		{
+			name: "metric_label_aggregation_sum_exponential_histogram_different_scales_update",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{include: "metric1"},
+					Action:              Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:          AggregateLabels,
+								AggregationType: Sum,
+								LabelSet:        []string{"label1"},
+							},
+							labelSetMap: map[string]bool{"label1": true},
+						},
+					},
+				},
+			},
+			in: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeExponentialHistogram, "metric1", "label1", "label2").
+					addExponentialHistogramDatapoint(0, 1, 7, 10, 1, 1, 0, []uint64{1, 2, 3}, "label1-value1",
+						"label2-value1").
+					addExponentialHistogramDatapoint(0, 1, 4, 8, 0, 0, 1, []uint64{4}, "label1-value1",
+						"label2-value2").
+					build(),
+			},
+			out: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeExponentialHistogram, "metric1", "label1").
+					addExponentialHistogramDatapoint(0, 1, 11, 18, 0, 1, 0, []uint64{3, 7}, "label1-value1").
+					build(),
+			},
+		},
+		{
+			name: "metric_histogram_percentiles_update",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{include: "metric1"},
+					Action:              Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:      HistogramPercentiles,
+								Label:       "percentile",
+								Percentiles: []float64{50, 75},
+							},
+						},
+					},
+				},
+			},
+			in: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeHistogram, "metric1", "label1").
+					addHistogramDatapoint(1, 2, 4, 8, []float64{1, 2, 3}, []uint64{0, 2, 2, 0}, "label1-value1").
+					addHistogramDatapoint(1, 2, 0, 0, []float64{1, 2, 3}, []uint64{0, 0, 0, 0}, "label1-value2").
+					build(),
+			},
+			out: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeGauge, "metric1", "label1", "percentile").
+					addDoubleDatapoint(1, 2, 2, "label1-value1", "p50").
+					addDoubleDatapoint(1, 2, 2.5, "label1-value1", "p75").
+					build(),
+			},
+		},
 		{
 			name: "metric_label_aggregation_ignored_for_partial_metric_match",
 			transforms: []internalTransform{
@@ -17,6 +17,7 @@ package metricstransformprocessor // import "github.com/open-telemetry/opentelem
 import (
 	"encoding/json"
 	"math"
+	"sort"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -111,10 +112,8 @@ func groupHistogramDataPoints(dps pmetric.HistogramDataPointSlice, useStartTime
 	dpsByAttrsAndTs map[string]pmetric.HistogramDataPointSlice) {
 	for i := 0; i < dps.Len(); i++ {
 		dp := dps.At(i)
-		keyHashParts := make([]interface{}, 0, dp.ExplicitBounds().Len()+4)
-		for b := 0; b < dp.ExplicitBounds().Len(); b++ {
-			keyHashParts = append(keyHashParts, dp.ExplicitBounds().At(b))
-		}
+		// Bucket layouts are not part of the key: points with different bounds are re-bucketed when merged.
+		keyHashParts := make([]interface{}, 0, 4)
 		if useStartTime {
 			keyHashParts = append(keyHashParts, dp.StartTimestamp().String())
 		}
@@ -132,9 +131,9 @@ func groupExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPoi
 	dpsByAttrsAndTs map[string]pmetric.ExponentialHistogramDataPointSlice) {
 	for i := 0; i < dps.Len(); i++ {
 		dp := dps.At(i)
-		keyHashParts := make([]interface{}, 0, 5)
-		keyHashParts = append(keyHashParts, dp.Scale(), dp.HasMin(), dp.HasMax(), uint32(dp.Flags()), dp.Negative().Offset(),
-			dp.Positive().Offset())
+		// Scales and offsets are not part of the key: points are downscaled to a common scale when merged.
+		keyHashParts := make([]interface{}, 0, 4)
+		keyHashParts = append(keyHashParts, dp.HasMin(), dp.HasMax(), uint32(dp.Flags()))
 		if useStartTime {
 			keyHashParts = append(keyHashParts, dp.StartTimestamp().String())
 		}
@@ -231,11 +230,14 @@ func intVal(dp pmetric.NumberDataPoint) int64 {
 	return 0
 }
 
+// mergeHistogramDataPoints merges the data points of each group into one, re-bucketing them to the bounds they
+// all share.
 func mergeHistogramDataPoints(dpsMap map[string]pmetric.HistogramDataPointSlice, to pmetric.HistogramDataPointSlice) {
 	for _, dps := range dpsMap {
+		bounds := commonExplicitBounds(dps)
 		dp := to.AppendEmpty()
 		dps.At(0).MoveTo(dp)
-		counts := dp.BucketCounts()
+		counts := rebucket(dp, bounds)
 		for i := 1; i < dps.Len(); i++ {
 			if dps.At(i).Count() == 0 {
 				continue
@@ -248,46 +250,149 @@ func mergeHistogramDataPoints(dpsMap map[string]pmetric.HistogramDataPointSlice,
 			if dp.HasMax() && dp.Max() < dps.At(i).Max() {
 				dp.SetMax(dps.At(i).Max())
 			}
-			for b := 0; b < dps.At(i).BucketCounts().Len(); b++ {
-				counts.SetAt(b, counts.At(b)+dps.At(i).BucketCounts().At(b))
+			for b, c := range rebucket(dps.At(i), bounds) {
+				counts[b] += c
 			}
 			dps.At(i).Exemplars().MoveAndAppendTo(dp.Exemplars())
 			if dps.At(i).StartTimestamp() < dp.StartTimestamp() {
 				dp.SetStartTimestamp(dps.At(i).StartTimestamp())
 			}
 		}
+		dp.ExplicitBounds().FromRaw(bounds)
+		dp.BucketCounts().FromRaw(counts)
+	}
+}
+
+// commonExplicitBounds returns the intersection of the bucket bounds of the data points. Every bucket of every
+// data point fits entirely in one bucket of the intersection, so re-bucketing to it doesn't need to guess how
+// values are spread inside a bucket. Histograms without buckets only contribute to count and sum, so they don't
+// restrict the common bounds.
+func commonExplicitBounds(dps pmetric.HistogramDataPointSlice) []float64 {
+	var bounds []float64
+	found := false
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.BucketCounts().Len() != dp.ExplicitBounds().Len()+1 {
+			continue
+		}
+		if !found {
+			bounds = dp.ExplicitBounds().AsRaw()
+			found = true
+			continue
+		}
+		other := dp.ExplicitBounds()
+		common := bounds[:0]
+		for j, k := 0, 0; j < len(bounds) && k < other.Len(); {
+			switch {
+			case bounds[j] == other.At(k):
+				common = append(common, bounds[j])
+				j++
+				k++
+			case bounds[j] < other.At(k):
+				j++
+			default:
+				k++
+			}
+		}
+		bounds = common
 	}
+	return bounds
+}
+
+// rebucket returns the bucket counts of the data point for the given bounds,
+// which must be a subset of the data point bounds.
+func rebucket(dp pmetric.HistogramDataPoint, bounds []float64) []uint64 {
+	counts := make([]uint64, len(bounds)+1)
+	dpBounds := dp.ExplicitBounds()
+	dpCounts := dp.BucketCounts()
+	if dpCounts.Len() != dpBounds.Len()+1 {
+		// Histograms without buckets only contribute to count and sum.
+		return counts
+	}
+	for i := 0; i < dpCounts.Len(); i++ {
+		target := len(bounds)
+		if i < dpBounds.Len() {
+			target = sort.SearchFloat64s(bounds, dpBounds.At(i))
+		}
+		counts[target] += dpCounts.At(i)
+	}
+	return counts
 }
 
 func mergeExponentialHistogramDataPoints(dpsMap map[string]pmetric.ExponentialHistogramDataPointSlice,
 	to pmetric.ExponentialHistogramDataPointSlice) {
 	for _, dps := range dpsMap {
+		scale := dps.At(0).Scale()
+		for i := 1; i < dps.Len(); i++ {
+			if dps.At(i).Count() != 0 && dps.At(i).Scale() < scale {
+				scale = dps.At(i).Scale()
+			}
+		}
+
 		dp := to.AppendEmpty()
 		dps.At(0).MoveTo(dp)
-		negatives := dp.Negative().BucketCounts()
-		positives := dp.Positive().BucketCounts()
+		negatives := map[int32]uint64{}
+		positives := map[int32]uint64{}
+		addExponentialBuckets(negatives, dp.Negative(), dp.Scale()-scale)
+		addExponentialBuckets(positives, dp.Positive(), dp.Scale()-scale)
 		for i := 1; i < dps.Len(); i++ {
 			if dps.At(i).Count() == 0 {
 				continue
 			}
 			dp.SetCount(dp.Count() + dps.At(i).Count())
 			dp.SetSum(dp.Sum() + dps.At(i).Sum())
+			dp.SetZeroCount(dp.ZeroCount() + dps.At(i).ZeroCount())
 			if dp.HasMin() && dp.Min() > dps.At(i).Min() {
 				dp.SetMin(dps.At(i).Min())
 			}
 			if dp.HasMax() && dp.Max() < dps.At(i).Max() {
 				dp.SetMax(dps.At(i).Max())
 			}
-			for b := 0; b < dps.At(i).Negative().BucketCounts().Len(); b++ {
-				negatives.SetAt(b, negatives.At(b)+dps.At(i).Negative().BucketCounts().At(b))
-			}
-			for b := 0; b < dps.At(i).Positive().BucketCounts().Len(); b++ {
-				positives.SetAt(b, positives.At(b)+dps.At(i).Positive().BucketCounts().At(b))
-			}
+			addExponentialBuckets(negatives, dps.At(i).Negative(), dps.At(i).Scale()-scale)
+			addExponentialBuckets(positives, dps.At(i).Positive(), dps.At(i).Scale()-scale)
 			dps.At(i).Exemplars().MoveAndAppendTo(dp.Exemplars())
 			if dps.At(i).StartTimestamp() < dp.StartTimestamp() {
 				dp.SetStartTimestamp(dps.At(i).StartTimestamp())
 			}
 		}
+		dp.SetScale(scale)
+		setExponentialBuckets(dp.Negative(), negatives)
+		setExponentialBuckets(dp.Positive(), positives)
+	}
+}
+
+// addExponentialBuckets adds the bucket counts to the counts indexed by bucket index,
+// reducing the resolution of the buckets by scaleDelta.
+func addExponentialBuckets(counts map[int32]uint64, buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDelta int32) {
+	for i := 0; i < buckets.BucketCounts().Len(); i++ {
+		if c := buckets.BucketCounts().At(i); c != 0 {
+			// Arithmetic shift rounds towards negative infinity, which keeps negative indexes in the right bucket.
+			counts[(buckets.Offset()+int32(i))>>scaleDelta] += c
+		}
+	}
+}
+
+func setExponentialBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, counts map[int32]uint64) {
+	if len(counts) == 0 {
+		buckets.SetOffset(0)
+		if buckets.BucketCounts().Len() > 0 {
+			buckets.BucketCounts().FromRaw(nil)
+		}
+		return
+	}
+	minIndex, maxIndex := int32(math.MaxInt32), int32(math.MinInt32)
+	for index := range counts {
+		if index < minIndex {
+			minIndex = index
+		}
+		if index > maxIndex {
+			maxIndex = index
+		}
+	}
+	raw := make([]uint64, maxIndex-minIndex+1)
+	for index, c := range counts {
+		raw[index-minIndex] = c
 	}
+	buckets.SetOffset(minIndex)
+	buckets.BucketCounts().FromRaw(raw)
 }
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
+
+import (
+	"math"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var defaultPercentiles = []float64{50, 90, 99}
+
+const defaultPercentileLabel = "percentile"
+
+// histogramPercentilesOp replaces a histogram or exponential histogram metric with a gauge metric
+// holding the configured percentiles of every data point. Data points without any measurement are dropped.
+// Applicable to histogram and exponential histogram metrics only.
+func histogramPercentilesOp(metric pmetric.Metric, op internalOperation) {
+	percentiles := op.configOperation.Percentiles
+	label := op.configOperation.Label
+
+	newMetric := pmetric.NewMetric()
+	newMetric.SetName(metric.Name())
+	newMetric.SetDescription(metric.Description())
+	newMetric.SetUnit(metric.Unit())
+	to := newMetric.SetEmptyGauge().DataPoints()
+
+	switch metric.Type() {
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if dp.Count() == 0 {
+				continue
+			}
+			for _, p := range percentiles {
+				appendPercentileDataPoint(to, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), label, p,
+					histogramQuantile(dp, p/100))
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if dp.Count() == 0 {
+				continue
+			}
+			for _, p := range percentiles {
+				appendPercentileDataPoint(to, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), label, p,
+					exponentialHistogramQuantile(dp, p/100))
+			}
+		}
+	default:
+		return
+	}
+
+	newMetric.MoveTo(metric)
+}
+
+func appendPercentileDataPoint(to pmetric.NumberDataPointSlice, attrs pcommon.Map, start, ts pcommon.Timestamp,
+	label string, percentile, value float64) {
+	dp := to.AppendEmpty()
+	attrs.CopyTo(dp.Attributes())
+	dp.Attributes().PutStr(label, "p"+strconv.FormatFloat(percentile, 'f', -1, 64))
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(value)
+}
+
+// histogramQuantile estimates the q-quantile of the histogram data point assuming
+// the measurements are evenly spread inside each bucket.
+func histogramQuantile(dp pmetric.HistogramDataPoint, q float64) float64 {
+	bounds := dp.ExplicitBounds()
+	counts := dp.BucketCounts()
+	if bounds.Len() == 0 || counts.Len() != bounds.Len()+1 {
+		// Without buckets the mean is the only available estimate.
+		return clampQuantile(dp.HasMin(), dp.Min(), dp.HasMax(), dp.Max(), dp.Sum()/float64(dp.Count()))
+	}
+
+	var total uint64
+	for i := 0; i < counts.Len(); i++ {
+		total += counts.At(i)
+	}
+	rank := q * float64(total)
+
+	var cumulative uint64
+	for i := 0; i < counts.Len(); i++ {
+		c := counts.At(i)
+		if c == 0 || float64(cumulative+c) < rank {
+			cumulative += c
+			continue
+		}
+
+		var lower, upper float64
+		switch {
+		case i == 0:
+			upper = bounds.At(0)
+			lower = math.Min(0, upper)
+			if dp.HasMin() {
+				lower = dp.Min()
+			}
+		case i == bounds.Len():
+			lower = bounds.At(i - 1)
+			upper = lower
+			if dp.HasMax() {
+				upper = dp.Max()
+			}
+		default:
+			lower, upper = bounds.At(i-1), bounds.At(i)
+		}
+		value := lower + (upper-lower)*(rank-float64(cumulative))/float64(c)
+		return clampQuantile(dp.HasMin(), dp.Min(), dp.HasMax(), dp.Max(), value)
+	}
+	return clampQuantile(dp.HasMin(), dp.Min(), dp.HasMax(), dp.Max(), bounds.At(bounds.Len()-1))
+}
+
+// exponentialHistogramQuantile estimates the q-quantile of the exponential histogram data point
+// assuming the measurements are evenly spread inside each bucket.
+func exponentialHistogramQuantile(dp pmetric.ExponentialHistogramDataPoint, q float64) float64 {
+	rank := q * float64(dp.Count())
+	base := math.Ldexp(math.Ln2, -int(dp.Scale()))
+	boundary := func(index int32) float64 {
+		return math.Exp(float64(index) * base)
+	}
+
+	var cumulative uint64
+	// Returns whether the rank falls in the bucket along with the position of the rank inside it.
+	inBucket := func(c uint64) (bool, float64) {
+		if c == 0 || float64(cumulative+c) < rank {
+			cumulative += c
+			return false, 0
+		}
+		return true, (rank - float64(cumulative)) / float64(c)
+	}
+
+	// Negative buckets hold the absolute values, the largest index being the lowest value.
+	negative := dp.Negative()
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		if ok, pos := inBucket(negative.BucketCounts().At(i)); ok {
+			index := negative.Offset() + int32(i)
+			value := -boundary(index+1) + (boundary(index+1)-boundary(index))*pos
+			return clampQuantile(dp.HasMin(), dp.Min(), dp.HasMax(), dp.Max(), value)
+		}
+	}
+
+	if ok, _ := inBucket(dp.ZeroCount()); ok {
+		return clampQuantile(dp.HasMin(), dp.Min(), dp.HasMax(), dp.Max(), 0)
+	}
+
+	positive := dp.Positive()
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		if ok, pos := inBucket(positive.BucketCounts().At(i)); ok {
+			index := positive.Offset() + int32(i)
+			value := boundary(index) + (boundary(index+1)-boundary(index))*pos
+			return clampQuantile(dp.HasMin(), dp.Min(), dp.HasMax(), dp.Max(), value)
+		}
+	}
+
+	if dp.HasMax() {
+		return dp.Max()
+	}
+	return boundary(positive.Offset() + int32(positive.BucketCounts().Len()))
+}
+
+func clampQuantile(hasMin bool, min float64, hasMax bool, max float64, value float64) float64 {
+	if hasMin && value < min {
+		return min
+	}
+	if hasMax && value > max {
+		return max
+	}
+	return value
+}
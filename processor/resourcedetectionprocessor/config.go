@@ -20,6 +20,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
@@ -56,6 +57,16 @@ type DetectorConfig struct {
 
 	// OpenShift contains user-specified configurations for the Openshift detector
 	OpenShiftConfig openshift.Config `mapstructure:"openshift"`
+
+	// K8SNodeConfig contains user-specified configurations for the K8SNode detector
+	K8SNodeConfig k8snode.Config `mapstructure:"k8snode"`
+}
+
+func detectorCreateDefaultConfig() DetectorConfig {
+	return DetectorConfig{
+		SystemConfig:  system.CreateDefaultConfig(),
+		K8SNodeConfig: k8snode.CreateDefaultConfig(),
+	}
 }
 
 func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) internal.DetectorConfig {
@@ -68,6 +79,8 @@ func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) i
 		return d.SystemConfig
 	case openshift.TypeStr:
 		return d.OpenShiftConfig
+	case k8snode.TypeStr:
+		return d.K8SNodeConfig
 	default:
 		return nil
 	}
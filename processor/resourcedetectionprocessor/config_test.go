@@ -26,10 +26,12 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/lambda"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/heroku"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
@@ -40,6 +42,11 @@ func TestLoadConfig(t *testing.T) {
 	cfg := confighttp.NewDefaultHTTPClientSettings()
 	cfg.Timeout = 2 * time.Second
 
+	systemConfig := system.CreateDefaultConfig()
+	systemConfig.HostnameSources = []string{"os"}
+	systemConfig.ResourceAttributes.HostArch.Enabled = true
+	systemConfig.ResourceAttributes.HostID.Enabled = false
+
 	tests := []struct {
 		id           component.ID
 		expected     component.Config
@@ -57,6 +64,8 @@ func TestLoadConfig(t *testing.T) {
 							Insecure: true,
 						},
 					},
+					SystemConfig:  system.CreateDefaultConfig(),
+					K8SNodeConfig: k8snode.CreateDefaultConfig(),
 				},
 				HTTPClientSettings: cfg,
 				Override:           false,
@@ -66,6 +75,7 @@ func TestLoadConfig(t *testing.T) {
 			id: component.NewIDWithName(typeStr, "gcp"),
 			expected: &Config{
 				Detectors:          []string{"env", "gcp"},
+				DetectorConfig:     detectorCreateDefaultConfig(),
 				HTTPClientSettings: cfg,
 				Override:           false,
 			},
@@ -78,6 +88,8 @@ func TestLoadConfig(t *testing.T) {
 					EC2Config: ec2.Config{
 						Tags: []string{"^tag1$", "^tag2$"},
 					},
+					SystemConfig:  system.CreateDefaultConfig(),
+					K8SNodeConfig: k8snode.CreateDefaultConfig(),
 				},
 				HTTPClientSettings: cfg,
 				Override:           false,
@@ -88,9 +100,8 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				Detectors: []string{"env", "system"},
 				DetectorConfig: DetectorConfig{
-					SystemConfig: system.Config{
-						HostnameSources: []string{"os"},
-					},
+					SystemConfig:  systemConfig,
+					K8SNodeConfig: k8snode.CreateDefaultConfig(),
 				},
 				HTTPClientSettings: cfg,
 				Override:           false,
@@ -101,6 +112,7 @@ func TestLoadConfig(t *testing.T) {
 			id: component.NewIDWithName(typeStr, "heroku"),
 			expected: &Config{
 				Detectors:          []string{"env", "heroku"},
+				DetectorConfig:     detectorCreateDefaultConfig(),
 				HTTPClientSettings: cfg,
 				Override:           false,
 			},
@@ -109,6 +121,23 @@ func TestLoadConfig(t *testing.T) {
 			id: component.NewIDWithName(typeStr, "lambda"),
 			expected: &Config{
 				Detectors:          []string{"env", "lambda"},
+				DetectorConfig:     detectorCreateDefaultConfig(),
+				HTTPClientSettings: cfg,
+				Override:           false,
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "k8snode"),
+			expected: &Config{
+				Detectors: []string{"k8snode"},
+				DetectorConfig: DetectorConfig{
+					SystemConfig: system.CreateDefaultConfig(),
+					K8SNodeConfig: k8snode.Config{
+						APIConfig:      k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeNone},
+						NodeFromEnvVar: "MY_NODE_NAME",
+						Labels:         []string{"topology.kubernetes.io/zone", "example.com/rack"},
+					},
+				},
 				HTTPClientSettings: cfg,
 				Override:           false,
 			},
@@ -52,6 +52,11 @@ func TestCreateProcessor(t *testing.T) {
 }
 
 func TestCreateConfigProcessors(t *testing.T) {
+	// Required by the k8snode detector to build its client.
+	t.Setenv("KUBERNETES_SERVICE_HOST", "127.0.0.1")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "6443")
+	t.Setenv("MY_NODE_NAME", "node-1")
+
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 
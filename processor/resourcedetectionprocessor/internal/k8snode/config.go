@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8snode // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+const defaultNodeFromEnvVar = "K8S_NODE_NAME"
+
+var defaultLabels = []string{
+	"topology.kubernetes.io/region",
+	"topology.kubernetes.io/zone",
+	"node.kubernetes.io/instance-type",
+}
+
+// Config defines user-specified configurations unique to the k8snode detector
+type Config struct {
+	k8sconfig.APIConfig `mapstructure:",squash"`
+
+	// NodeFromEnvVar is the name of the environment variable holding the name of
+	// the node the collector runs on. It is usually populated through the
+	// downward API from `spec.nodeName`. (**default**: `K8S_NODE_NAME`)
+	NodeFromEnvVar string `mapstructure:"node_from_env_var"`
+
+	// Labels is the list of node labels added to the resource as
+	// `k8s.node.label.<key>` when present on the node. (**default**: the
+	// `topology.kubernetes.io/region`, `topology.kubernetes.io/zone` and
+	// `node.kubernetes.io/instance-type` well-known labels)
+	Labels []string `mapstructure:"labels"`
+}
+
+// CreateDefaultConfig returns the default configuration of the k8snode detector.
+func CreateDefaultConfig() Config {
+	return Config{
+		APIConfig:      k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+		NodeFromEnvVar: defaultNodeFromEnvVar,
+	}
+}
+
+// Validate config
+func (cfg Config) Validate() error {
+	if err := cfg.APIConfig.Validate(); err != nil {
+		return err
+	}
+	if cfg.NodeFromEnvVar == "" {
+		return fmt.Errorf("node_from_env_var must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8snode // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/collector/semconv/v1.18.0"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "k8snode"
+
+	labelAttributePrefix = "k8s.node.label."
+)
+
+var _ internal.Detector = (*detector)(nil)
+
+// makeClient is overridden in tests to avoid talking to a real API server.
+var makeClient = k8sconfig.MakeClient
+
+type detector struct {
+	client   k8s.Interface
+	nodeName string
+	labels   []string
+	logger   *zap.Logger
+}
+
+// NewDetector returns a detector which reads the UID and selected labels of the
+// Kubernetes node the collector runs on from the Kubernetes API.
+func NewDetector(set processor.CreateSettings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+
+	nodeName := os.Getenv(cfg.NodeFromEnvVar)
+	if nodeName == "" {
+		return nil, fmt.Errorf("node name can't be found: environment variable %q is not set, "+
+			"expose spec.nodeName to the collector through the downward API", cfg.NodeFromEnvVar)
+	}
+
+	client, err := makeClient(cfg.APIConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	labels := cfg.Labels
+	if labels == nil {
+		labels = defaultLabels
+	}
+
+	return &detector{
+		client:   client,
+		nodeName: nodeName,
+		labels:   labels,
+		logger:   set.Logger,
+	}, nil
+}
+
+// Detect fetches the node from the Kubernetes API and returns a resource describing it.
+func (d *detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	res := pcommon.NewResource()
+
+	node, err := d.client.CoreV1().Nodes().Get(ctx, d.nodeName, metav1.GetOptions{})
+	if err != nil {
+		return res, "", fmt.Errorf("failed to get node %q: %w", d.nodeName, err)
+	}
+
+	attrs := res.Attributes()
+	attrs.PutStr(conventions.AttributeK8SNodeName, node.Name)
+	attrs.PutStr(conventions.AttributeK8SNodeUID, string(node.UID))
+	for _, key := range d.labels {
+		if value, ok := node.Labels[key]; ok {
+			attrs.PutStr(labelAttributePrefix+key, value)
+		}
+	}
+
+	return res, conventions.SchemaURL, nil
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8snode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/collector/semconv/v1.18.0"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+func newFakeNode() *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+			UID:  types.UID("4a1b6d4e-22b0-4f8c-9e7a-0d6c2f3a1b2c"),
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone":      "rack-a",
+				"node.kubernetes.io/instance-type": "bare-metal",
+				"kubernetes.io/os":                 "linux",
+			},
+		},
+	}
+}
+
+func TestNewDetector(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	makeClient = func(k8sconfig.APIConfig) (k8s.Interface, error) { return client, nil }
+	t.Cleanup(func() { makeClient = k8sconfig.MakeClient })
+
+	cfg := CreateDefaultConfig()
+
+	_, err := NewDetector(processortest.NewNopCreateSettings(), cfg)
+	assert.ErrorContains(t, err, `environment variable "K8S_NODE_NAME" is not set`)
+
+	t.Setenv("K8S_NODE_NAME", "node-1")
+	d, err := NewDetector(processortest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "node-1", d.(*detector).nodeName)
+	assert.Equal(t, defaultLabels, d.(*detector).labels)
+}
+
+func TestDetect(t *testing.T) {
+	d := &detector{
+		client:   fake.NewSimpleClientset(newFakeNode()),
+		nodeName: "node-1",
+		labels:   defaultLabels,
+		logger:   zap.NewNop(),
+	}
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeK8SNodeName:                  "node-1",
+		conventions.AttributeK8SNodeUID:                   "4a1b6d4e-22b0-4f8c-9e7a-0d6c2f3a1b2c",
+		"k8s.node.label.topology.kubernetes.io/zone":      "rack-a",
+		"k8s.node.label.node.kubernetes.io/instance-type": "bare-metal",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectNodeNotFound(t *testing.T) {
+	d := &detector{
+		client:   fake.NewSimpleClientset(),
+		nodeName: "node-1",
+		logger:   zap.NewNop(),
+	}
+
+	res, _, err := d.Detect(context.Background())
+	assert.ErrorContains(t, err, `failed to get node "node-1"`)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
+
+func TestValidate(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	assert.NoError(t, cfg.Validate())
+
+	cfg.NodeFromEnvVar = ""
+	assert.EqualError(t, cfg.Validate(), "node_from_env_var must not be empty")
+
+	cfg = CreateDefaultConfig()
+	cfg.AuthType = "invalid"
+	assert.EqualError(t, cfg.Validate(), "invalid authType for kubernetes: invalid")
+}
@@ -14,7 +14,11 @@
 
 package system // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system/internal/metadata"
+)
 
 // Config defines user-specified configurations unique to the system detector
 type Config struct {
@@ -22,6 +26,16 @@ type Config struct {
 	// In case of the error in fetching hostname from source,
 	// the next source from the list will be considered.(**default**: `["dns", "os"]`)
 	HostnameSources []string `mapstructure:"hostname_sources"`
+
+	// ResourceAttributes lists the resource attributes the detector sets and whether each of them is enabled.
+	ResourceAttributes metadata.ResourceAttributesSettings `mapstructure:"resource_attributes"`
+}
+
+// CreateDefaultConfig returns the default configuration of the system detector.
+func CreateDefaultConfig() Config {
+	return Config{
+		ResourceAttributes: metadata.DefaultResourceAttributesSettings(),
+	}
 }
 
 // Validate config
@@ -18,6 +18,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"strings"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor"
@@ -26,6 +28,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/system"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system/internal/metadata"
 )
 
 const (
@@ -47,6 +50,7 @@ type Detector struct {
 	provider        system.Provider
 	logger          *zap.Logger
 	hostnameSources []string
+	attributes      metadata.ResourceAttributesSettings
 }
 
 // NewDetector creates a new system metadata detector
@@ -56,40 +60,131 @@ func NewDetector(p processor.CreateSettings, dcfg internal.DetectorConfig) (inte
 		cfg.HostnameSources = []string{"dns", "os"}
 	}
 
-	return &Detector{provider: system.NewProvider(), logger: p.Logger, hostnameSources: cfg.HostnameSources}, nil
+	return &Detector{
+		provider:        system.NewProvider(),
+		logger:          p.Logger,
+		hostnameSources: cfg.HostnameSources,
+		attributes:      cfg.ResourceAttributes,
+	}, nil
 }
 
 // Detect detects system metadata and returns a resource with the available ones
-func (d *Detector) Detect(_ context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
 	var hostname string
 
-	res := pcommon.NewResource()
-	attrs := res.Attributes()
+	rb := metadata.NewResourceBuilder(d.attributes)
 
-	osType, err := d.provider.OSType()
-	if err != nil {
-		return res, "", fmt.Errorf("failed getting OS type: %w", err)
+	if d.attributes.OsType.Enabled {
+		osType, err := d.provider.OSType()
+		if err != nil {
+			return pcommon.NewResource(), "", fmt.Errorf("failed getting OS type: %w", err)
+		}
+		rb.SetOsType(osType)
 	}
 
-	hostID, err := d.provider.HostID()
-	if err != nil {
-		return res, "", fmt.Errorf("failed getting host ID: %w", err)
+	if d.attributes.HostID.Enabled {
+		hostID, err := d.provider.HostID()
+		if err != nil {
+			return pcommon.NewResource(), "", fmt.Errorf("failed getting host ID: %w", err)
+		}
+		rb.SetHostID(hostID)
+	}
+
+	if err = d.detectHostFacts(ctx, rb); err != nil {
+		return pcommon.NewResource(), "", err
 	}
 
 	for _, source := range d.hostnameSources {
 		getHostFromSource := hostnameSourcesMap[source]
 		hostname, err = getHostFromSource(d)
 		if err == nil {
-			attrs.PutStr(conventions.AttributeHostName, hostname)
-			attrs.PutStr(conventions.AttributeOSType, osType)
-			attrs.PutStr(conventions.AttributeHostID, hostID)
-
-			return res, conventions.SchemaURL, nil
+			rb.SetHostName(hostname)
+			return rb.Emit(), conventions.SchemaURL, nil
 		}
 		d.logger.Debug(err.Error())
 	}
 
-	return res, "", errors.New("all hostname sources failed to get hostname")
+	return pcommon.NewResource(), "", errors.New("all hostname sources failed to get hostname")
+}
+
+// detectHostFacts adds the optional architecture, OS, network and CPU attributes
+// that are enabled in the configuration.
+func (d *Detector) detectHostFacts(ctx context.Context, rb *metadata.ResourceBuilder) error {
+	if d.attributes.HostArch.Enabled {
+		hostArch, err := d.provider.HostArch()
+		if err != nil {
+			return fmt.Errorf("failed getting host architecture: %w", err)
+		}
+		rb.SetHostArch(hostArch)
+	}
+
+	if d.attributes.OsDescription.Enabled {
+		osDescription, err := d.provider.OSDescription(ctx)
+		if err != nil {
+			return fmt.Errorf("failed getting OS description: %w", err)
+		}
+		rb.SetOsDescription(osDescription)
+	}
+
+	if d.attributes.OsVersion.Enabled {
+		osVersion, err := d.provider.OSVersion(ctx)
+		if err != nil {
+			return fmt.Errorf("failed getting OS version: %w", err)
+		}
+		rb.SetOsVersion(osVersion)
+	}
+
+	if d.attributes.HostIP.Enabled {
+		ips, err := d.provider.HostIPs()
+		if err != nil {
+			return fmt.Errorf("failed getting host IP addresses: %w", err)
+		}
+		ipStrs := make([]any, 0, len(ips))
+		for _, ip := range ips {
+			ipStrs = append(ipStrs, ip.String())
+		}
+		rb.SetHostIP(ipStrs)
+	}
+
+	if d.attributes.HostMac.Enabled {
+		macs, err := d.provider.HostMACs()
+		if err != nil {
+			return fmt.Errorf("failed getting host MAC addresses: %w", err)
+		}
+		macStrs := make([]any, 0, len(macs))
+		for _, mac := range macs {
+			macStrs = append(macStrs, toIEEERA(mac))
+		}
+		rb.SetHostMac(macStrs)
+	}
+
+	if d.attributes.HostCPUVendorID.Enabled || d.attributes.HostCPUFamily.Enabled ||
+		d.attributes.HostCPUModelID.Enabled || d.attributes.HostCPUModelName.Enabled ||
+		d.attributes.HostCPUStepping.Enabled || d.attributes.HostCPUCacheL2Size.Enabled {
+		cpuInfo, err := d.provider.CPUInfo(ctx)
+		if err != nil {
+			return fmt.Errorf("failed getting host CPU info: %w", err)
+		}
+		// All the CPUs of a host are expected to be the same model, so the first one describes the host.
+		if len(cpuInfo) > 0 {
+			info := cpuInfo[0]
+			rb.SetHostCPUVendorID(info.VendorID)
+			rb.SetHostCPUFamily(info.Family)
+			rb.SetHostCPUModelID(info.Model)
+			rb.SetHostCPUModelName(info.ModelName)
+			rb.SetHostCPUStepping(int64(info.Stepping))
+			// gopsutil reports the cache size in KB.
+			rb.SetHostCPUCacheL2Size(int64(info.CacheSize) * 1024)
+		}
+	}
+
+	return nil
+}
+
+// toIEEERA converts a MAC address to IEEE RA format, as required by the
+// semantic conventions: upper case hexadecimal digits separated by hyphens.
+func toIEEERA(mac net.HardwareAddr) string {
+	return strings.ToUpper(strings.ReplaceAll(mac.String(), ":", "-"))
 }
 
 // getHostname returns OS hostname
@@ -17,8 +17,10 @@ package system
 import (
 	"context"
 	"errors"
+	"net"
 	"testing"
 
+	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -28,6 +30,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/system"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system/internal/metadata"
 )
 
 var _ system.Provider = (*mockMetadata)(nil)
@@ -66,6 +69,52 @@ func (m *mockMetadata) ReverseLookupHost() (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+func (m *mockMetadata) HostArch() (string, error) {
+	args := m.MethodCalled("HostArch")
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) OSDescription(_ context.Context) (string, error) {
+	args := m.MethodCalled("OSDescription")
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) OSVersion(_ context.Context) (string, error) {
+	args := m.MethodCalled("OSVersion")
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockMetadata) HostIPs() ([]net.IP, error) {
+	args := m.MethodCalled("HostIPs")
+	return args.Get(0).([]net.IP), args.Error(1)
+}
+
+func (m *mockMetadata) HostMACs() ([]net.HardwareAddr, error) {
+	args := m.MethodCalled("HostMACs")
+	return args.Get(0).([]net.HardwareAddr), args.Error(1)
+}
+
+func (m *mockMetadata) CPUInfo(_ context.Context) ([]cpu.InfoStat, error) {
+	args := m.MethodCalled("CPUInfo")
+	return args.Get(0).([]cpu.InfoStat), args.Error(1)
+}
+
+func allEnabledConfig() metadata.ResourceAttributesSettings {
+	cfg := metadata.DefaultResourceAttributesSettings()
+	cfg.HostArch.Enabled = true
+	cfg.OsDescription.Enabled = true
+	cfg.OsVersion.Enabled = true
+	cfg.HostIP.Enabled = true
+	cfg.HostMac.Enabled = true
+	cfg.HostCPUVendorID.Enabled = true
+	cfg.HostCPUFamily.Enabled = true
+	cfg.HostCPUModelID.Enabled = true
+	cfg.HostCPUModelName.Enabled = true
+	cfg.HostCPUStepping.Enabled = true
+	cfg.HostCPUCacheL2Size.Enabled = true
+	return cfg
+}
+
 func TestNewDetector(t *testing.T) {
 	tests := []struct {
 		name string
@@ -99,7 +148,7 @@ func TestDetectFQDNAvailable(t *testing.T) {
 	md.On("OSType").Return("darwin", nil)
 	md.On("HostID").Return("2", nil)
 
-	detector := &Detector{provider: md, logger: zap.NewNop(), hostnameSources: []string{"dns"}}
+	detector := &Detector{provider: md, logger: zap.NewNop(), hostnameSources: []string{"dns"}, attributes: metadata.DefaultResourceAttributesSettings()}
 	res, schemaURL, err := detector.Detect(context.Background())
 	require.NoError(t, err)
 	assert.Equal(t, conventions.SchemaURL, schemaURL)
@@ -115,6 +164,75 @@ func TestDetectFQDNAvailable(t *testing.T) {
 
 }
 
+func TestDetectAllEnabled(t *testing.T) {
+	md := &mockMetadata{}
+	md.On("FQDN").Return("fqdn", nil)
+	md.On("OSType").Return("linux", nil)
+	md.On("HostID").Return("2", nil)
+	md.On("HostArch").Return("amd64", nil)
+	md.On("OSDescription").Return("ubuntu 22.04 (linux 5.15.0-60-generic)", nil)
+	md.On("OSVersion").Return("5.15.0-60-generic", nil)
+	md.On("HostIPs").Return([]net.IP{net.ParseIP("192.168.1.140"), net.ParseIP("fe80::abc2:4a28:737a:609e")}, nil)
+	md.On("HostMACs").Return([]net.HardwareAddr{{0x00, 0x00, 0x5e, 0x00, 0x53, 0xaf}}, nil)
+	md.On("CPUInfo").Return([]cpu.InfoStat{{
+		VendorID:  "GenuineIntel",
+		Family:    "6",
+		Model:     "158",
+		ModelName: "Intel(R) Core(TM) i7-7700 CPU @ 3.60GHz",
+		Stepping:  9,
+		CacheSize: 8192,
+	}}, nil)
+
+	detector := &Detector{provider: md, logger: zap.NewNop(), hostnameSources: []string{"dns"}, attributes: allEnabledConfig()}
+	res, schemaURL, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	md.AssertExpectations(t)
+
+	expected := map[string]any{
+		conventions.AttributeHostName: "fqdn",
+		conventions.AttributeOSType:   "linux",
+		conventions.AttributeHostID:   "2",
+		"host.arch":                   "amd64",
+		"os.description":              "ubuntu 22.04 (linux 5.15.0-60-generic)",
+		"os.version":                  "5.15.0-60-generic",
+		"host.ip":                     []any{"192.168.1.140", "fe80::abc2:4a28:737a:609e"},
+		"host.mac":                    []any{"00-00-5E-00-53-AF"},
+		"host.cpu.vendor.id":          "GenuineIntel",
+		"host.cpu.family":             "6",
+		"host.cpu.model.id":           "158",
+		"host.cpu.model.name":         "Intel(R) Core(TM) i7-7700 CPU @ 3.60GHz",
+		"host.cpu.stepping":           int64(9),
+		"host.cpu.cache.l2.size":      int64(8192 * 1024),
+	}
+
+	assert.Equal(t, expected, res.Attributes().AsRaw())
+}
+
+func TestDetectOnlyEnabledAttributes(t *testing.T) {
+	cfg := metadata.DefaultResourceAttributesSettings()
+	cfg.HostID.Enabled = false
+	cfg.OsVersion.Enabled = true
+
+	md := &mockMetadata{}
+	md.On("Hostname").Return("hostname", nil)
+	md.On("OSType").Return("linux", nil)
+	md.On("OSVersion").Return("5.15.0-60-generic", nil)
+
+	detector := &Detector{provider: md, logger: zap.NewNop(), hostnameSources: []string{"os"}, attributes: cfg}
+	res, _, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	md.AssertExpectations(t)
+	md.AssertNotCalled(t, "HostID")
+	md.AssertNotCalled(t, "CPUInfo")
+
+	assert.Equal(t, map[string]any{
+		conventions.AttributeHostName: "hostname",
+		conventions.AttributeOSType:   "linux",
+		"os.version":                  "5.15.0-60-generic",
+	}, res.Attributes().AsRaw())
+}
+
 func TestFallbackHostname(t *testing.T) {
 	mdHostname := &mockMetadata{}
 	mdHostname.On("Hostname").Return("hostname", nil)
@@ -123,7 +241,7 @@ func TestFallbackHostname(t *testing.T) {
 	mdHostname.On("OSType").Return("darwin", nil)
 	mdHostname.On("HostID").Return("3", nil)
 
-	detector := &Detector{provider: mdHostname, logger: zap.NewNop(), hostnameSources: []string{"dns", "os"}}
+	detector := &Detector{provider: mdHostname, logger: zap.NewNop(), hostnameSources: []string{"dns", "os"}, attributes: metadata.DefaultResourceAttributesSettings()}
 	res, schemaURL, err := detector.Detect(context.Background())
 	require.NoError(t, err)
 	assert.Equal(t, conventions.SchemaURL, schemaURL)
@@ -144,7 +262,7 @@ func TestUseHostname(t *testing.T) {
 	mdHostname.On("OSType").Return("darwin", nil)
 	mdHostname.On("HostID").Return("1", nil)
 
-	detector := &Detector{provider: mdHostname, logger: zap.NewNop(), hostnameSources: []string{"os"}}
+	detector := &Detector{provider: mdHostname, logger: zap.NewNop(), hostnameSources: []string{"os"}, attributes: metadata.DefaultResourceAttributesSettings()}
 	res, schemaURL, err := detector.Detect(context.Background())
 	require.NoError(t, err)
 	assert.Equal(t, conventions.SchemaURL, schemaURL)
@@ -167,7 +285,7 @@ func TestDetectError(t *testing.T) {
 	mdFQDN.On("Hostname").Return("", errors.New("err"))
 	mdFQDN.On("HostID").Return("", errors.New("err"))
 
-	detector := &Detector{provider: mdFQDN, logger: zap.NewNop(), hostnameSources: []string{"dns"}}
+	detector := &Detector{provider: mdFQDN, logger: zap.NewNop(), hostnameSources: []string{"dns"}, attributes: metadata.DefaultResourceAttributesSettings()}
 	res, schemaURL, err := detector.Detect(context.Background())
 	assert.Error(t, err)
 	assert.Equal(t, "", schemaURL)
@@ -179,19 +297,36 @@ func TestDetectError(t *testing.T) {
 	mdHostname.On("Hostname").Return("", errors.New("err"))
 	mdHostname.On("HostID").Return("", errors.New("err"))
 
-	detector = &Detector{provider: mdHostname, logger: zap.NewNop(), hostnameSources: []string{"os"}}
+	detector = &Detector{provider: mdHostname, logger: zap.NewNop(), hostnameSources: []string{"os"}, attributes: metadata.DefaultResourceAttributesSettings()}
 	res, schemaURL, err = detector.Detect(context.Background())
 	assert.Error(t, err)
 	assert.Equal(t, "", schemaURL)
 	assert.True(t, internal.IsEmptyResource(res))
 
+	// CPU info fails
+	mdCPUInfo := &mockMetadata{}
+	mdCPUInfo.On("OSType").Return("linux", nil)
+	mdCPUInfo.On("HostID").Return("1", nil)
+	mdCPUInfo.On("HostArch").Return("amd64", nil)
+	mdCPUInfo.On("OSDescription").Return("ubuntu 22.04", nil)
+	mdCPUInfo.On("OSVersion").Return("5.15.0", nil)
+	mdCPUInfo.On("HostIPs").Return([]net.IP{}, nil)
+	mdCPUInfo.On("HostMACs").Return([]net.HardwareAddr{}, nil)
+	mdCPUInfo.On("CPUInfo").Return([]cpu.InfoStat{}, errors.New("err"))
+
+	detector = &Detector{provider: mdCPUInfo, logger: zap.NewNop(), hostnameSources: []string{"os"}, attributes: allEnabledConfig()}
+	res, schemaURL, err = detector.Detect(context.Background())
+	assert.EqualError(t, err, "failed getting host CPU info: err")
+	assert.Equal(t, "", schemaURL)
+	assert.True(t, internal.IsEmptyResource(res))
+
 	// OS type fails
 	mdOSType := &mockMetadata{}
 	mdOSType.On("FQDN").Return("fqdn", nil)
 	mdOSType.On("OSType").Return("", errors.New("err"))
 	mdOSType.On("HostID").Return("", errors.New("err"))
 
-	detector = &Detector{provider: mdOSType, logger: zap.NewNop(), hostnameSources: []string{"dns"}}
+	detector = &Detector{provider: mdOSType, logger: zap.NewNop(), hostnameSources: []string{"dns"}, attributes: metadata.DefaultResourceAttributesSettings()}
 	res, schemaURL, err = detector.Detect(context.Background())
 	assert.Error(t, err)
 	assert.Equal(t, "", schemaURL)
@@ -35,7 +35,8 @@ func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		receiver.WithTraces(createTracesReceiver, component.StabilityLevelAlpha))
+		receiver.WithTraces(createTracesReceiver, component.StabilityLevelAlpha),
+		receiver.WithMetrics(createMetricsReceiver, component.StabilityLevelDevelopment))
 
 }
 
@@ -48,13 +49,30 @@ func createDefaultConfig() component.Config {
 	}
 }
 
-func createTracesReceiver(ctx context.Context, params receiver.CreateSettings, cfg component.Config, consumer consumer.Traces) (r receiver.Traces, err error) {
-	rcfg := cfg.(*Config)
-	r = receivers.GetOrAdd(cfg, func() component.Component {
-		dd, _ := newDataDogReceiver(rcfg, consumer, params)
-		return dd
-	})
+func createTracesReceiver(ctx context.Context, params receiver.CreateSettings, cfg component.Config, consumer consumer.Traces) (receiver.Traces, error) {
+	r, err := getOrAddReceiver(cfg.(*Config), params)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*datadogReceiver).nextTracesConsumer = consumer
 	return r, nil
 }
 
+func createMetricsReceiver(ctx context.Context, params receiver.CreateSettings, cfg component.Config, consumer consumer.Metrics) (receiver.Metrics, error) {
+	r, err := getOrAddReceiver(cfg.(*Config), params)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*datadogReceiver).nextMetricsConsumer = consumer
+	return r, nil
+}
+
+// getOrAddReceiver returns the receiver shared by the traces and metrics pipelines of a configuration,
+// so that both signals are served by the same HTTP server.
+func getOrAddReceiver(rcfg *Config, params receiver.CreateSettings) (*sharedcomponent.SharedComponent, error) {
+	return receivers.GetOrAddWithError(rcfg, func() (component.Component, error) {
+		return newDataDogReceiver(rcfg, params)
+	})
+}
+
 var receivers = sharedcomponent.NewSharedComponents()
@@ -0,0 +1,364 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver"
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DataDog/agent-payload/v5/gogen"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	semconv "go.opentelemetry.io/collector/semconv/v1.16.0"
+)
+
+const (
+	scopeName = "otelcol/datadogreceiver"
+
+	// sketchInterval is the width of the time buckets the agent aggregates distributions into.
+	sketchInterval = 10 * time.Second
+	// sketchScale is the exponential histogram scale sketches are converted to. The agent's
+	// sketches have a relative accuracy of 1/128, which falls between scale 5 and 6.
+	sketchScale = 5
+	// sketchRelativeAccuracy and sketchMinValue mirror the defaults of the agent's sketch implementation.
+	sketchRelativeAccuracy = 1.0 / 128
+	sketchMinValue         = 1e-9
+)
+
+var (
+	sketchGamma    = 1 + 2*sketchRelativeAccuracy
+	sketchLogGamma = math.Log1p(2 * sketchRelativeAccuracy)
+	sketchBias     = 1 - int(math.Floor(math.Log(sketchMinValue)/sketchLogGamma))
+)
+
+// seriesV1 is a single series of the /api/v1/series JSON payload.
+type seriesV1 struct {
+	Metric         string        `json:"metric"`
+	Points         [][2]*float64 `json:"points"`
+	Tags           []string      `json:"tags"`
+	Host           string        `json:"host"`
+	Device         string        `json:"device"`
+	Type           string        `json:"type"`
+	Interval       int64         `json:"interval"`
+	SourceTypeName string        `json:"source_type_name"`
+}
+
+type seriesV1Payload struct {
+	Series []seriesV1 `json:"series"`
+}
+
+// checkRun is a single service check of the /api/v1/check_run JSON payload.
+type checkRun struct {
+	Check     string   `json:"check"`
+	HostName  string   `json:"host_name"`
+	Timestamp int64    `json:"timestamp"`
+	Status    int64    `json:"status"`
+	Message   string   `json:"message"`
+	Tags      []string `json:"tags"`
+}
+
+// metricsBuilder groups translated metrics into one resource per reporting host.
+type metricsBuilder struct {
+	req     *http.Request
+	metrics pmetric.Metrics
+	byHost  map[string]pmetric.MetricSlice
+}
+
+func newMetricsBuilder(req *http.Request) *metricsBuilder {
+	return &metricsBuilder{
+		req:     req,
+		metrics: pmetric.NewMetrics(),
+		byHost:  make(map[string]pmetric.MetricSlice),
+	}
+}
+
+func (b *metricsBuilder) metricSlice(host string) pmetric.MetricSlice {
+	if ms, ok := b.byHost[host]; ok {
+		return ms
+	}
+	rm := b.metrics.ResourceMetrics().AppendEmpty()
+	resource := rm.Resource()
+	addResourceData(b.req, &resource)
+	if host != "" {
+		resource.Attributes().PutStr(semconv.AttributeHostName, host)
+	}
+	rm.SetSchemaUrl(semconv.SchemaURL)
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+	if version := b.req.Header.Get("DD-Agent-Version"); version != "" {
+		sm.Scope().SetVersion(version)
+	}
+	b.byHost[host] = sm.Metrics()
+	return sm.Metrics()
+}
+
+// readBody returns the request body, decompressing it according to its Content-Encoding.
+func readBody(req *http.Request) ([]byte, error) {
+	var reader io.Reader = req.Body
+	switch req.Header.Get("Content-Encoding") {
+	case "gzip":
+		gr, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		reader = gr
+	case "deflate":
+		zr, err := zlib.NewReader(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		reader = zr
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if _, err := io.Copy(buf, reader); err != nil {
+		return nil, err
+	}
+	body := make([]byte, buf.Len())
+	copy(body, buf.Bytes())
+	return body, nil
+}
+
+// translateTags converts Datadog "key:value" tags into attributes.
+// Tags without a value are kept as attributes with an empty string value.
+func translateTags(tags []string, attrs pcommon.Map) {
+	attrs.EnsureCapacity(len(tags))
+	for _, tag := range tags {
+		key, value, _ := strings.Cut(tag, ":")
+		if key = translateDataDogKeyToOtel(key); key != "" {
+			attrs.PutStr(key, value)
+		}
+	}
+}
+
+func secondsToTimestamp(ts int64) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(time.Unix(ts, 0))
+}
+
+// addNumberPoint appends a data point to a metric of the given Datadog type:
+// gauges stay gauges, counts become delta sums and rates are multiplied by their
+// interval to recover the delta sum they were computed from.
+func addNumberPoint(metric pmetric.Metric, metricType string, interval int64, ts int64, value float64) pmetric.NumberDataPoint {
+	var dp pmetric.NumberDataPoint
+	switch {
+	case metricType == "count", metricType == "rate" && interval > 0:
+		if metric.Type() != pmetric.MetricTypeSum {
+			metric.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		}
+		if metricType == "rate" {
+			value *= float64(interval)
+		}
+		dp = metric.Sum().DataPoints().AppendEmpty()
+		if interval > 0 {
+			dp.SetStartTimestamp(secondsToTimestamp(ts - interval))
+		}
+	default:
+		if metric.Type() != pmetric.MetricTypeGauge {
+			metric.SetEmptyGauge()
+		}
+		dp = metric.Gauge().DataPoints().AppendEmpty()
+	}
+	dp.SetTimestamp(secondsToTimestamp(ts))
+	dp.SetDoubleValue(value)
+	return dp
+}
+
+// translateSeriesV1 converts a JSON /api/v1/series payload.
+func translateSeriesV1(req *http.Request, body []byte) (pmetric.Metrics, error) {
+	var payload seriesV1Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return pmetric.Metrics{}, err
+	}
+	b := newMetricsBuilder(req)
+	for _, series := range payload.Series {
+		// the metric is only created with its first point, metrics without data points are invalid
+		var metric pmetric.Metric
+		var hasPoints bool
+		for _, point := range series.Points {
+			if point[0] == nil || point[1] == nil {
+				continue
+			}
+			if !hasPoints {
+				metric = b.metricSlice(series.Host).AppendEmpty()
+				metric.SetName(series.Metric)
+				hasPoints = true
+			}
+			dp := addNumberPoint(metric, series.Type, series.Interval, int64(*point[0]), *point[1])
+			translateTags(series.Tags, dp.Attributes())
+			if series.Device != "" {
+				dp.Attributes().PutStr("device", series.Device)
+			}
+		}
+	}
+	return b.metrics, nil
+}
+
+// translateSeriesV2 converts a protobuf /api/v2/series payload.
+func translateSeriesV2(req *http.Request, body []byte) (pmetric.Metrics, error) {
+	var payload gogen.MetricPayload
+	if err := payload.Unmarshal(body); err != nil {
+		return pmetric.Metrics{}, err
+	}
+	b := newMetricsBuilder(req)
+	for _, series := range payload.GetSeries() {
+		var host string
+		for _, resource := range series.GetResources() {
+			if resource.GetType() == "host" {
+				host = resource.GetName()
+			}
+		}
+		metricType := strings.ToLower(series.GetType().String())
+		// the metric is only created with its first point, metrics without data points are invalid
+		var metric pmetric.Metric
+		var hasPoints bool
+		for _, point := range series.GetPoints() {
+			if !hasPoints {
+				metric = b.metricSlice(host).AppendEmpty()
+				metric.SetName(series.GetMetric())
+				metric.SetUnit(series.GetUnit())
+				hasPoints = true
+			}
+			dp := addNumberPoint(metric, metricType, series.GetInterval(), point.GetTimestamp(), point.GetValue())
+			translateTags(series.GetTags(), dp.Attributes())
+			for _, resource := range series.GetResources() {
+				if resource.GetType() != "host" {
+					dp.Attributes().PutStr(resource.GetType(), resource.GetName())
+				}
+			}
+		}
+	}
+	return b.metrics, nil
+}
+
+// translateSketches converts a protobuf /api/beta/sketches payload into delta exponential histograms.
+func translateSketches(req *http.Request, body []byte) (pmetric.Metrics, error) {
+	var payload gogen.SketchPayload
+	if err := payload.Unmarshal(body); err != nil {
+		return pmetric.Metrics{}, err
+	}
+	b := newMetricsBuilder(req)
+	for _, sketch := range payload.Sketches {
+		// A sketch without points would be converted to an empty metric.
+		if len(sketch.Dogsketches) == 0 {
+			continue
+		}
+		metric := b.metricSlice(sketch.Host).AppendEmpty()
+		metric.SetName(sketch.Metric)
+		histogram := metric.SetEmptyExponentialHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		for _, ds := range sketch.Dogsketches {
+			dp := histogram.DataPoints().AppendEmpty()
+			ts := time.Unix(ds.Ts, 0)
+			dp.SetStartTimestamp(pcommon.NewTimestampFromTime(ts.Add(-sketchInterval)))
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+			dp.SetCount(uint64(ds.Cnt))
+			dp.SetSum(ds.Sum)
+			dp.SetMin(ds.Min)
+			dp.SetMax(ds.Max)
+			translateTags(sketch.Tags, dp.Attributes())
+			if err := sketchToExponentialHistogram(ds.K, ds.N, dp); err != nil {
+				return pmetric.Metrics{}, fmt.Errorf("sketch %q: %w", sketch.Metric, err)
+			}
+		}
+	}
+	return b.metrics, nil
+}
+
+// sketchToExponentialHistogram fills the buckets of dp from the keys and counts of an agent sketch.
+// Each sketch bin is mapped to the exponential bucket containing its representative value.
+func sketchToExponentialHistogram(keys []int32, counts []uint32, dp pmetric.ExponentialHistogramDataPoint) error {
+	if len(keys) != len(counts) {
+		return fmt.Errorf("mismatched number of keys (%d) and counts (%d)", len(keys), len(counts))
+	}
+	dp.SetScale(sketchScale)
+	positive := make(map[int32]uint64)
+	negative := make(map[int32]uint64)
+	for i, key := range keys {
+		switch {
+		case key == 0:
+			dp.SetZeroCount(dp.ZeroCount() + uint64(counts[i]))
+		case key > 0:
+			positive[exponentialIndex(sketchKeyToValue(key))] += uint64(counts[i])
+		default:
+			negative[exponentialIndex(sketchKeyToValue(-key))] += uint64(counts[i])
+		}
+	}
+	fillBuckets(positive, dp.Positive())
+	fillBuckets(negative, dp.Negative())
+	return nil
+}
+
+// sketchKeyToValue returns the midpoint of the range covered by a positive agent sketch key.
+func sketchKeyToValue(key int32) float64 {
+	lower := math.Exp(float64(int(key)-sketchBias) * sketchLogGamma)
+	return lower * (1 + sketchGamma) / 2
+}
+
+// exponentialIndex returns the index of the bucket containing v at sketchScale.
+func exponentialIndex(v float64) int32 {
+	return int32(math.Ceil(math.Log2(v)*math.Exp2(sketchScale))) - 1
+}
+
+func fillBuckets(counts map[int32]uint64, buckets pmetric.ExponentialHistogramDataPointBuckets) {
+	if len(counts) == 0 {
+		return
+	}
+	minIndex, maxIndex := int32(math.MaxInt32), int32(math.MinInt32)
+	for index := range counts {
+		if index < minIndex {
+			minIndex = index
+		}
+		if index > maxIndex {
+			maxIndex = index
+		}
+	}
+	bucketCounts := make([]uint64, maxIndex-minIndex+1)
+	for index, count := range counts {
+		bucketCounts[index-minIndex] = count
+	}
+	buckets.SetOffset(minIndex)
+	buckets.BucketCounts().FromRaw(bucketCounts)
+}
+
+// translateCheckRuns converts a JSON /api/v1/check_run payload into gauges named after each check,
+// whose value is the check status (0: OK, 1: WARNING, 2: CRITICAL, 3: UNKNOWN).
+func translateCheckRuns(req *http.Request, body []byte) (pmetric.Metrics, error) {
+	var checks []checkRun
+	if err := json.Unmarshal(body, &checks); err != nil {
+		return pmetric.Metrics{}, err
+	}
+	b := newMetricsBuilder(req)
+	for _, check := range checks {
+		metric := b.metricSlice(check.HostName).AppendEmpty()
+		metric.SetName(check.Check)
+		dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(secondsToTimestamp(check.Timestamp))
+		dp.SetIntValue(check.Status)
+		translateTags(check.Tags, dp.Attributes())
+		if check.Message != "" {
+			dp.Attributes().PutStr("message", check.Message)
+		}
+	}
+	return b.metrics, nil
+}
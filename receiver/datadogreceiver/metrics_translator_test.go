@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver"
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/DataDog/agent-payload/v5/gogen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newMetricsRequest(t *testing.T, path string, body []byte) *http.Request {
+	req, err := http.NewRequest(http.MethodPost, path, io.NopCloser(bytes.NewReader(body)))
+	require.NoError(t, err)
+	return req
+}
+
+func TestTranslateSeriesV1(t *testing.T) {
+	body := []byte(`{"series":[
+		{"metric":"system.load.1","points":[[1680000000,0.5]],"tags":["env:prod","role"],"host":"host-a","type":"gauge"},
+		{"metric":"requests.count","points":[[1680000010,3]],"host":"host-a","type":"count","interval":10},
+		{"metric":"requests.rate","points":[[1680000010,2]],"host":"host-b","type":"rate","interval":10}
+	]}`)
+	metrics, err := translateSeriesV1(newMetricsRequest(t, "/api/v1/series", body), body)
+	require.NoError(t, err)
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+
+	rm := metrics.ResourceMetrics().At(0)
+	host, ok := rm.Resource().Attributes().Get("host.name")
+	require.True(t, ok)
+	assert.Equal(t, "host-a", host.Str())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+
+	gauge := ms.At(0)
+	assert.Equal(t, "system.load.1", gauge.Name())
+	require.Equal(t, pmetric.MetricTypeGauge, gauge.Type())
+	dp := gauge.Gauge().DataPoints().At(0)
+	assert.Equal(t, 0.5, dp.DoubleValue())
+	assert.Equal(t, int64(1680000000), dp.Timestamp().AsTime().Unix())
+	assert.Equal(t, map[string]any{"deployment.environment": "prod", "role": ""}, dp.Attributes().AsRaw())
+
+	count := ms.At(1)
+	require.Equal(t, pmetric.MetricTypeSum, count.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, count.Sum().AggregationTemporality())
+	assert.Equal(t, 3.0, count.Sum().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, int64(1680000000), count.Sum().DataPoints().At(0).StartTimestamp().AsTime().Unix())
+
+	rate := metrics.ResourceMetrics().At(1).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, pmetric.MetricTypeSum, rate.Type())
+	assert.Equal(t, 20.0, rate.Sum().DataPoints().At(0).DoubleValue())
+}
+
+func TestTranslateSeriesWithoutPoints(t *testing.T) {
+	body := []byte(`{"series":[
+		{"metric":"no.points","points":[],"host":"host-a","type":"gauge"},
+		{"metric":"nil.points","points":[[null,1],[1680000000,null]],"host":"host-a","type":"count"},
+		{"metric":"system.load.1","points":[[null,1],[1680000000,0.5]],"host":"host-b","type":"gauge"}
+	]}`)
+	metrics, err := translateSeriesV1(newMetricsRequest(t, "/api/v1/series", body), body)
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, ms.Len())
+	assert.Equal(t, "system.load.1", ms.At(0).Name())
+	assert.Equal(t, 1, ms.At(0).Gauge().DataPoints().Len())
+
+	payload := gogen.MetricPayload{
+		Series: []*gogen.MetricPayload_MetricSeries{
+			{
+				Resources: []*gogen.MetricPayload_Resource{{Type: "host", Name: "host-a"}},
+				Metric:    "no.points",
+				Type:      gogen.MetricPayload_GAUGE,
+			},
+		},
+	}
+	body, err = payload.Marshal()
+	require.NoError(t, err)
+	metrics, err = translateSeriesV2(newMetricsRequest(t, "/api/v2/series", body), body)
+	require.NoError(t, err)
+	assert.Equal(t, 0, metrics.MetricCount())
+}
+
+func TestTranslateSeriesV2(t *testing.T) {
+	payload := gogen.MetricPayload{
+		Series: []*gogen.MetricPayload_MetricSeries{
+			{
+				Resources: []*gogen.MetricPayload_Resource{{Type: "host", Name: "host-a"}, {Type: "device", Name: "sda"}},
+				Metric:    "system.io.r_s",
+				Tags:      []string{"version:1.2"},
+				Points:    []*gogen.MetricPayload_MetricPoint{{Value: 1.5, Timestamp: 1680000000}},
+				Type:      gogen.MetricPayload_GAUGE,
+				Unit:      "request",
+			},
+			{
+				Resources: []*gogen.MetricPayload_Resource{{Type: "host", Name: "host-a"}},
+				Metric:    "requests",
+				Points:    []*gogen.MetricPayload_MetricPoint{{Value: 4, Timestamp: 1680000010}},
+				Type:      gogen.MetricPayload_COUNT,
+				Interval:  10,
+			},
+		},
+	}
+	body, err := payload.Marshal()
+	require.NoError(t, err)
+	metrics, err := translateSeriesV2(newMetricsRequest(t, "/api/v2/series", body), body)
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+
+	gauge := ms.At(0)
+	assert.Equal(t, "request", gauge.Unit())
+	require.Equal(t, pmetric.MetricTypeGauge, gauge.Type())
+	assert.Equal(t, map[string]any{"service.version": "1.2", "device": "sda"}, gauge.Gauge().DataPoints().At(0).Attributes().AsRaw())
+
+	sum := ms.At(1)
+	require.Equal(t, pmetric.MetricTypeSum, sum.Type())
+	assert.Equal(t, 4.0, sum.Sum().DataPoints().At(0).DoubleValue())
+}
+
+func TestTranslateSketches(t *testing.T) {
+	// Keys for 1, 2 and -1 in the agent's sketch key space.
+	oneKey := int32(sketchBias)
+	twoKey := oneKey + int32(math.Round(math.Log(2)/sketchLogGamma))
+	payload := gogen.SketchPayload{
+		Sketches: []gogen.SketchPayload_Sketch{
+			{
+				Metric: "latency",
+				Host:   "host-a",
+				Tags:   []string{"env:prod"},
+				Dogsketches: []gogen.SketchPayload_Sketch_Dogsketch{
+					{Ts: 1680000010, Cnt: 6, Min: -1, Max: 2, Sum: 4, K: []int32{-oneKey, 0, oneKey, twoKey}, N: []uint32{1, 1, 2, 2}},
+				},
+			},
+		},
+	}
+	body, err := payload.Marshal()
+	require.NoError(t, err)
+	metrics, err := translateSketches(newMetricsRequest(t, "/api/beta/sketches", body), body)
+	require.NoError(t, err)
+
+	metric := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, metric.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, metric.ExponentialHistogram().AggregationTemporality())
+	dp := metric.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, 4.0, dp.Sum())
+	assert.Equal(t, -1.0, dp.Min())
+	assert.Equal(t, 2.0, dp.Max())
+	assert.Equal(t, int32(sketchScale), dp.Scale())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	assert.Equal(t, int64(1680000000), dp.StartTimestamp().AsTime().Unix())
+
+	// Values of 1 and 2 fall into the buckets right above the powers of two.
+	assert.Equal(t, int32(0), dp.Positive().Offset())
+	positive := dp.Positive().BucketCounts().AsRaw()
+	require.Len(t, positive, 33)
+	assert.Equal(t, uint64(2), positive[0])
+	assert.Equal(t, uint64(2), positive[32])
+	assert.Equal(t, int32(0), dp.Negative().Offset())
+	assert.Equal(t, []uint64{1}, dp.Negative().BucketCounts().AsRaw())
+}
+
+func TestTranslateSketchesWithoutPoints(t *testing.T) {
+	payload := gogen.SketchPayload{
+		Sketches: []gogen.SketchPayload_Sketch{
+			{Metric: "no.points", Host: "host-a"},
+			{
+				Metric:      "latency",
+				Host:        "host-b",
+				Dogsketches: []gogen.SketchPayload_Sketch_Dogsketch{{Ts: 1680000010, Cnt: 1, Sum: 1, K: []int32{0}, N: []uint32{1}}},
+			},
+		},
+	}
+	body, err := payload.Marshal()
+	require.NoError(t, err)
+	metrics, err := translateSketches(newMetricsRequest(t, "/api/beta/sketches", body), body)
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, ms.Len())
+	assert.Equal(t, "latency", ms.At(0).Name())
+}
+
+func TestTranslateSketchesMismatchedBins(t *testing.T) {
+	payload := gogen.SketchPayload{
+		Sketches: []gogen.SketchPayload_Sketch{
+			{Metric: "latency", Dogsketches: []gogen.SketchPayload_Sketch_Dogsketch{{K: []int32{1, 2}, N: []uint32{1}}}},
+		},
+	}
+	body, err := payload.Marshal()
+	require.NoError(t, err)
+	_, err = translateSketches(newMetricsRequest(t, "/api/beta/sketches", body), body)
+	assert.Error(t, err)
+}
+
+func TestTranslateCheckRuns(t *testing.T) {
+	body := []byte(`[{"check":"datadog.agent.up","host_name":"host-a","timestamp":1680000000,"status":2,"message":"down","tags":["env:prod"]}]`)
+	metrics, err := translateCheckRuns(newMetricsRequest(t, "/api/v1/check_run", body), body)
+	require.NoError(t, err)
+	metric := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "datadog.agent.up", metric.Name())
+	require.Equal(t, pmetric.MetricTypeGauge, metric.Type())
+	dp := metric.Gauge().DataPoints().At(0)
+	assert.Equal(t, int64(2), dp.IntValue())
+	assert.Equal(t, map[string]any{"deployment.environment": "prod", "message": "down"}, dp.Attributes().AsRaw())
+}
+
+func TestReadBodyDeflate(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, err := zw.Write([]byte(`{"series":[]}`))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	req := newMetricsRequest(t, "/api/v1/series", buf.Bytes())
+	req.Header.Set("Content-Encoding", "deflate")
+	body, err := readBody(req)
+	require.NoError(t, err)
+	assert.Equal(t, `{"series":[]}`, string(body))
+}
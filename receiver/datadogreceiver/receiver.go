@@ -23,30 +23,28 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
 )
 
 type datadogReceiver struct {
-	config       *Config
-	params       receiver.CreateSettings
-	nextConsumer consumer.Traces
-	server       *http.Server
-	tReceiver    *obsreport.Receiver
+	config              *Config
+	params              receiver.CreateSettings
+	nextTracesConsumer  consumer.Traces
+	nextMetricsConsumer consumer.Metrics
+	server              *http.Server
+	tReceiver           *obsreport.Receiver
 }
 
-func newDataDogReceiver(config *Config, nextConsumer consumer.Traces, params receiver.CreateSettings) (receiver.Traces, error) {
-	if nextConsumer == nil {
-		return nil, component.ErrNilNextConsumer
-	}
-
+func newDataDogReceiver(config *Config, params receiver.CreateSettings) (*datadogReceiver, error) {
 	instance, err := obsreport.NewReceiver(obsreport.ReceiverSettings{LongLivedCtx: false, ReceiverID: params.ID, Transport: "http", ReceiverCreateSettings: params})
 	if err != nil {
 		return nil, err
 	}
 	return &datadogReceiver{
-		params:       params,
-		config:       config,
-		nextConsumer: nextConsumer,
+		params: params,
+		config: config,
 		server: &http.Server{
 			ReadTimeout: config.ReadTimeout,
 			Addr:        config.HTTPServerSettings.Endpoint,
@@ -58,10 +56,19 @@ func newDataDogReceiver(config *Config, nextConsumer consumer.Traces, params rec
 func (ddr *datadogReceiver) Start(_ context.Context, host component.Host) error {
 	go func() {
 		ddmux := http.NewServeMux()
-		ddmux.HandleFunc("/v0.3/traces", ddr.handleTraces)
-		ddmux.HandleFunc("/v0.4/traces", ddr.handleTraces)
-		ddmux.HandleFunc("/v0.5/traces", ddr.handleTraces)
-		ddmux.HandleFunc("/v0.7/traces", ddr.handleTraces)
+		if ddr.nextTracesConsumer != nil {
+			ddmux.HandleFunc("/v0.3/traces", ddr.handleTraces)
+			ddmux.HandleFunc("/v0.4/traces", ddr.handleTraces)
+			ddmux.HandleFunc("/v0.5/traces", ddr.handleTraces)
+			ddmux.HandleFunc("/v0.7/traces", ddr.handleTraces)
+		}
+		if ddr.nextMetricsConsumer != nil {
+			ddmux.HandleFunc("/api/v1/series", ddr.handleV1Series)
+			ddmux.HandleFunc("/api/v2/series", ddr.handleV2Series)
+			ddmux.HandleFunc("/api/beta/sketches", ddr.handleSketches)
+			ddmux.HandleFunc("/api/v1/check_run", ddr.handleCheckRun)
+			ddmux.HandleFunc("/api/v1/validate", ddr.handleValidate)
+		}
 		ddr.server.Handler = ddmux
 		if err := ddr.server.ListenAndServe(); err != http.ErrServerClosed {
 			host.ReportFatalError(fmt.Errorf("error starting datadog receiver: %w", err))
@@ -91,7 +98,7 @@ func (ddr *datadogReceiver) handleTraces(w http.ResponseWriter, req *http.Reques
 
 	otelTraces := toTraces(ddTraces, req)
 	spanCount = otelTraces.SpanCount()
-	err = ddr.nextConsumer.ConsumeTraces(obsCtx, otelTraces)
+	err = ddr.nextTracesConsumer.ConsumeTraces(obsCtx, otelTraces)
 	if err != nil {
 		http.Error(w, "Trace consumer errored out", http.StatusInternalServerError)
 		ddr.params.Logger.Error("Trace consumer errored out")
@@ -99,3 +106,60 @@ func (ddr *datadogReceiver) handleTraces(w http.ResponseWriter, req *http.Reques
 		_, _ = w.Write([]byte("OK"))
 	}
 }
+
+func (ddr *datadogReceiver) handleV1Series(w http.ResponseWriter, req *http.Request) {
+	ddr.handleMetrics(w, req, translateSeriesV1)
+}
+
+func (ddr *datadogReceiver) handleV2Series(w http.ResponseWriter, req *http.Request) {
+	ddr.handleMetrics(w, req, translateSeriesV2)
+}
+
+func (ddr *datadogReceiver) handleSketches(w http.ResponseWriter, req *http.Request) {
+	ddr.handleMetrics(w, req, translateSketches)
+}
+
+func (ddr *datadogReceiver) handleCheckRun(w http.ResponseWriter, req *http.Request) {
+	ddr.handleMetrics(w, req, translateCheckRuns)
+}
+
+// handleValidate answers the API key validation the agent performs on startup.
+func (ddr *datadogReceiver) handleValidate(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"valid":true}`))
+}
+
+func (ddr *datadogReceiver) handleMetrics(w http.ResponseWriter, req *http.Request, translate func(*http.Request, []byte) (pmetric.Metrics, error)) {
+	obsCtx := ddr.tReceiver.StartMetricsOp(req.Context())
+	var err error
+	var dataPointCount int
+	defer func(dataPointCount *int) {
+		ddr.tReceiver.EndMetricsOp(obsCtx, "datadog", *dataPointCount, err)
+	}(&dataPointCount)
+
+	var body []byte
+	body, err = readBody(req)
+	if err != nil {
+		http.Error(w, "Unable to read request body", http.StatusBadRequest)
+		ddr.params.Logger.Error("Unable to read request body", zap.Error(err))
+		return
+	}
+	var otelMetrics pmetric.Metrics
+	otelMetrics, err = translate(req, body)
+	if err != nil {
+		http.Error(w, "Unable to unmarshal reqs", http.StatusBadRequest)
+		ddr.params.Logger.Error("Unable to unmarshal reqs", zap.Error(err))
+		return
+	}
+
+	dataPointCount = otelMetrics.DataPointCount()
+	err = ddr.nextMetricsConsumer.ConsumeMetrics(obsCtx, otelMetrics)
+	if err != nil {
+		http.Error(w, "Metrics consumer errored out", http.StatusInternalServerError)
+		ddr.params.Logger.Error("Metrics consumer errored out", zap.Error(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = w.Write([]byte(`{"status":"ok"}`))
+}
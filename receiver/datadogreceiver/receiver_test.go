@@ -15,13 +15,20 @@
 package datadogreceiver
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 )
 
 func TestDatadogReceiver_Lifecycle(t *testing.T) {
@@ -36,3 +43,38 @@ func TestDatadogReceiver_Lifecycle(t *testing.T) {
 	err = ddr.Shutdown(context.Background())
 	assert.NoError(t, err, "Server should stop")
 }
+
+func TestDatadogReceiver_Metrics(t *testing.T) {
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	sink := new(consumertest.MetricsSink)
+	ddr, err := factory.CreateMetricsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, ddr.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, ddr.Shutdown(context.Background()))
+	}()
+
+	body := []byte(`{"series":[{"metric":"system.load.1","points":[[1680000000,0.5]],"host":"host-a","type":"gauge"}]}`)
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = http.Post(fmt.Sprintf("http://%s/api/v1/series", endpoint), "application/json", bytes.NewReader(body))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 1, sink.DataPointCount())
+
+	resp, err = http.Post(fmt.Sprintf("http://%s/api/v1/series", endpoint), "application/json", bytes.NewReader([]byte("{")))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Post(fmt.Sprintf("http://%s/v0.4/traces", endpoint), "application/json", bytes.NewReader([]byte("[]")))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "traces endpoints are only served for traces pipelines")
+}
@@ -16,14 +16,37 @@ package filereceiver // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"errors"
+	"fmt"
+	"path/filepath"
 
 	"go.opentelemetry.io/collector/component"
 )
 
+const (
+	// the format of encoded telemetry data
+	formatTypeJSON  = "json"
+	formatTypeProto = "proto"
+
+	// the type of compression codec
+	compressionZSTD = "zstd"
+)
+
 // Config defines the configuration for the file receiver.
 type Config struct {
 	// Path of the file to read from. Path is relative to current directory.
+	// Path can be a glob pattern, in which case the matching files are read in lexical order.
 	Path string `mapstructure:"path"`
+	// FormatType is the data format of the encoded telemetry data, matching the
+	// format setting of the file exporter.
+	// Options:
+	// - json[default]: OTLP json bytes, one message per line unless compressed.
+	// - proto: OTLP binary protobuf bytes, each message prefixed with its length.
+	FormatType string `mapstructure:"format"`
+	// Compression is the codec the telemetry data was compressed with, matching the
+	// compression setting of the file exporter. Compressed messages are always
+	// prefixed with their length.
+	// Supported compression algorithms: `zstd`
+	Compression string `mapstructure:"compression"`
 	// Throttle determines how fast telemetry is replayed. A value of zero means
 	// that it will be replayed as fast as the system will allow. A value of 1 means
 	// that it will be replayed at the same rate as the data came in, as indicated
@@ -35,7 +58,8 @@ type Config struct {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		Throttle: 1,
+		FormatType: formatTypeJSON,
+		Throttle:   1,
 	}
 }
 
@@ -43,6 +67,15 @@ func (c Config) Validate() error {
 	if c.Path == "" {
 		return errors.New("path cannot be empty")
 	}
+	if _, err := filepath.Match(c.Path, ""); err != nil {
+		return fmt.Errorf("path is not a valid glob pattern: %w", err)
+	}
+	if c.FormatType != "" && c.FormatType != formatTypeJSON && c.FormatType != formatTypeProto {
+		return errors.New("format type is not supported")
+	}
+	if c.Compression != "" && c.Compression != compressionZSTD {
+		return errors.New("compression is not supported")
+	}
 	if c.Throttle < 0 {
 		return errors.New("throttle cannot be negative")
 	}
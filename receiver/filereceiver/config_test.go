@@ -49,12 +49,30 @@ func TestLoadConfig(t *testing.T) {
 		}, {
 			id: component.NewIDWithName(typeStr, "1"),
 			expected: &Config{
-				Path:     "./filename.json",
-				Throttle: 1,
+				Path:       "./filename.json",
+				FormatType: formatTypeJSON,
+				Throttle:   1,
 			},
 		}, {
 			id:           component.NewIDWithName(typeStr, "2"),
 			errorMessage: "throttle cannot be negative",
+		}, {
+			id: component.NewIDWithName(typeStr, "3"),
+			expected: &Config{
+				Path:        "./captures/*.pb.zst",
+				FormatType:  formatTypeProto,
+				Compression: compressionZSTD,
+				Throttle:    0,
+			},
+		}, {
+			id:           component.NewIDWithName(typeStr, "4"),
+			errorMessage: "format type is not supported",
+		}, {
+			id:           component.NewIDWithName(typeStr, "5"),
+			errorMessage: "compression is not supported",
+		}, {
+			id:           component.NewIDWithName(typeStr, "6"),
+			errorMessage: "path is not a valid glob pattern: syntax error in pattern",
 		},
 	}
 
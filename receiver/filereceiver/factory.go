@@ -32,6 +32,8 @@ func NewFactory() receiver.Factory {
 		typeStr,
 		createDefaultConfig,
 		receiver.WithMetrics(createMetricsReceiver, stability),
+		receiver.WithTraces(createTracesReceiver, stability),
+		receiver.WithLogs(createLogsReceiver, stability),
 	)
 }
 
@@ -42,10 +44,36 @@ func createMetricsReceiver(
 	consumer consumer.Metrics,
 ) (receiver.Metrics, error) {
 	cfg := cc.(*Config)
+	return newFileReceiver(cfg, settings, newMetricsReplayer(consumer, cfg.FormatType)), nil
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	settings receiver.CreateSettings,
+	cc component.Config,
+	consumer consumer.Traces,
+) (receiver.Traces, error) {
+	cfg := cc.(*Config)
+	return newFileReceiver(cfg, settings, newTracesReplayer(consumer, cfg.FormatType)), nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	settings receiver.CreateSettings,
+	cc component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	cfg := cc.(*Config)
+	return newFileReceiver(cfg, settings, newLogsReplayer(consumer, cfg.FormatType)), nil
+}
+
+func newFileReceiver(cfg *Config, settings receiver.CreateSettings, replayer replayer) *fileReceiver {
 	return &fileReceiver{
-		consumer: consumer,
-		path:     cfg.Path,
-		logger:   settings.Logger,
-		throttle: cfg.Throttle,
-	}, nil
+		replayer:    replayer,
+		path:        cfg.Path,
+		format:      cfg.FormatType,
+		compression: cfg.Compression,
+		logger:      settings.Logger,
+		throttle:    cfg.Throttle,
+	}
 }
@@ -33,3 +33,21 @@ func TestNewFactory(t *testing.T) {
 	)
 	require.NoError(t, err)
 }
+
+func TestNewFactory_TracesAndLogs(t *testing.T) {
+	f := NewFactory()
+	_, err := f.CreateTracesReceiver(
+		context.Background(),
+		receivertest.NewNopCreateSettings(),
+		f.CreateDefaultConfig(),
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	_, err = f.CreateLogsReceiver(
+		context.Background(),
+		receivertest.NewNopCreateSettings(),
+		f.CreateDefaultConfig(),
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+}
@@ -17,14 +17,17 @@ package filereceiver // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"bufio"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
-	"os"
 
+	"github.com/klauspost/compress/zstd"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 // stringReader is the only function we use from *bufio.Reader. We define it
@@ -33,24 +36,99 @@ type stringReader interface {
 	ReadString(delim byte) (string, error)
 }
 
+// messageReader reads the encoded messages written by the file exporter one at a time.
+type messageReader interface {
+	readMessage() ([]byte, error)
+}
+
+// lineReader reads messages written one per line.
+type lineReader struct {
+	stringReader stringReader
+}
+
+func (lr lineReader) readMessage() ([]byte, error) {
+	line, err := lr.stringReader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read line from input file: %w", err)
+	}
+	return []byte(line), nil
+}
+
+// lengthPrefixedReader reads messages each preceded by its length as a 4 bytes big endian unsigned integer.
+type lengthPrefixedReader struct {
+	reader io.Reader
+}
+
+func (lr lengthPrefixedReader) readMessage() ([]byte, error) {
+	var size uint32
+	if err := binary.Read(lr.reader, binary.BigEndian, &size); err != nil {
+		return nil, fmt.Errorf("failed to read message length from input file: %w", err)
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(lr.reader, buf); err != nil {
+		return nil, fmt.Errorf("failed to read message from input file: %w", err)
+	}
+	return buf, nil
+}
+
+// zstdReader decompresses the messages read by the wrapped reader.
+type zstdReader struct {
+	messageReader
+	decoder *zstd.Decoder
+}
+
+func (zr zstdReader) readMessage() ([]byte, error) {
+	buf, err := zr.messageReader.readMessage()
+	if err != nil {
+		return nil, err
+	}
+	buf, err = zr.decoder.DecodeAll(buf, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress message: %w", err)
+	}
+	return buf, nil
+}
+
+// Close releases the goroutines and buffers of the decoder.
+func (zr zstdReader) Close() error {
+	zr.decoder.Close()
+	return nil
+}
+
+// newMessageReader returns the reader matching the way the file exporter writes messages
+// with the given format and compression. Readers implementing io.Closer must be closed once the
+// file has been read.
+func newMessageReader(r io.Reader, compression string, format string) (messageReader, error) {
+	if compression == "" {
+		if format == formatTypeProto {
+			return lengthPrefixedReader{reader: r}, nil
+		}
+		return lineReader{stringReader: bufio.NewReader(r)}, nil
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	return zstdReader{messageReader: lengthPrefixedReader{reader: bufio.NewReader(r)}, decoder: decoder}, nil
+}
+
 // fileReader
 type fileReader struct {
-	stringReader stringReader
-	unm          pmetric.Unmarshaler
-	consumer     consumer.Metrics
-	timer        *replayTimer
+	reader   messageReader
+	replayer replayer
+	timer    *replayTimer
 }
 
-func newFileReader(consumer consumer.Metrics, file *os.File, timer *replayTimer) fileReader {
+func newFileReader(reader messageReader, replayer replayer, timer *replayTimer) fileReader {
 	return fileReader{
-		consumer:     consumer,
-		stringReader: bufio.NewReader(file),
-		unm:          &pmetric.JSONUnmarshaler{},
-		timer:        timer,
+		reader:   reader,
+		replayer: replayer,
+		timer:    timer,
 	}
 }
 
-// readAll calls readline for each line in the file until all lines have been
+// readAll calls readMessage for each message in the file until all messages have been
 // read or the context is cancelled.
 func (fr fileReader) readAll(ctx context.Context) error {
 	for {
@@ -58,7 +136,7 @@ func (fr fileReader) readAll(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		default:
-			err := fr.readLine(ctx)
+			err := fr.readMessage(ctx)
 			if err != nil {
 				if errors.Is(err, io.EOF) {
 					return nil
@@ -69,24 +147,135 @@ func (fr fileReader) readAll(ctx context.Context) error {
 	}
 }
 
-// readLine reads the next line in the file, converting it into metrics and
-// passing it to the the consumer member.
-func (fr fileReader) readLine(ctx context.Context) error {
-	line, err := fr.stringReader.ReadString('\n')
+// readMessage reads the next message in the file, converting it into telemetry and
+// passing it to the replayer.
+func (fr fileReader) readMessage(ctx context.Context) error {
+	buf, err := fr.reader.readMessage()
 	if err != nil {
-		return fmt.Errorf("failed to read line from input file: %w", err)
+		return err
 	}
-	metrics, err := fr.unm.UnmarshalMetrics([]byte(line))
+	return fr.replayer.replay(ctx, buf, fr.timer)
+}
+
+// replayer unmarshals a message and passes the telemetry it holds to the next consumer,
+// once the replay timer allows it.
+type replayer interface {
+	replay(ctx context.Context, buf []byte, timer *replayTimer) error
+}
+
+type metricsReplayer struct {
+	unm      pmetric.Unmarshaler
+	consumer consumer.Metrics
+}
+
+func (r metricsReplayer) replay(ctx context.Context, buf []byte, timer *replayTimer) error {
+	metrics, err := r.unm.UnmarshalMetrics(buf)
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal metrics: %w", err)
 	}
-	err = fr.timer.wait(ctx, getFirstTimestamp(metrics))
+	err = timer.wait(ctx, getFirstTimestamp(metrics))
+	if err != nil {
+		return fmt.Errorf("readMessage interrupted while waiting for timer: %w", err)
+	}
+	return r.consumer.ConsumeMetrics(ctx, metrics)
+}
+
+type tracesReplayer struct {
+	unm      ptrace.Unmarshaler
+	consumer consumer.Traces
+}
+
+func (r tracesReplayer) replay(ctx context.Context, buf []byte, timer *replayTimer) error {
+	traces, err := r.unm.UnmarshalTraces(buf)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal traces: %w", err)
+	}
+	err = timer.wait(ctx, getFirstSpanTimestamp(traces))
+	if err != nil {
+		return fmt.Errorf("readMessage interrupted while waiting for timer: %w", err)
+	}
+	return r.consumer.ConsumeTraces(ctx, traces)
+}
+
+type logsReplayer struct {
+	unm      plog.Unmarshaler
+	consumer consumer.Logs
+}
+
+func (r logsReplayer) replay(ctx context.Context, buf []byte, timer *replayTimer) error {
+	logs, err := r.unm.UnmarshalLogs(buf)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal logs: %w", err)
+	}
+	err = timer.wait(ctx, getFirstLogTimestamp(logs))
 	if err != nil {
-		return fmt.Errorf("readLine interrupted while waiting for timer: %w", err)
+		return fmt.Errorf("readMessage interrupted while waiting for timer: %w", err)
+	}
+	return r.consumer.ConsumeLogs(ctx, logs)
+}
+
+func newMetricsReplayer(consumer consumer.Metrics, format string) replayer {
+	var unm pmetric.Unmarshaler = &pmetric.JSONUnmarshaler{}
+	if format == formatTypeProto {
+		unm = &pmetric.ProtoUnmarshaler{}
+	}
+	return metricsReplayer{unm: unm, consumer: consumer}
+}
+
+func newTracesReplayer(consumer consumer.Traces, format string) replayer {
+	var unm ptrace.Unmarshaler = &ptrace.JSONUnmarshaler{}
+	if format == formatTypeProto {
+		unm = &ptrace.ProtoUnmarshaler{}
+	}
+	return tracesReplayer{unm: unm, consumer: consumer}
+}
+
+func newLogsReplayer(consumer consumer.Logs, format string) replayer {
+	var unm plog.Unmarshaler = &plog.JSONUnmarshaler{}
+	if format == formatTypeProto {
+		unm = &plog.ProtoUnmarshaler{}
+	}
+	return logsReplayer{unm: unm, consumer: consumer}
+}
+
+// getFirstSpanTimestamp returns the end timestamp of the first span, as spans are
+// exported once they end.
+func getFirstSpanTimestamp(traces ptrace.Traces) pcommon.Timestamp {
+	resourceSpans := traces.ResourceSpans()
+	if resourceSpans.Len() == 0 {
+		return 0
+	}
+	scopeSpans := resourceSpans.At(0).ScopeSpans()
+	if scopeSpans.Len() == 0 {
+		return 0
 	}
-	return fr.consumer.ConsumeMetrics(ctx, metrics)
+	spans := scopeSpans.At(0).Spans()
+	if spans.Len() == 0 {
+		return 0
+	}
+	return spans.At(0).EndTimestamp()
 }
 
+// getFirstLogTimestamp returns the timestamp of the first log record, falling back to
+// its observed timestamp when the record has no timestamp.
+func getFirstLogTimestamp(logs plog.Logs) pcommon.Timestamp {
+	resourceLogs := logs.ResourceLogs()
+	if resourceLogs.Len() == 0 {
+		return 0
+	}
+	scopeLogs := resourceLogs.At(0).ScopeLogs()
+	if scopeLogs.Len() == 0 {
+		return 0
+	}
+	records := scopeLogs.At(0).LogRecords()
+	if records.Len() == 0 {
+		return 0
+	}
+	if ts := records.At(0).Timestamp(); ts != 0 {
+		return ts
+	}
+	return records.At(0).ObservedTimestamp()
+}
 func getFirstTimestamp(metrics pmetric.Metrics) pcommon.Timestamp {
 	resourceMetrics := metrics.ResourceMetrics()
 	if resourceMetrics.Len() == 0 {
@@ -15,24 +15,32 @@
 package filereceiver
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/binary"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 func TestFileReader_Readline(t *testing.T) {
 	tc := testConsumer{}
 	f, err := os.Open(filepath.Join("testdata", "metrics.json"))
 	require.NoError(t, err)
-	fr := newFileReader(&tc, f, newReplayTimer(0))
-	err = fr.readLine(context.Background())
+	fr := newFileReader(lineReader{stringReader: bufio.NewReader(f)}, newMetricsReplayer(&tc, formatTypeJSON), newReplayTimer(0))
+	err = fr.readMessage(context.Background())
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(tc.consumed))
 	metrics := tc.consumed[0]
@@ -46,8 +54,8 @@ func TestFileReader_Readline(t *testing.T) {
 
 func TestFileReader_Cancellation(t *testing.T) {
 	fr := fileReader{
-		consumer:     consumertest.NewNop(),
-		stringReader: blockingStringReader{},
+		reader:   lineReader{stringReader: blockingStringReader{}},
+		replayer: newMetricsReplayer(consumertest.NewNop(), formatTypeJSON),
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
@@ -65,7 +73,7 @@ func TestFileReader_ReadAll(t *testing.T) {
 		throttle:  2,
 		sleepFunc: sleeper.fakeSleep,
 	}
-	fr := newFileReader(&tc, f, rt)
+	fr := newFileReader(lineReader{stringReader: bufio.NewReader(f)}, newMetricsReplayer(&tc, formatTypeJSON), rt)
 	err = fr.readAll(context.Background())
 	require.NoError(t, err)
 	const expectedSleeps = 10
@@ -79,6 +87,70 @@ func TestFileReader_ReadAll(t *testing.T) {
 	}
 }
 
+func TestFileReader_ReadTraces(t *testing.T) {
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("span")
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(time.Unix(10, 0)))
+	buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(traces)
+	require.NoError(t, err)
+
+	sink := new(consumertest.TracesSink)
+	sleeper := &fakeSleeper{}
+	reader, err := newMessageReader(bytes.NewReader(lengthPrefixed(buf, buf)), "", formatTypeProto)
+	require.NoError(t, err)
+	fr := newFileReader(reader, newTracesReplayer(sink, formatTypeProto), &replayTimer{throttle: 1, sleepFunc: sleeper.fakeSleep})
+	require.NoError(t, fr.readAll(context.Background()))
+
+	require.Len(t, sink.AllTraces(), 2)
+	assert.Equal(t, "span", sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	assert.Equal(t, []time.Duration{0, 0}, sleeper.durations)
+}
+
+func TestFileReader_ReadCompressedLogs(t *testing.T) {
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	var messages [][]byte
+	for i := 0; i < 2; i++ {
+		logs := plog.NewLogs()
+		record := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		record.Body().SetStr("log")
+		record.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(int64(10+i), 0)))
+		buf, err := (&plog.JSONMarshaler{}).MarshalLogs(logs)
+		require.NoError(t, err)
+		messages = append(messages, encoder.EncodeAll(buf, nil))
+	}
+
+	sink := new(consumertest.LogsSink)
+	sleeper := &fakeSleeper{}
+	reader, err := newMessageReader(bytes.NewReader(lengthPrefixed(messages...)), compressionZSTD, formatTypeJSON)
+	require.NoError(t, err)
+	fr := newFileReader(reader, newLogsReplayer(sink, formatTypeJSON), &replayTimer{throttle: 1, sleepFunc: sleeper.fakeSleep})
+	require.NoError(t, fr.readAll(context.Background()))
+
+	require.Equal(t, 2, sink.LogRecordCount())
+	assert.Equal(t, "log", sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	assert.Equal(t, []time.Duration{0, time.Second}, sleeper.durations)
+
+	// The decoder is released once the file has been read.
+	closer, ok := reader.(io.Closer)
+	require.True(t, ok)
+	require.NoError(t, closer.Close())
+	_, err = reader.(zstdReader).decoder.DecodeAll(messages[0], nil)
+	assert.ErrorIs(t, err, zstd.ErrDecoderClosed)
+}
+
+// lengthPrefixed encodes the messages the way the file exporter does for the proto format
+// and for compressed messages.
+func lengthPrefixed(messages ...[]byte) []byte {
+	var out []byte
+	for _, message := range messages {
+		out = binary.BigEndian.AppendUint32(out, uint32(len(message)))
+		out = append(out, message...)
+	}
+	return out
+}
+
 type blockingStringReader struct {
 }
 
@@ -3,6 +3,7 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filere
 go 1.19
 
 require (
+	github.com/klauspost/compress v1.16.5
 	github.com/stretchr/testify v1.8.2
 	go.opentelemetry.io/collector/component v0.75.0
 	go.opentelemetry.io/collector/confmap v0.75.0
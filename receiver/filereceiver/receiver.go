@@ -20,43 +20,79 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/consumer"
 	"go.uber.org/zap"
 )
 
 type fileReceiver struct {
-	consumer consumer.Metrics
-	path     string
-	logger   *zap.Logger
-	cancel   context.CancelFunc
-	throttle float64
+	replayer    replayer
+	path        string
+	format      string
+	compression string
+	logger      *zap.Logger
+	cancel      context.CancelFunc
+	throttle    float64
 }
 
 func (r *fileReceiver) Start(_ context.Context, _ component.Host) error {
 	var ctx context.Context
 	ctx, r.cancel = context.WithCancel(context.Background())
 
-	file, err := os.Open(r.path)
+	paths, err := filepath.Glob(r.path)
 	if err != nil {
-		return fmt.Errorf("failed to open file %q: %w", r.path, err)
+		return fmt.Errorf("invalid path %q: %w", r.path, err)
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("failed to open file %q: %w", r.path, os.ErrNotExist)
 	}
 
-	fr := newFileReader(r.consumer, file, newReplayTimer(r.throttle))
-	go func() {
-		err := fr.readAll(ctx)
+	files := make([]*os.File, 0, len(paths))
+	for _, path := range paths {
+		file, err := os.Open(path)
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				r.logger.Debug("EOF reached")
-			} else {
-				r.logger.Error("failed to read input file", zap.Error(err))
+			closeFiles(files)
+			return fmt.Errorf("failed to open file %q: %w", path, err)
+		}
+		files = append(files, file)
+	}
+
+	// The files share the replay timer so that the replay pace is kept from one file to the next.
+	timer := newReplayTimer(r.throttle)
+	go func() {
+		defer closeFiles(files)
+		for _, file := range files {
+			reader, err := newMessageReader(file, r.compression, r.format)
+			if err != nil {
+				r.logger.Error("failed to create input file reader", zap.Error(err))
+				return
+			}
+			err = newFileReader(reader, r.replayer, timer).readAll(ctx)
+			if closer, ok := reader.(io.Closer); ok {
+				_ = closer.Close()
+			}
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					r.logger.Debug("EOF reached")
+				} else {
+					r.logger.Error("failed to read input file", zap.String("path", file.Name()), zap.Error(err))
+				}
+			}
+			if ctx.Err() != nil {
+				return
 			}
 		}
 	}()
 	return nil
 }
 
+func closeFiles(files []*os.File) {
+	for _, file := range files {
+		_ = file.Close()
+	}
+}
+
 func (r *fileReceiver) Shutdown(ctx context.Context) error {
 	if r.cancel != nil {
 		r.cancel()
@@ -16,6 +16,8 @@ package filereceiver
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -32,7 +34,7 @@ func TestReceiver(t *testing.T) {
 	tc := &testConsumer{}
 	r := &fileReceiver{
 		path:     "testdata/metrics.json",
-		consumer: tc,
+		replayer: newMetricsReplayer(tc, formatTypeJSON),
 		logger:   zap.NewNop(),
 	}
 	err := r.Start(context.Background(), componenttest.NewNopHost())
@@ -45,6 +47,37 @@ func TestReceiver(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestReceiver_Glob(t *testing.T) {
+	metrics, err := os.ReadFile(filepath.Join("testdata", "metrics.json"))
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "metrics-1.json"), metrics, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "metrics-2.json"), metrics, 0600))
+
+	tc := &testConsumer{}
+	r := &fileReceiver{
+		path:     filepath.Join(dir, "metrics-*.json"),
+		replayer: newMetricsReplayer(tc, formatTypeJSON),
+		logger:   zap.NewNop(),
+	}
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool {
+		const numExpectedMetrics = 20
+		return numExpectedMetrics == tc.numConsumed()
+	}, 2*time.Second, 100*time.Millisecond)
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestReceiver_NoMatchingFile(t *testing.T) {
+	r := &fileReceiver{
+		path:     filepath.Join(t.TempDir(), "*.json"),
+		replayer: newMetricsReplayer(&testConsumer{}, formatTypeJSON),
+		logger:   zap.NewNop(),
+	}
+	assert.ErrorIs(t, r.Start(context.Background(), componenttest.NewNopHost()), os.ErrNotExist)
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
+
 type testConsumer struct {
 	mu       sync.Mutex
 	consumed []pmetric.Metrics
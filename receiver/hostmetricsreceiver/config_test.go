@@ -27,6 +27,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
@@ -34,8 +35,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/memoryscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/networkscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -92,6 +95,23 @@ func TestLoadConfig(t *testing.T) {
 				}
 				return cfg
 			})(),
+			pressurescraper.TypeStr: (&pressurescraper.Factory{}).CreateDefaultConfig(),
+			cgroupscraper.TypeStr: (func() internal.Config {
+				cfg := (&cgroupscraper.Factory{}).CreateDefaultConfig()
+				cfg.(*cgroupscraper.Config).Include = cgroupscraper.MatchConfig{
+					Paths:  []string{"/kubepods.slice/.*"},
+					Config: filterset.Config{MatchType: "regexp"},
+				}
+				return cfg
+			})(),
+			systemdscraper.TypeStr: (func() internal.Config {
+				cfg := (&systemdscraper.Factory{}).CreateDefaultConfig()
+				cfg.(*systemdscraper.Config).Exclude = systemdscraper.MatchConfig{
+					Units:  []string{"user@.*"},
+					Config: filterset.Config{MatchType: "regexp"},
+				}
+				return cfg
+			})(),
 		},
 	}
 
@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+const (
+	cpuMetricsLen    = 5
+	memoryMetricsLen = 2
+	ioMetricsLen     = 2
+
+	// cgroupRoot is the mount point of the cgroup v2 hierarchy, relative to the root path.
+	cgroupRoot = "sys/fs/cgroup"
+)
+
+// scraper for cgroup v2 Metrics
+type scraper struct {
+	settings  receiver.CreateSettings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+
+	// for mocking
+	bootTime func() (uint64, error)
+}
+
+// newCgroupScraper creates a scraper reading the statistics of every cgroup of the cgroup v2 hierarchy.
+func newCgroupScraper(settings receiver.CreateSettings, cfg *Config) (*scraper, error) {
+	s := &scraper{settings: settings, config: cfg, bootTime: host.BootTime}
+
+	var err error
+
+	if len(cfg.Include.Paths) > 0 {
+		s.includeFS, err = filterset.CreateFilterSet(cfg.Include.Paths, &cfg.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Exclude.Paths) > 0 {
+		s.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Paths, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup exclude filters: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *scraper) start(context.Context, component.Host) error {
+	bootTime, err := s.bootTime()
+	if err != nil {
+		return err
+	}
+
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+	root := filepath.Join(s.config.RootPath, "/", cgroupRoot)
+
+	var errs scrapererror.ScrapeErrors
+	err := filepath.WalkDir(root, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// The cgroup may have been removed while walking the hierarchy.
+			if errors.Is(err, fs.ErrNotExist) && dir != root {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return err
+		}
+		path := filepath.ToSlash(filepath.Join("/", rel))
+		if (s.includeFS != nil && !s.includeFS.Matches(path)) ||
+			(s.excludeFS != nil && s.excludeFS.Matches(path)) {
+			return nil
+		}
+
+		if err := s.scrapeCPU(now, dir); err != nil {
+			errs.AddPartial(cpuMetricsLen, fmt.Errorf("error reading cpu stats of cgroup %s: %w", path, err))
+		}
+		if err := s.scrapeMemory(now, dir); err != nil {
+			errs.AddPartial(memoryMetricsLen, fmt.Errorf("error reading memory stats of cgroup %s: %w", path, err))
+		}
+		if err := s.scrapeIO(now, dir); err != nil {
+			errs.AddPartial(ioMetricsLen, fmt.Errorf("error reading io stats of cgroup %s: %w", path, err))
+		}
+		s.mb.EmitForResource(metadata.WithCgroupPath(path))
+		return nil
+	})
+	if err != nil {
+		return pmetric.NewMetrics(), err
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+// readKeyValues reads a flat keyed file such as cpu.stat, made of "key value" lines.
+// A missing file, because the controller is not enabled for the cgroup, returns no values.
+func readKeyValues(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := map[string]uint64{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %q: %w", fields[0], err)
+		}
+		values[fields[0]] = value
+	}
+	return values, scanner.Err()
+}
+
+// readSingleValue reads a file holding a single value. The second return value is false if the file
+// does not exist or holds "max".
+func readSingleValue(path string) (int64, bool, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	value := strings.TrimSpace(string(content))
+	if value == "max" {
+		return 0, false, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return parsed, true, nil
+}
+
+func usecToSeconds(usec uint64) float64 {
+	return float64(usec) / float64(time.Second/time.Microsecond)
+}
+
+func (s *scraper) scrapeCPU(now pcommon.Timestamp, dir string) error {
+	stats, err := readKeyValues(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return err
+	}
+
+	if v, ok := stats["user_usec"]; ok {
+		s.mb.RecordCgroupCPUTimeDataPoint(now, usecToSeconds(v), metadata.AttributeStateUser)
+	}
+	if v, ok := stats["system_usec"]; ok {
+		s.mb.RecordCgroupCPUTimeDataPoint(now, usecToSeconds(v), metadata.AttributeStateSystem)
+	}
+	if v, ok := stats["nr_periods"]; ok {
+		s.mb.RecordCgroupCPUPeriodsDataPoint(now, int64(v))
+	}
+	if v, ok := stats["nr_throttled"]; ok {
+		s.mb.RecordCgroupCPUThrottledPeriodsDataPoint(now, int64(v))
+	}
+	if v, ok := stats["throttled_usec"]; ok {
+		s.mb.RecordCgroupCPUThrottledTimeDataPoint(now, usecToSeconds(v))
+	}
+	return nil
+}
+
+func (s *scraper) scrapeMemory(now pcommon.Timestamp, dir string) error {
+	usage, ok, err := readSingleValue(filepath.Join(dir, "memory.current"))
+	if err != nil {
+		return err
+	}
+	if ok {
+		s.mb.RecordCgroupMemoryUsageDataPoint(now, usage)
+	}
+
+	limit, ok, err := readSingleValue(filepath.Join(dir, "memory.max"))
+	if err != nil {
+		return err
+	}
+	if ok {
+		s.mb.RecordCgroupMemoryLimitDataPoint(now, limit)
+	}
+	return nil
+}
+
+// scrapeIO reads io.stat, made of lines such as "8:0 rbytes=1024 wbytes=0 rios=1 wios=0 dbytes=0 dios=0".
+func (s *scraper) scrapeIO(now pcommon.Timestamp, dir string) error {
+	f, err := os.Open(filepath.Join(dir, "io.stat"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		device := fields[0]
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return fmt.Errorf("invalid field %q", field)
+			}
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value of %q: %w", key, err)
+			}
+			switch key {
+			case "rbytes":
+				s.mb.RecordCgroupIoBytesDataPoint(now, parsed, device, metadata.AttributeDirectionRead)
+			case "wbytes":
+				s.mb.RecordCgroupIoBytesDataPoint(now, parsed, device, metadata.AttributeDirectionWrite)
+			case "rios":
+				s.mb.RecordCgroupIoOperationsDataPoint(now, parsed, device, metadata.AttributeDirectionRead)
+			case "wios":
+				s.mb.RecordCgroupIoOperationsDataPoint(now, parsed, device, metadata.AttributeDirectionWrite)
+			}
+		}
+	}
+	return scanner.Err()
+}
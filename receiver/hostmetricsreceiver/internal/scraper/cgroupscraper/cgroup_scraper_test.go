@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+const bootTime = 100
+
+func newTestScraper(t *testing.T, rootPath string, cfg *Config) *scraper {
+	cfg.MetricsBuilderConfig = metadata.DefaultMetricsBuilderConfig()
+	cfg.SetRootPath(rootPath)
+	s, err := newCgroupScraper(receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	s.bootTime = func() (uint64, error) { return bootTime, nil }
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+// metricsByCgroup indexes the scraped metrics by cgroup path and metric name.
+func metricsByCgroup(t *testing.T, md pmetric.Metrics) map[string]map[string]pmetric.Metric {
+	out := map[string]map[string]pmetric.Metric{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		path, ok := rm.Resource().Attributes().Get("cgroup.path")
+		require.True(t, ok)
+		metrics := map[string]pmetric.Metric{}
+		for j := 0; j < rm.ScopeMetrics().At(0).Metrics().Len(); j++ {
+			m := rm.ScopeMetrics().At(0).Metrics().At(j)
+			metrics[m.Name()] = m
+		}
+		out[path.Str()] = metrics
+	}
+	return out
+}
+
+func TestScrape(t *testing.T) {
+	s := newTestScraper(t, "testdata", &Config{})
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	cgroups := metricsByCgroup(t, md)
+	require.Len(t, cgroups, 3)
+
+	root := cgroups["/"]
+	assert.Len(t, root, 3)
+	assert.Equal(t, 2, root["cgroup.cpu.time"].Sum().DataPoints().Len())
+	assert.Equal(t, 3.0, root["cgroup.cpu.time"].Sum().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(bootTime*1e9), root["cgroup.cpu.time"].Sum().DataPoints().At(0).StartTimestamp())
+	assert.Equal(t, 2, root["cgroup.io.bytes"].Sum().DataPoints().Len())
+	assert.NotContains(t, root, "cgroup.memory.usage")
+
+	slice := cgroups["/system.slice"]
+	assert.Len(t, slice, 7)
+	assert.Equal(t, int64(100), slice["cgroup.cpu.periods"].Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(10), slice["cgroup.cpu.throttled.periods"].Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, 0.5, slice["cgroup.cpu.throttled.time"].Sum().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, int64(1048576), slice["cgroup.memory.usage"].Sum().DataPoints().At(0).IntValue())
+	assert.NotContains(t, slice, "cgroup.memory.limit")
+	ioBytes := slice["cgroup.io.bytes"].Sum().DataPoints()
+	require.Equal(t, 4, ioBytes.Len())
+	device, _ := ioBytes.At(2).Attributes().Get("device")
+	direction, _ := ioBytes.At(2).Attributes().Get("direction")
+	assert.Equal(t, "253:1", device.Str())
+	assert.Equal(t, "read", direction.Str())
+	assert.Equal(t, int64(1024), ioBytes.At(2).IntValue())
+
+	service := cgroups["/system.slice/app.service"]
+	assert.Len(t, service, 6)
+	assert.Equal(t, int64(1073741824), service["cgroup.memory.limit"].Sum().DataPoints().At(0).IntValue())
+	assert.NotContains(t, service, "cgroup.io.bytes")
+}
+
+func TestScrapeFilters(t *testing.T) {
+	s := newTestScraper(t, "testdata", &Config{
+		Include: MatchConfig{
+			Config: filterset.Config{MatchType: filterset.Regexp},
+			Paths:  []string{"^/system.slice"},
+		},
+		Exclude: MatchConfig{
+			Config: filterset.Config{MatchType: filterset.Strict},
+			Paths:  []string{"/system.slice"},
+		},
+	})
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	cgroups := metricsByCgroup(t, md)
+	assert.Len(t, cgroups, 1)
+	assert.Contains(t, cgroups, "/system.slice/app.service")
+}
+
+func TestScrapeErrors(t *testing.T) {
+	rootPath := t.TempDir()
+	dir := filepath.Join(rootPath, cgroupRoot)
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cpu.stat"), []byte("user_usec -1\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "memory.current"), []byte("2048\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "io.stat"), []byte("8:0 rbytes\n"), 0600))
+
+	s := newTestScraper(t, rootPath, &Config{})
+	md, err := s.scrape(context.Background())
+	require.Error(t, err)
+
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, cpuMetricsLen+ioMetricsLen, partialErr.Failed)
+	assert.Equal(t, 1, md.MetricCount())
+}
+
+func TestScrapeMissingHierarchy(t *testing.T) {
+	s := newTestScraper(t, t.TempDir(), &Config{})
+	_, err := s.scrape(context.Background())
+	assert.Error(t, err)
+}
+
+func TestCreateFilterError(t *testing.T) {
+	_, err := newCgroupScraper(receivertest.NewNopCreateSettings(), &Config{
+		Include: MatchConfig{
+			Config: filterset.Config{MatchType: filterset.Regexp},
+			Paths:  []string{"("},
+		},
+	})
+	assert.ErrorContains(t, err, "error creating cgroup include filters")
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+// metricsLen is the number of metrics recorded for each resource: the stall time
+// and the stall ratio of every window, for both stall types.
+const metricsLen = 8
+
+var resources = []metadata.AttributeResource{
+	metadata.AttributeResourceCpu,
+	metadata.AttributeResourceMemory,
+	metadata.AttributeResourceIo,
+}
+
+// pressureStat holds one line of a pressure file, e.g.
+// "some avg10=0.12 avg60=0.05 avg300=0.01 total=123456".
+type pressureStat struct {
+	stallType metadata.AttributeStallType
+	avg10     float64
+	avg60     float64
+	avg300    float64
+	// total is the stall time in microseconds.
+	total uint64
+}
+
+// scraper for Pressure Stall Information Metrics
+type scraper struct {
+	settings receiver.CreateSettings
+	config   *Config
+	mb       *metadata.MetricsBuilder
+
+	// for mocking
+	bootTime func() (uint64, error)
+}
+
+// newPressureScraper creates a scraper reading the pressure stall information of the cpu, memory and io resources.
+func newPressureScraper(_ context.Context, settings receiver.CreateSettings, cfg *Config) *scraper {
+	return &scraper{settings: settings, config: cfg, bootTime: host.BootTime}
+}
+
+func (s *scraper) start(context.Context, component.Host) error {
+	bootTime, err := s.bootTime()
+	if err != nil {
+		return err
+	}
+
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	var errs scrapererror.ScrapeErrors
+	for _, resource := range resources {
+		stats, err := readPressureFile(filepath.Join(s.config.RootPath, "proc", "pressure", resource.String()))
+		if err != nil {
+			errs.AddPartial(metricsLen, err)
+			continue
+		}
+		for _, stat := range stats {
+			s.mb.RecordSystemPressureStallTimeDataPoint(now, float64(stat.total)/float64(time.Second/time.Microsecond), resource, stat.stallType)
+			s.mb.RecordSystemPressureStallRatioDataPoint(now, stat.avg10/100, resource, stat.stallType, metadata.AttributeWindow10s)
+			s.mb.RecordSystemPressureStallRatioDataPoint(now, stat.avg60/100, resource, stat.stallType, metadata.AttributeWindow60s)
+			s.mb.RecordSystemPressureStallRatioDataPoint(now, stat.avg300/100, resource, stat.stallType, metadata.AttributeWindow300s)
+		}
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func readPressureFile(path string) ([]pressureStat, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var stats []pressureStat
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		stat, err := parsePressureLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		stats = append(stats, stat)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func parsePressureLine(line string) (pressureStat, error) {
+	fields := strings.Fields(line)
+	stallType, ok := metadata.MapAttributeStallType[fields[0]]
+	if !ok {
+		return pressureStat{}, fmt.Errorf("unknown stall type %q", fields[0])
+	}
+
+	stat := pressureStat{stallType: stallType}
+	for _, field := range fields[1:] {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return pressureStat{}, fmt.Errorf("invalid field %q", field)
+		}
+		var err error
+		switch key {
+		case "avg10":
+			stat.avg10, err = strconv.ParseFloat(value, 64)
+		case "avg60":
+			stat.avg60, err = strconv.ParseFloat(value, 64)
+		case "avg300":
+			stat.avg300, err = strconv.ParseFloat(value, 64)
+		case "total":
+			stat.total, err = strconv.ParseUint(value, 10, 64)
+		}
+		if err != nil {
+			return pressureStat{}, fmt.Errorf("invalid value of %q: %w", key, err)
+		}
+	}
+	return stat, nil
+}
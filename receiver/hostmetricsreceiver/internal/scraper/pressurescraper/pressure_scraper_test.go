@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pressurescraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+const bootTime = 100
+
+func newTestScraper(t *testing.T, rootPath string) *scraper {
+	cfg := &Config{MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig()}
+	cfg.SetRootPath(rootPath)
+	s := newPressureScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+	s.bootTime = func() (uint64, error) { return bootTime, nil }
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+func findPoint(t *testing.T, metric pmetric.Metric, attrs map[string]string) pmetric.NumberDataPoint {
+	var dps pmetric.NumberDataPointSlice
+	if metric.Type() == pmetric.MetricTypeSum {
+		dps = metric.Sum().DataPoints()
+	} else {
+		dps = metric.Gauge().DataPoints()
+	}
+	for i := 0; i < dps.Len(); i++ {
+		matches := true
+		for k, v := range attrs {
+			if got, ok := dps.At(i).Attributes().Get(k); !ok || got.Str() != v {
+				matches = false
+			}
+		}
+		if matches {
+			return dps.At(i)
+		}
+	}
+	t.Fatalf("no data point of %s with attributes %v", metric.Name(), attrs)
+	return pmetric.NumberDataPoint{}
+}
+
+func TestScrape(t *testing.T) {
+	s := newTestScraper(t, "testdata")
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, md.MetricCount())
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	internal.AssertSameTimeStampForAllMetrics(t, metrics)
+
+	ratio := metrics.At(0)
+	assert.Equal(t, "system.pressure.stall.ratio", ratio.Name())
+	assert.Equal(t, 18, ratio.Gauge().DataPoints().Len())
+	assert.InDelta(t, 0.015, findPoint(t, ratio, map[string]string{"resource": "cpu", "type": "some", "window": "10s"}).DoubleValue(), 1e-9)
+	assert.InDelta(t, 0.01, findPoint(t, ratio, map[string]string{"resource": "memory", "type": "full", "window": "300s"}).DoubleValue(), 1e-9)
+
+	stallTime := metrics.At(1)
+	assert.Equal(t, "system.pressure.stall.time", stallTime.Name())
+	assert.Equal(t, 6, stallTime.Sum().DataPoints().Len())
+	assert.True(t, stallTime.Sum().IsMonotonic())
+	memory := findPoint(t, stallTime, map[string]string{"resource": "memory", "type": "some"})
+	assert.Equal(t, 4.0, memory.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(bootTime*1e9), memory.StartTimestamp())
+	assert.Equal(t, 0.5, findPoint(t, stallTime, map[string]string{"resource": "io", "type": "full"}).DoubleValue())
+}
+
+func TestScrapeMissingResource(t *testing.T) {
+	rootPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootPath, "proc", "pressure"), 0700))
+	cpu, err := os.ReadFile(filepath.Join("testdata", "proc", "pressure", "cpu"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, "proc", "pressure", "cpu"), cpu, 0600))
+
+	s := newTestScraper(t, rootPath)
+	md, err := s.scrape(context.Background())
+	require.Error(t, err)
+
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, 2*metricsLen, partialErr.Failed)
+	assert.Equal(t, 8, md.DataPointCount())
+}
+
+func TestParsePressureLine(t *testing.T) {
+	stat, err := parsePressureLine("full avg10=1.25 avg60=0.50 avg300=0.10 total=42")
+	require.NoError(t, err)
+	assert.Equal(t, pressureStat{stallType: metadata.AttributeStallTypeFull, avg10: 1.25, avg60: 0.5, avg300: 0.1, total: 42}, stat)
+
+	_, err = parsePressureLine("partial avg10=1.25")
+	assert.EqualError(t, err, `unknown stall type "partial"`)
+
+	_, err = parsePressureLine("some avg10")
+	assert.EqualError(t, err, `invalid field "avg10"`)
+
+	_, err = parsePressureLine("some total=-1")
+	assert.ErrorContains(t, err, `invalid value of "total"`)
+}
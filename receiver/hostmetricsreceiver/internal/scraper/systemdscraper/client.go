@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemdscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper"
+
+import "context"
+
+// unitStatus is the state of a unit loaded by systemd.
+type unitStatus struct {
+	Name        string
+	LoadState   string
+	ActiveState string
+	SubState    string
+}
+
+// systemdClient lists the units loaded by systemd and reads their properties.
+// It is implemented over D-Bus on Linux, and faked in tests.
+type systemdClient interface {
+	// ListUnits returns the units currently loaded by systemd.
+	ListUnits(ctx context.Context) ([]unitStatus, error)
+	// UnitTypeProperties returns the properties of the unit type specific D-Bus interface of the unit,
+	// e.g. org.freedesktop.systemd1.Service for a service unit, keyed by property name.
+	UnitTypeProperties(ctx context.Context, name string, unitType string) (map[string]interface{}, error)
+	Close()
+}
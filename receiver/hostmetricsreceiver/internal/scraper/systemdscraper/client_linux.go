@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package systemdscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper"
+
+import (
+	"context"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+)
+
+type dbusClient struct {
+	conn *dbus.Conn
+}
+
+var _ systemdClient = (*dbusClient)(nil)
+
+// newDBusClient connects to the system instance of systemd over D-Bus.
+func newDBusClient(ctx context.Context) (systemdClient, error) {
+	conn, err := dbus.NewSystemConnectionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &dbusClient{conn: conn}, nil
+}
+
+func (c *dbusClient) ListUnits(ctx context.Context) ([]unitStatus, error) {
+	units, err := c.conn.ListUnitsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]unitStatus, 0, len(units))
+	for _, unit := range units {
+		statuses = append(statuses, unitStatus{
+			Name:        unit.Name,
+			LoadState:   unit.LoadState,
+			ActiveState: unit.ActiveState,
+			SubState:    unit.SubState,
+		})
+	}
+	return statuses, nil
+}
+
+func (c *dbusClient) UnitTypeProperties(ctx context.Context, name string, unitType string) (map[string]interface{}, error) {
+	return c.conn.GetUnitTypePropertiesContext(ctx, name, unitType)
+}
+
+func (c *dbusClient) Close() {
+	c.conn.Close()
+}
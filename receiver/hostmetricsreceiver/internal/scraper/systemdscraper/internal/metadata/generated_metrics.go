@@ -0,0 +1,785 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+)
+
+// MetricSettings provides common settings for a particular metric.
+type MetricSettings struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricSettings) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms, confmap.WithErrorUnused())
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsSettings provides settings for hostmetricsreceiver/systemd metrics.
+type MetricsSettings struct {
+	SystemdUnitActiveState MetricSettings `mapstructure:"systemd.unit.active_state"`
+	SystemdUnitCPUTime     MetricSettings `mapstructure:"systemd.unit.cpu.time"`
+	SystemdUnitIo          MetricSettings `mapstructure:"systemd.unit.io"`
+	SystemdUnitLoadState   MetricSettings `mapstructure:"systemd.unit.load_state"`
+	SystemdUnitMemoryUsage MetricSettings `mapstructure:"systemd.unit.memory.usage"`
+	SystemdUnitRestarts    MetricSettings `mapstructure:"systemd.unit.restarts"`
+	SystemdUnitSubState    MetricSettings `mapstructure:"systemd.unit.sub_state"`
+	SystemdUnitTasks       MetricSettings `mapstructure:"systemd.unit.tasks"`
+}
+
+func DefaultMetricsSettings() MetricsSettings {
+	return MetricsSettings{
+		SystemdUnitActiveState: MetricSettings{
+			Enabled: true,
+		},
+		SystemdUnitCPUTime: MetricSettings{
+			Enabled: true,
+		},
+		SystemdUnitIo: MetricSettings{
+			Enabled: false,
+		},
+		SystemdUnitLoadState: MetricSettings{
+			Enabled: true,
+		},
+		SystemdUnitMemoryUsage: MetricSettings{
+			Enabled: true,
+		},
+		SystemdUnitRestarts: MetricSettings{
+			Enabled: true,
+		},
+		SystemdUnitSubState: MetricSettings{
+			Enabled: true,
+		},
+		SystemdUnitTasks: MetricSettings{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeSettings provides common settings for a particular metric.
+type ResourceAttributeSettings struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// ResourceAttributesSettings provides settings for hostmetricsreceiver/systemd metrics.
+type ResourceAttributesSettings struct {
+	SystemdUnitName ResourceAttributeSettings `mapstructure:"systemd.unit.name"`
+	SystemdUnitType ResourceAttributeSettings `mapstructure:"systemd.unit.type"`
+}
+
+func DefaultResourceAttributesSettings() ResourceAttributesSettings {
+	return ResourceAttributesSettings{
+		SystemdUnitName: ResourceAttributeSettings{
+			Enabled: true,
+		},
+		SystemdUnitType: ResourceAttributeSettings{
+			Enabled: false,
+		},
+	}
+}
+
+// AttributeActiveState specifies the a value active_state attribute.
+type AttributeActiveState int
+
+const (
+	_ AttributeActiveState = iota
+	AttributeActiveStateActive
+	AttributeActiveStateReloading
+	AttributeActiveStateInactive
+	AttributeActiveStateFailed
+	AttributeActiveStateActivating
+	AttributeActiveStateDeactivating
+)
+
+// String returns the string representation of the AttributeActiveState.
+func (av AttributeActiveState) String() string {
+	switch av {
+	case AttributeActiveStateActive:
+		return "active"
+	case AttributeActiveStateReloading:
+		return "reloading"
+	case AttributeActiveStateInactive:
+		return "inactive"
+	case AttributeActiveStateFailed:
+		return "failed"
+	case AttributeActiveStateActivating:
+		return "activating"
+	case AttributeActiveStateDeactivating:
+		return "deactivating"
+	}
+	return ""
+}
+
+// MapAttributeActiveState is a helper map of string to AttributeActiveState attribute value.
+var MapAttributeActiveState = map[string]AttributeActiveState{
+	"active":       AttributeActiveStateActive,
+	"reloading":    AttributeActiveStateReloading,
+	"inactive":     AttributeActiveStateInactive,
+	"failed":       AttributeActiveStateFailed,
+	"activating":   AttributeActiveStateActivating,
+	"deactivating": AttributeActiveStateDeactivating,
+}
+
+// AttributeDirection specifies the a value direction attribute.
+type AttributeDirection int
+
+const (
+	_ AttributeDirection = iota
+	AttributeDirectionRead
+	AttributeDirectionWrite
+)
+
+// String returns the string representation of the AttributeDirection.
+func (av AttributeDirection) String() string {
+	switch av {
+	case AttributeDirectionRead:
+		return "read"
+	case AttributeDirectionWrite:
+		return "write"
+	}
+	return ""
+}
+
+// MapAttributeDirection is a helper map of string to AttributeDirection attribute value.
+var MapAttributeDirection = map[string]AttributeDirection{
+	"read":  AttributeDirectionRead,
+	"write": AttributeDirectionWrite,
+}
+
+type metricSystemdUnitActiveState struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.active_state metric with initial data.
+func (m *metricSystemdUnitActiveState) init() {
+	m.data.SetName("systemd.unit.active_state")
+	m.data.SetDescription("Whether the unit is in the active state. One data point is reported per state, with value 1 for the current state and 0 for the others.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemdUnitActiveState) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, activeStateAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", activeStateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitActiveState) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitActiveState) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitActiveState(settings MetricSettings) metricSystemdUnitActiveState {
+	m := metricSystemdUnitActiveState{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitCPUTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.cpu.time metric with initial data.
+func (m *metricSystemdUnitCPUTime) init() {
+	m.data.SetName("systemd.unit.cpu.time")
+	m.data.SetDescription("Total CPU seconds consumed by the processes of the unit.")
+	m.data.SetUnit("s")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricSystemdUnitCPUTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitCPUTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitCPUTime) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitCPUTime(settings MetricSettings) metricSystemdUnitCPUTime {
+	m := metricSystemdUnitCPUTime{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitIo struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.io metric with initial data.
+func (m *metricSystemdUnitIo) init() {
+	m.data.SetName("systemd.unit.io")
+	m.data.SetDescription("Bytes read from or written to block devices by the processes of the unit.")
+	m.data.SetUnit("By")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemdUnitIo) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, directionAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("direction", directionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitIo) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitIo) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitIo(settings MetricSettings) metricSystemdUnitIo {
+	m := metricSystemdUnitIo{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitLoadState struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.load_state metric with initial data.
+func (m *metricSystemdUnitLoadState) init() {
+	m.data.SetName("systemd.unit.load_state")
+	m.data.SetDescription("Load state of the unit, reported as a data point with value 1 for the current state.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemdUnitLoadState) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, loadStateAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", loadStateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitLoadState) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitLoadState) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitLoadState(settings MetricSettings) metricSystemdUnitLoadState {
+	m := metricSystemdUnitLoadState{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitMemoryUsage struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.memory.usage metric with initial data.
+func (m *metricSystemdUnitMemoryUsage) init() {
+	m.data.SetName("systemd.unit.memory.usage")
+	m.data.SetDescription("Memory currently used by the processes of the unit.")
+	m.data.SetUnit("By")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricSystemdUnitMemoryUsage) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitMemoryUsage) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitMemoryUsage) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitMemoryUsage(settings MetricSettings) metricSystemdUnitMemoryUsage {
+	m := metricSystemdUnitMemoryUsage{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitRestarts struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.restarts metric with initial data.
+func (m *metricSystemdUnitRestarts) init() {
+	m.data.SetName("systemd.unit.restarts")
+	m.data.SetDescription("Number of times the service was restarted automatically.")
+	m.data.SetUnit("{restarts}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricSystemdUnitRestarts) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitRestarts) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitRestarts) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitRestarts(settings MetricSettings) metricSystemdUnitRestarts {
+	m := metricSystemdUnitRestarts{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitSubState struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.sub_state metric with initial data.
+func (m *metricSystemdUnitSubState) init() {
+	m.data.SetName("systemd.unit.sub_state")
+	m.data.SetDescription("Unit type specific state of the unit, reported as a data point with value 1 for the current state.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemdUnitSubState) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, subStateAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", subStateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitSubState) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitSubState) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitSubState(settings MetricSettings) metricSystemdUnitSubState {
+	m := metricSystemdUnitSubState{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitTasks struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.tasks metric with initial data.
+func (m *metricSystemdUnitTasks) init() {
+	m.data.SetName("systemd.unit.tasks")
+	m.data.SetDescription("Number of tasks currently running in the unit.")
+	m.data.SetUnit("{tasks}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricSystemdUnitTasks) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitTasks) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitTasks) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitTasks(settings MetricSettings) metricSystemdUnitTasks {
+	m := metricSystemdUnitTasks{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilderConfig is a structural subset of an otherwise 1-1 copy of metadata.yaml
+type MetricsBuilderConfig struct {
+	Metrics            MetricsSettings            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesSettings `mapstructure:"resource_attributes"`
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user settings.
+type MetricsBuilder struct {
+	startTime                    pcommon.Timestamp   // start time that will be applied to all recorded data points.
+	metricsCapacity              int                 // maximum observed number of metrics per resource.
+	resourceCapacity             int                 // maximum observed number of resource attributes.
+	metricsBuffer                pmetric.Metrics     // accumulates metrics data before emitting.
+	buildInfo                    component.BuildInfo // contains version information
+	resourceAttributesSettings   ResourceAttributesSettings
+	metricSystemdUnitActiveState metricSystemdUnitActiveState
+	metricSystemdUnitCPUTime     metricSystemdUnitCPUTime
+	metricSystemdUnitIo          metricSystemdUnitIo
+	metricSystemdUnitLoadState   metricSystemdUnitLoadState
+	metricSystemdUnitMemoryUsage metricSystemdUnitMemoryUsage
+	metricSystemdUnitRestarts    metricSystemdUnitRestarts
+	metricSystemdUnitSubState    metricSystemdUnitSubState
+	metricSystemdUnitTasks       metricSystemdUnitTasks
+}
+
+// metricBuilderOption applies changes to default metrics builder.
+type metricBuilderOption func(*MetricsBuilder)
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
+	return func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	}
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsSettings(),
+		ResourceAttributes: DefaultResourceAttributesSettings(),
+	}
+}
+
+func NewMetricsBuilderConfig(ms MetricsSettings, ras ResourceAttributesSettings) MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            ms,
+		ResourceAttributes: ras,
+	}
+}
+
+func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.CreateSettings, options ...metricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		startTime:                    pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                pmetric.NewMetrics(),
+		buildInfo:                    settings.BuildInfo,
+		resourceAttributesSettings:   mbc.ResourceAttributes,
+		metricSystemdUnitActiveState: newMetricSystemdUnitActiveState(mbc.Metrics.SystemdUnitActiveState),
+		metricSystemdUnitCPUTime:     newMetricSystemdUnitCPUTime(mbc.Metrics.SystemdUnitCPUTime),
+		metricSystemdUnitIo:          newMetricSystemdUnitIo(mbc.Metrics.SystemdUnitIo),
+		metricSystemdUnitLoadState:   newMetricSystemdUnitLoadState(mbc.Metrics.SystemdUnitLoadState),
+		metricSystemdUnitMemoryUsage: newMetricSystemdUnitMemoryUsage(mbc.Metrics.SystemdUnitMemoryUsage),
+		metricSystemdUnitRestarts:    newMetricSystemdUnitRestarts(mbc.Metrics.SystemdUnitRestarts),
+		metricSystemdUnitSubState:    newMetricSystemdUnitSubState(mbc.Metrics.SystemdUnitSubState),
+		metricSystemdUnitTasks:       newMetricSystemdUnitTasks(mbc.Metrics.SystemdUnitTasks),
+	}
+	for _, op := range options {
+		op(mb)
+	}
+	return mb
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+	if mb.resourceCapacity < rm.Resource().Attributes().Len() {
+		mb.resourceCapacity = rm.Resource().Attributes().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption func(ResourceAttributesSettings, pmetric.ResourceMetrics)
+
+// WithSystemdUnitName sets provided value as "systemd.unit.name" attribute for current resource.
+func WithSystemdUnitName(val string) ResourceMetricsOption {
+	return func(ras ResourceAttributesSettings, rm pmetric.ResourceMetrics) {
+		if ras.SystemdUnitName.Enabled {
+			rm.Resource().Attributes().PutStr("systemd.unit.name", val)
+		}
+	}
+}
+
+// WithSystemdUnitType sets provided value as "systemd.unit.type" attribute for current resource.
+func WithSystemdUnitType(val string) ResourceMetricsOption {
+	return func(ras ResourceAttributesSettings, rm pmetric.ResourceMetrics) {
+		if ras.SystemdUnitType.Enabled {
+			rm.Resource().Attributes().PutStr("systemd.unit.type", val)
+		}
+	}
+}
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return func(ras ResourceAttributesSettings, rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).Type() {
+			case pmetric.MetricTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	}
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	rm.SetSchemaUrl(conventions.SchemaURL)
+	rm.Resource().Attributes().EnsureCapacity(mb.resourceCapacity)
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName("otelcol/hostmetricsreceiver/systemd")
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricSystemdUnitActiveState.emit(ils.Metrics())
+	mb.metricSystemdUnitCPUTime.emit(ils.Metrics())
+	mb.metricSystemdUnitIo.emit(ils.Metrics())
+	mb.metricSystemdUnitLoadState.emit(ils.Metrics())
+	mb.metricSystemdUnitMemoryUsage.emit(ils.Metrics())
+	mb.metricSystemdUnitRestarts.emit(ils.Metrics())
+	mb.metricSystemdUnitSubState.emit(ils.Metrics())
+	mb.metricSystemdUnitTasks.emit(ils.Metrics())
+
+	for _, op := range rmo {
+		op(mb.resourceAttributesSettings, rm)
+	}
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user settings, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(rmo...)
+	metrics := mb.metricsBuffer
+	mb.metricsBuffer = pmetric.NewMetrics()
+	return metrics
+}
+
+// RecordSystemdUnitActiveStateDataPoint adds a data point to systemd.unit.active_state metric.
+func (mb *MetricsBuilder) RecordSystemdUnitActiveStateDataPoint(ts pcommon.Timestamp, val int64, activeStateAttributeValue AttributeActiveState) {
+	mb.metricSystemdUnitActiveState.recordDataPoint(mb.startTime, ts, val, activeStateAttributeValue.String())
+}
+
+// RecordSystemdUnitCPUTimeDataPoint adds a data point to systemd.unit.cpu.time metric.
+func (mb *MetricsBuilder) RecordSystemdUnitCPUTimeDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricSystemdUnitCPUTime.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordSystemdUnitIoDataPoint adds a data point to systemd.unit.io metric.
+func (mb *MetricsBuilder) RecordSystemdUnitIoDataPoint(ts pcommon.Timestamp, val int64, directionAttributeValue AttributeDirection) {
+	mb.metricSystemdUnitIo.recordDataPoint(mb.startTime, ts, val, directionAttributeValue.String())
+}
+
+// RecordSystemdUnitLoadStateDataPoint adds a data point to systemd.unit.load_state metric.
+func (mb *MetricsBuilder) RecordSystemdUnitLoadStateDataPoint(ts pcommon.Timestamp, val int64, loadStateAttributeValue string) {
+	mb.metricSystemdUnitLoadState.recordDataPoint(mb.startTime, ts, val, loadStateAttributeValue)
+}
+
+// RecordSystemdUnitMemoryUsageDataPoint adds a data point to systemd.unit.memory.usage metric.
+func (mb *MetricsBuilder) RecordSystemdUnitMemoryUsageDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricSystemdUnitMemoryUsage.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordSystemdUnitRestartsDataPoint adds a data point to systemd.unit.restarts metric.
+func (mb *MetricsBuilder) RecordSystemdUnitRestartsDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricSystemdUnitRestarts.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordSystemdUnitSubStateDataPoint adds a data point to systemd.unit.sub_state metric.
+func (mb *MetricsBuilder) RecordSystemdUnitSubStateDataPoint(ts pcommon.Timestamp, val int64, subStateAttributeValue string) {
+	mb.metricSystemdUnitSubState.recordDataPoint(mb.startTime, ts, val, subStateAttributeValue)
+}
+
+// RecordSystemdUnitTasksDataPoint adds a data point to systemd.unit.tasks metric.
+func (mb *MetricsBuilder) RecordSystemdUnitTasksDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricSystemdUnitTasks.recordDataPoint(mb.startTime, ts, val)
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op(mb)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemdscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper/internal/metadata"
+)
+
+// accountingMetricsLen is the number of metrics read from the unit type properties.
+const accountingMetricsLen = 5
+
+// unitTypeInterfaces maps the unit types to the name of their D-Bus interface. Only these
+// unit types have resource accounting properties.
+var unitTypeInterfaces = map[string]string{
+	"service": "Service",
+	"scope":   "Scope",
+	"slice":   "Slice",
+	"socket":  "Socket",
+	"mount":   "Mount",
+	"swap":    "Swap",
+}
+
+// scraper for systemd unit Metrics
+type scraper struct {
+	settings  receiver.CreateSettings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	client    systemdClient
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+
+	// for mocking
+	bootTime  func() (uint64, error)
+	newClient func(ctx context.Context) (systemdClient, error)
+}
+
+// newSystemdScraper creates a scraper reporting the state and resource usage of systemd units.
+func newSystemdScraper(settings receiver.CreateSettings, cfg *Config) (*scraper, error) {
+	s := &scraper{settings: settings, config: cfg, bootTime: host.BootTime, newClient: newDBusClient}
+
+	var err error
+
+	if len(cfg.Include.Units) > 0 {
+		s.includeFS, err = filterset.CreateFilterSet(cfg.Include.Units, &cfg.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating unit include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Exclude.Units) > 0 {
+		s.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Units, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating unit exclude filters: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *scraper) start(ctx context.Context, _ component.Host) error {
+	bootTime, err := s.bootTime()
+	if err != nil {
+		return err
+	}
+
+	s.client, err = s.newClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to systemd: %w", err)
+	}
+
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *scraper) shutdown(context.Context) error {
+	if s.client != nil {
+		s.client.Close()
+	}
+	return nil
+}
+
+func (s *scraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	units, err := s.client.ListUnits(ctx)
+	if err != nil {
+		return pmetric.NewMetrics(), err
+	}
+
+	var errs scrapererror.ScrapeErrors
+	for _, unit := range units {
+		if (s.includeFS != nil && !s.includeFS.Matches(unit.Name)) ||
+			(s.excludeFS != nil && s.excludeFS.Matches(unit.Name)) {
+			continue
+		}
+
+		s.recordStates(now, unit)
+
+		unitType := unit.Name[strings.LastIndex(unit.Name, ".")+1:]
+		if iface, ok := unitTypeInterfaces[unitType]; ok {
+			props, err := s.client.UnitTypeProperties(ctx, unit.Name, iface)
+			if err != nil {
+				errs.AddPartial(accountingMetricsLen, fmt.Errorf("error reading properties of unit %s: %w", unit.Name, err))
+			} else {
+				s.recordAccounting(now, props)
+			}
+		}
+
+		s.mb.EmitForResource(
+			metadata.WithSystemdUnitName(unit.Name),
+			metadata.WithSystemdUnitType(unitType),
+		)
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *scraper) recordStates(now pcommon.Timestamp, unit unitStatus) {
+	for state := metadata.AttributeActiveStateActive; state <= metadata.AttributeActiveStateDeactivating; state++ {
+		var value int64
+		if state.String() == unit.ActiveState {
+			value = 1
+		}
+		s.mb.RecordSystemdUnitActiveStateDataPoint(now, value, state)
+	}
+	s.mb.RecordSystemdUnitLoadStateDataPoint(now, 1, unit.LoadState)
+	s.mb.RecordSystemdUnitSubStateDataPoint(now, 1, unit.SubState)
+}
+
+// accountingValue returns the value of a resource accounting property. systemd reports
+// the maximum value when accounting is not enabled for the unit.
+func accountingValue(props map[string]interface{}, name string) (uint64, bool) {
+	value, ok := props[name].(uint64)
+	if !ok || value == math.MaxUint64 {
+		return 0, false
+	}
+	return value, true
+}
+
+func (s *scraper) recordAccounting(now pcommon.Timestamp, props map[string]interface{}) {
+	if restarts, ok := props["NRestarts"].(uint32); ok {
+		s.mb.RecordSystemdUnitRestartsDataPoint(now, int64(restarts))
+	}
+	if cpu, ok := accountingValue(props, "CPUUsageNSec"); ok {
+		s.mb.RecordSystemdUnitCPUTimeDataPoint(now, float64(cpu)/float64(time.Second))
+	}
+	if memory, ok := accountingValue(props, "MemoryCurrent"); ok {
+		s.mb.RecordSystemdUnitMemoryUsageDataPoint(now, int64(memory))
+	}
+	if tasks, ok := accountingValue(props, "TasksCurrent"); ok {
+		s.mb.RecordSystemdUnitTasksDataPoint(now, int64(tasks))
+	}
+	if read, ok := accountingValue(props, "IOReadBytes"); ok {
+		s.mb.RecordSystemdUnitIoDataPoint(now, int64(read), metadata.AttributeDirectionRead)
+	}
+	if written, ok := accountingValue(props, "IOWriteBytes"); ok {
+		s.mb.RecordSystemdUnitIoDataPoint(now, int64(written), metadata.AttributeDirectionWrite)
+	}
+}
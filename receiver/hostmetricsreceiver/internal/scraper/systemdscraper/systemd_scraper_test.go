@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemdscraper
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper/internal/metadata"
+)
+
+const bootTime = 100
+
+type fakeClient struct {
+	units      []unitStatus
+	listErr    error
+	properties map[string]map[string]interface{}
+	propsErr   map[string]error
+	closed     bool
+}
+
+func (c *fakeClient) ListUnits(context.Context) ([]unitStatus, error) {
+	return c.units, c.listErr
+}
+
+func (c *fakeClient) UnitTypeProperties(_ context.Context, name string, unitType string) (map[string]interface{}, error) {
+	if err := c.propsErr[name]; err != nil {
+		return nil, err
+	}
+	if unitType != "Service" && unitType != "Slice" {
+		return nil, errors.New("unexpected unit type " + unitType)
+	}
+	return c.properties[name], nil
+}
+
+func (c *fakeClient) Close() {
+	c.closed = true
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		units: []unitStatus{
+			{Name: "sshd.service", LoadState: "loaded", ActiveState: "active", SubState: "running"},
+			{Name: "backup.service", LoadState: "loaded", ActiveState: "failed", SubState: "failed"},
+			{Name: "system.slice", LoadState: "loaded", ActiveState: "active", SubState: "active"},
+			{Name: "multi-user.target", LoadState: "loaded", ActiveState: "active", SubState: "active"},
+		},
+		properties: map[string]map[string]interface{}{
+			"sshd.service": {
+				"NRestarts":     uint32(2),
+				"CPUUsageNSec":  uint64(1500000000),
+				"MemoryCurrent": uint64(4096),
+				"TasksCurrent":  uint64(3),
+				"IOReadBytes":   uint64(math.MaxUint64),
+				"IOWriteBytes":  uint64(math.MaxUint64),
+			},
+			"backup.service": {
+				"NRestarts":     uint32(5),
+				"CPUUsageNSec":  uint64(math.MaxUint64),
+				"MemoryCurrent": uint64(math.MaxUint64),
+				"TasksCurrent":  uint64(math.MaxUint64),
+			},
+			"system.slice": {
+				"CPUUsageNSec":  uint64(3000000000),
+				"MemoryCurrent": uint64(8192),
+			},
+		},
+	}
+}
+
+func newTestScraper(t *testing.T, cfg *Config, client systemdClient) *scraper {
+	cfg.MetricsBuilderConfig = metadata.DefaultMetricsBuilderConfig()
+	s, err := newSystemdScraper(receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	s.bootTime = func() (uint64, error) { return bootTime, nil }
+	s.newClient = func(context.Context) (systemdClient, error) { return client, nil }
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+// metricsByUnit indexes the scraped metrics by unit name and metric name.
+func metricsByUnit(t *testing.T, md pmetric.Metrics) map[string]map[string]pmetric.Metric {
+	out := map[string]map[string]pmetric.Metric{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		name, ok := rm.Resource().Attributes().Get("systemd.unit.name")
+		require.True(t, ok)
+		metrics := map[string]pmetric.Metric{}
+		for j := 0; j < rm.ScopeMetrics().At(0).Metrics().Len(); j++ {
+			m := rm.ScopeMetrics().At(0).Metrics().At(j)
+			metrics[m.Name()] = m
+		}
+		out[name.Str()] = metrics
+	}
+	return out
+}
+
+func activeStates(m pmetric.Metric) map[string]int64 {
+	states := map[string]int64{}
+	dps := m.Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		state, _ := dps.At(i).Attributes().Get("state")
+		states[state.Str()] = dps.At(i).IntValue()
+	}
+	return states
+}
+
+func TestScrape(t *testing.T) {
+	client := newFakeClient()
+	s := newTestScraper(t, &Config{}, client)
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	units := metricsByUnit(t, md)
+	require.Len(t, units, 4)
+
+	sshd := units["sshd.service"]
+	assert.Len(t, sshd, 7)
+	assert.Equal(t, map[string]int64{
+		"active": 1, "reloading": 0, "inactive": 0, "failed": 0, "activating": 0, "deactivating": 0,
+	}, activeStates(sshd["systemd.unit.active_state"]))
+	subState, _ := sshd["systemd.unit.sub_state"].Gauge().DataPoints().At(0).Attributes().Get("state")
+	assert.Equal(t, "running", subState.Str())
+	loadState, _ := sshd["systemd.unit.load_state"].Gauge().DataPoints().At(0).Attributes().Get("state")
+	assert.Equal(t, "loaded", loadState.Str())
+	assert.Equal(t, int64(2), sshd["systemd.unit.restarts"].Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, pcommon.Timestamp(bootTime*1e9), sshd["systemd.unit.restarts"].Sum().DataPoints().At(0).StartTimestamp())
+	assert.Equal(t, 1.5, sshd["systemd.unit.cpu.time"].Sum().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, int64(4096), sshd["systemd.unit.memory.usage"].Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(3), sshd["systemd.unit.tasks"].Sum().DataPoints().At(0).IntValue())
+
+	backup := units["backup.service"]
+	assert.Len(t, backup, 4)
+	assert.Equal(t, int64(1), activeStates(backup["systemd.unit.active_state"])["failed"])
+	assert.Equal(t, int64(5), backup["systemd.unit.restarts"].Sum().DataPoints().At(0).IntValue())
+
+	slice := units["system.slice"]
+	assert.Len(t, slice, 5)
+	assert.Equal(t, int64(8192), slice["systemd.unit.memory.usage"].Sum().DataPoints().At(0).IntValue())
+
+	assert.Len(t, units["multi-user.target"], 3)
+
+	require.NoError(t, s.shutdown(context.Background()))
+	assert.True(t, client.closed)
+}
+
+func TestScrapeFilters(t *testing.T) {
+	s := newTestScraper(t, &Config{
+		Include: MatchConfig{
+			Config: filterset.Config{MatchType: filterset.Regexp},
+			Units:  []string{".*\\.service$"},
+		},
+		Exclude: MatchConfig{
+			Config: filterset.Config{MatchType: filterset.Strict},
+			Units:  []string{"sshd.service"},
+		},
+	}, newFakeClient())
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	units := metricsByUnit(t, md)
+	assert.Len(t, units, 1)
+	assert.Contains(t, units, "backup.service")
+}
+
+func TestScrapeErrors(t *testing.T) {
+	client := newFakeClient()
+	client.propsErr = map[string]error{"sshd.service": errors.New("access denied")}
+	s := newTestScraper(t, &Config{}, client)
+
+	md, err := s.scrape(context.Background())
+	require.Error(t, err)
+
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, accountingMetricsLen, partialErr.Failed)
+	assert.ErrorContains(t, err, "error reading properties of unit sshd.service: access denied")
+	assert.Len(t, metricsByUnit(t, md)["sshd.service"], 3)
+
+	client.listErr = errors.New("connection closed")
+	_, err = s.scrape(context.Background())
+	assert.EqualError(t, err, "connection closed")
+}
+
+func TestStartError(t *testing.T) {
+	s, err := newSystemdScraper(receivertest.NewNopCreateSettings(), &Config{})
+	require.NoError(t, err)
+	s.bootTime = func() (uint64, error) { return bootTime, nil }
+	s.newClient = func(context.Context) (systemdClient, error) { return nil, errors.New("no bus") }
+
+	assert.EqualError(t, s.start(context.Background(), componenttest.NewNopHost()), "failed to connect to systemd: no bus")
+	assert.NoError(t, s.shutdown(context.Background()))
+}
@@ -61,6 +61,14 @@ type Config struct {
 
 	TargetAllocator *targetAllocator `mapstructure:"target_allocator"`
 
+	// ScrapeConfigFilesReloadInterval is the interval at which the scrape_config_files of the Prometheus
+	// configuration are reloaded. Only the scrape jobs whose configuration changed are restarted.
+	// Reloading is disabled if zero.
+	ScrapeConfigFilesReloadInterval time.Duration `mapstructure:"scrape_config_files_reload_interval"`
+
+	// Sharding splits the targets of the scrape jobs between several receivers, e.g. the replicas of a StatefulSet.
+	Sharding *sharding `mapstructure:"sharding"`
+
 	// ConfigPlaceholder is just an entry to make the configuration pass a check
 	// that requires that all keys present in the config actually exist on the
 	// structure, ie.: it will error if an unknown key is present.
@@ -78,6 +86,16 @@ type targetAllocator struct {
 	HTTPSDConfig      *promHTTP.SDConfig `mapstructure:"-"`
 }
 
+type sharding struct {
+	// ShardCount is the number of shards the targets are split into.
+	// If zero, it is read from the SHARD_COUNT environment variable.
+	ShardCount int `mapstructure:"shard_count"`
+	// ShardIndex is the shard scraped by this receiver, from 0 to ShardCount - 1.
+	// If not set, it is read from the SHARD environment variable, which can also be
+	// the name of a StatefulSet pod, e.g. "otel-collector-2".
+	ShardIndex *int `mapstructure:"shard_index"`
+}
+
 var _ component.Config = (*Config)(nil)
 var _ confmap.Unmarshaler = (*Config)(nil)
 
@@ -153,11 +171,22 @@ func (cfg *Config) Validate() error {
 			return err
 		}
 	}
+
+	if cfg.Sharding != nil {
+		err := cfg.validateShardingConfig()
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfg.ScrapeConfigFilesReloadInterval < 0 {
+		return errors.New("scrape_config_files_reload_interval can't be negative")
+	}
 	return nil
 }
 
 func (cfg *Config) validatePromConfig(promConfig *promconfig.Config) error {
-	if len(promConfig.ScrapeConfigs) == 0 && cfg.TargetAllocator == nil {
+	if len(promConfig.ScrapeConfigs) == 0 && len(promConfig.ScrapeConfigFiles) == 0 && cfg.TargetAllocator == nil {
 		return errors.New("no Prometheus scrape_configs or target_allocator set")
 	}
 
@@ -188,46 +217,55 @@ func (cfg *Config) validatePromConfig(promConfig *promconfig.Config) error {
 	}
 
 	for _, sc := range cfg.PrometheusConfig.ScrapeConfigs {
-		for _, rc := range sc.MetricRelabelConfigs {
-			if rc.TargetLabel == "__name__" {
-				// TODO(#2297): Remove validation after renaming is fixed
-				return fmt.Errorf("error validating scrapeconfig for job %v: %w", sc.JobName, errRenamingDisallowed)
-			}
+		if err := validateScrapeConfig(sc); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		if sc.HTTPClientConfig.Authorization != nil {
-			if err := checkFile(sc.HTTPClientConfig.Authorization.CredentialsFile); err != nil {
-				return fmt.Errorf("error checking authorization credentials file %q: %w", sc.HTTPClientConfig.Authorization.CredentialsFile, err)
-			}
+// validateScrapeConfig rejects the scrape jobs the receiver doesn't support, and checks the files they refer to.
+// It also validates the jobs loaded from scrape_config_files, which are only known once the files are read.
+func validateScrapeConfig(sc *promconfig.ScrapeConfig) error {
+	for _, rc := range sc.MetricRelabelConfigs {
+		if rc.TargetLabel == "__name__" {
+			// TODO(#2297): Remove validation after renaming is fixed
+			return fmt.Errorf("error validating scrapeconfig for job %v: %w", sc.JobName, errRenamingDisallowed)
 		}
+	}
 
-		if err := checkTLSConfig(sc.HTTPClientConfig.TLSConfig); err != nil {
-			return err
+	if sc.HTTPClientConfig.Authorization != nil {
+		if err := checkFile(sc.HTTPClientConfig.Authorization.CredentialsFile); err != nil {
+			return fmt.Errorf("error checking authorization credentials file %q: %w", sc.HTTPClientConfig.Authorization.CredentialsFile, err)
 		}
+	}
 
-		for _, c := range sc.ServiceDiscoveryConfigs {
-			switch c := c.(type) {
-			case *kubernetes.SDConfig:
-				if err := checkTLSConfig(c.HTTPClientConfig.TLSConfig); err != nil {
+	if err := checkTLSConfig(sc.HTTPClientConfig.TLSConfig); err != nil {
+		return err
+	}
+
+	for _, c := range sc.ServiceDiscoveryConfigs {
+		switch c := c.(type) {
+		case *kubernetes.SDConfig:
+			if err := checkTLSConfig(c.HTTPClientConfig.TLSConfig); err != nil {
+				return err
+			}
+		case *file.SDConfig:
+			for _, file := range c.Files {
+				files, err := filepath.Glob(file)
+				if err != nil {
 					return err
 				}
-			case *file.SDConfig:
-				for _, file := range c.Files {
-					files, err := filepath.Glob(file)
-					if err != nil {
-						return err
-					}
-					if len(files) != 0 {
-						for _, f := range files {
-							err = checkSDFile(f)
-							if err != nil {
-								return fmt.Errorf("checking SD file %q: %w", file, err)
-							}
+				if len(files) != 0 {
+					for _, f := range files {
+						err = checkSDFile(f)
+						if err != nil {
+							return fmt.Errorf("checking SD file %q: %w", file, err)
 						}
-						continue
 					}
-					return fmt.Errorf("file %q for file_sd in scrape job %q does not exist", file, sc.JobName)
+					continue
 				}
+				return fmt.Errorf("file %q for file_sd in scrape job %q does not exist", file, sc.JobName)
 			}
 		}
 	}
@@ -252,6 +290,26 @@ func (cfg *Config) validateTargetAllocatorConfig() error {
 	return nil
 }
 
+func (cfg *Config) validateShardingConfig() error {
+	shardingConfig := cfg.Sharding
+	// the target allocator already assigns the targets to the collectors
+	if cfg.TargetAllocator != nil {
+		return errors.New("sharding can't be used with target_allocator")
+	}
+	if shardingConfig.ShardCount < 0 {
+		return fmt.Errorf("shard_count can't be negative: %d", shardingConfig.ShardCount)
+	}
+	if shardingConfig.ShardIndex != nil {
+		if *shardingConfig.ShardIndex < 0 {
+			return fmt.Errorf("shard_index can't be negative: %d", *shardingConfig.ShardIndex)
+		}
+		if shardingConfig.ShardCount > 0 && *shardingConfig.ShardIndex >= shardingConfig.ShardCount {
+			return fmt.Errorf("shard_index %d must be lower than shard_count %d", *shardingConfig.ShardIndex, shardingConfig.ShardCount)
+		}
+	}
+	return nil
+}
+
 // Unmarshal a config.Parser into the config struct.
 func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 	if componentParser == nil {
@@ -101,6 +101,73 @@ func TestLoadTargetAllocatorConfig(t *testing.T) {
 	assert.Equal(t, promModel.Duration(5*time.Second), r2.PrometheusConfig.ScrapeConfigs[0].ScrapeInterval)
 }
 
+func TestLoadShardingConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_sharding.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(typeStr, "").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	r0 := cfg.(*Config)
+	assert.Equal(t, 30*time.Second, r0.ScrapeConfigFilesReloadInterval)
+	assert.Equal(t, []string{"testdata/scrape_configs/*.yaml"}, r0.PrometheusConfig.ScrapeConfigFiles)
+	assert.Equal(t, 3, r0.Sharding.ShardCount)
+	require.NotNil(t, r0.Sharding.ShardIndex)
+	assert.Equal(t, 1, *r0.Sharding.ShardIndex)
+
+	sub, err = cm.Sub(component.NewIDWithName(typeStr, "env").String())
+	require.NoError(t, err)
+	cfg = factory.CreateDefaultConfig()
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	r1 := cfg.(*Config)
+	require.NotNil(t, r1.Sharding)
+	assert.Equal(t, 0, r1.Sharding.ShardCount)
+	assert.Nil(t, r1.Sharding.ShardIndex)
+}
+
+func TestInvalidShardingConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_sharding.yaml"))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name    string
+		wantErr string
+	}{
+		{
+			name:    "negative_shard_count",
+			wantErr: "shard_count can't be negative: -1",
+		},
+		{
+			name:    "shard_index_out_of_range",
+			wantErr: "shard_index 3 must be lower than shard_count 3",
+		},
+		{
+			name:    "with_target_allocator",
+			wantErr: "sharding can't be used with target_allocator",
+		},
+		{
+			name:    "negative_reload_interval",
+			wantErr: "scrape_config_files_reload_interval can't be negative",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(component.NewIDWithName(typeStr, tc.name).String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+			assert.EqualError(t, component.ValidateConfig(cfg), tc.wantErr)
+		})
+	}
+}
+
 func TestLoadConfigFailsOnUnknownSection(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "invalid-config-section.yaml"))
 	require.NoError(t, err)
@@ -21,7 +21,9 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/scrape"
@@ -61,6 +63,7 @@ type metricGroup struct {
 	created      float64
 	value        float64
 	complexValue []*dataPoint
+	fhValue      *histogram.FloatHistogram
 	exemplars    pmetric.ExemplarSlice
 }
 
@@ -157,6 +160,45 @@ func (mg *metricGroup) toDistributionPoint(dest pmetric.HistogramDataPointSlice)
 	mg.setExemplars(point.Exemplars())
 }
 
+func (mg *metricGroup) toExponentialHistogramDataPoint(dest pmetric.ExponentialHistogramDataPointSlice) {
+	if mg.fhValue == nil {
+		return
+	}
+	fh := mg.fhValue
+
+	point := dest.AppendEmpty()
+
+	if value.IsStaleNaN(fh.Sum) {
+		point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	} else {
+		point.SetScale(fh.Schema)
+		point.SetCount(uint64(fh.Count))
+		point.SetSum(fh.Sum)
+		// OTLP has no zero threshold, observations within the threshold are counted as zeros.
+		point.SetZeroCount(uint64(fh.ZeroCount))
+		convertNativeHistogramBuckets(fh.PositiveSpans, fh.PositiveBuckets, point.Positive())
+		convertNativeHistogramBuckets(fh.NegativeSpans, fh.NegativeBuckets, point.Negative())
+	}
+
+	// The timestamp MUST be in retrieved from milliseconds and converted to nanoseconds.
+	tsNanos := timestampFromMs(mg.ts)
+	// metrics_adjuster adjusts the startTimestamp to the initial scrape timestamp
+	point.SetStartTimestamp(tsNanos)
+	point.SetTimestamp(tsNanos)
+	populateAttributes(pmetric.MetricTypeExponentialHistogram, mg.ls, point.Attributes())
+	mg.setExemplars(point.Exemplars())
+}
+
+// convertNativeHistogramBuckets converts the bucket spans of a native histogram before expanding them into the
+// dense buckets of an exponential histogram.
+func convertNativeHistogramBuckets(spans []histogram.Span, counts []float64, dest pmetric.ExponentialHistogramDataPointBuckets) {
+	bucketSpans := make([]prometheus.BucketSpan, len(spans))
+	for i, span := range spans {
+		bucketSpans[i] = prometheus.BucketSpan(span)
+	}
+	prometheus.ConvertNativeHistogramBuckets(bucketSpans, counts, dest)
+}
+
 func (mg *metricGroup) setExemplars(exemplars pmetric.ExemplarSlice) {
 	if mg == nil {
 		return
@@ -291,12 +333,22 @@ func (mf *metricFamily) addSeries(seriesRef uint64, metricName string, ls labels
 		case strings.HasSuffix(metricName, metricSuffixCreated):
 			mg.created = v
 		default:
+			if mf.mtype == pmetric.MetricTypeHistogram && value.IsStaleNaN(v) && ls.Get(model.BucketLabel) == "" {
+				// staleness markers of native histograms are appended as floats, without any suffix.
+				mf.mtype = pmetric.MetricTypeExponentialHistogram
+				mg.fhValue = &histogram.FloatHistogram{Sum: v}
+				return nil
+			}
 			boundary, err := getBoundary(mf.mtype, ls)
 			if err != nil {
 				return err
 			}
 			mg.complexValue = append(mg.complexValue, &dataPoint{value: v, boundary: boundary})
 		}
+	case pmetric.MetricTypeExponentialHistogram:
+		if value.IsStaleNaN(v) {
+			mg.fhValue = &histogram.FloatHistogram{Sum: v}
+		}
 	case pmetric.MetricTypeSum:
 		if strings.HasSuffix(metricName, metricSuffixCreated) {
 			mg.created = v
@@ -310,6 +362,24 @@ func (mf *metricFamily) addSeries(seriesRef uint64, metricName string, ls labels
 	return nil
 }
 
+func (mf *metricFamily) addExponentialHistogramSeries(seriesRef uint64, metricName string, ls labels.Labels, t int64, fh *histogram.FloatHistogram) error {
+	switch mf.mtype {
+	case pmetric.MetricTypeHistogram, pmetric.MetricTypeEmpty, pmetric.MetricTypeExponentialHistogram:
+		// native histograms share the metadata of classic histograms.
+		mf.mtype = pmetric.MetricTypeExponentialHistogram
+	default:
+		return fmt.Errorf("unexpected native histogram for metric %v of type %v", metricName, mf.mtype)
+	}
+
+	mg := mf.loadMetricGroupOrCreate(seriesRef, ls, t)
+	if mg.ts != t {
+		return fmt.Errorf("inconsistent timestamps on metric points for metric %v", metricName)
+	}
+	mg.mtype = mf.mtype
+	mg.fhValue = fh
+	return nil
+}
+
 func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice, normalizer *prometheus.Normalizer) {
 	metric := pmetric.NewMetric()
 	// Trims type's and unit's suffixes from metric name
@@ -329,6 +399,15 @@ func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice, normalizer *pr
 		}
 		pointCount = hdpL.Len()
 
+	case pmetric.MetricTypeExponentialHistogram:
+		expHistogram := metric.SetEmptyExponentialHistogram()
+		expHistogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		hdpL := expHistogram.DataPoints()
+		for _, mg := range mf.groupOrders {
+			mg.toExponentialHistogramDataPoint(hdpL)
+		}
+		pointCount = hdpL.Len()
+
 	case pmetric.MetricTypeSummary:
 		summary := metric.SetEmptySummary()
 		sdpL := summary.DataPoints()
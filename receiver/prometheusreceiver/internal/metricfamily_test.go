@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/textparse"
 	"github.com/prometheus/prometheus/model/value"
@@ -279,6 +280,122 @@ func TestMetricGroupData_toDistributionUnitTest(t *testing.T) {
 	}
 }
 
+func TestMetricGroupData_toExponentialHistogramUnitTest(t *testing.T) {
+	tests := []struct {
+		name       string
+		metricName string
+		labels     labels.Labels
+		fh         *histogram.FloatHistogram
+		staleValue bool
+		want       func() pmetric.ExponentialHistogramDataPoint
+	}{
+		{
+			name:       "native histogram",
+			metricName: "histogram",
+			labels:     labels.FromMap(map[string]string{"a": "A", "b": "B"}),
+			fh: &histogram.FloatHistogram{
+				Schema:          1,
+				ZeroThreshold:   0.001,
+				ZeroCount:       2,
+				Count:           12,
+				Sum:             42.5,
+				PositiveSpans:   []histogram.Span{{Offset: -1, Length: 2}, {Offset: 2, Length: 1}},
+				PositiveBuckets: []float64{1, 2, 3},
+				NegativeSpans:   []histogram.Span{{Offset: 0, Length: 1}},
+				NegativeBuckets: []float64{4},
+			},
+			want: func() pmetric.ExponentialHistogramDataPoint {
+				point := pmetric.NewExponentialHistogramDataPoint()
+				point.SetScale(1)
+				point.SetCount(12)
+				point.SetSum(42.5)
+				point.SetZeroCount(2)
+				point.Positive().SetOffset(-2)
+				point.Positive().BucketCounts().FromRaw([]uint64{1, 2, 0, 0, 3})
+				point.Negative().SetOffset(-1)
+				point.Negative().BucketCounts().FromRaw([]uint64{4})
+				point.SetTimestamp(pcommon.Timestamp(11 * time.Millisecond)) // the time in milliseconds -> nanoseconds.
+				point.SetStartTimestamp(pcommon.Timestamp(11 * time.Millisecond))
+				attributes := point.Attributes()
+				attributes.PutStr("a", "A")
+				attributes.PutStr("b", "B")
+				return point
+			},
+		},
+		{
+			name:       "native histogram without buckets",
+			metricName: "histogram",
+			labels:     labels.FromMap(map[string]string{"a": "A"}),
+			fh: &histogram.FloatHistogram{
+				Schema:    -2,
+				ZeroCount: 3,
+				Count:     3,
+			},
+			want: func() pmetric.ExponentialHistogramDataPoint {
+				point := pmetric.NewExponentialHistogramDataPoint()
+				point.SetScale(-2)
+				point.SetCount(3)
+				point.SetSum(0)
+				point.SetZeroCount(3)
+				point.SetTimestamp(pcommon.Timestamp(11 * time.Millisecond)) // the time in milliseconds -> nanoseconds.
+				point.SetStartTimestamp(pcommon.Timestamp(11 * time.Millisecond))
+				point.Attributes().PutStr("a", "A")
+				return point
+			},
+		},
+		{
+			name:       "native histogram that is stale",
+			metricName: "histogram_stale",
+			labels:     labels.FromMap(map[string]string{"a": "A", "b": "B"}),
+			staleValue: true,
+			want: func() pmetric.ExponentialHistogramDataPoint {
+				point := pmetric.NewExponentialHistogramDataPoint()
+				point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+				point.SetTimestamp(pcommon.Timestamp(11 * time.Millisecond)) // the time in milliseconds -> nanoseconds.
+				point.SetStartTimestamp(pcommon.Timestamp(11 * time.Millisecond))
+				attributes := point.Attributes()
+				attributes.PutStr("a", "A")
+				attributes.PutStr("b", "B")
+				return point
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mp := newMetricFamily(tt.metricName, mc, zap.NewNop())
+			sRef, _ := getSeriesRef(nil, tt.labels, pmetric.MetricTypeExponentialHistogram)
+			if tt.staleValue {
+				require.NoError(t, mp.addSeries(sRef, tt.metricName, tt.labels, 11, math.Float64frombits(value.StaleNaN)))
+			} else {
+				require.NoError(t, mp.addExponentialHistogramSeries(sRef, tt.metricName, tt.labels, 11, tt.fh))
+			}
+
+			require.Len(t, mp.groups, 1)
+
+			sl := pmetric.NewMetricSlice()
+			mp.appendMetric(sl, prometheus.NewNormalizer(featuregate.GlobalRegistry()))
+
+			require.Equal(t, 1, sl.Len(), "Exactly one metric expected")
+			metric := sl.At(0)
+			require.Equal(t, pmetric.MetricTypeExponentialHistogram, metric.Type())
+			require.Equal(t, pmetric.AggregationTemporalityCumulative, metric.ExponentialHistogram().AggregationTemporality())
+
+			hdpL := metric.ExponentialHistogram().DataPoints()
+			require.Equal(t, 1, hdpL.Len(), "Exactly one point expected")
+			require.Equal(t, tt.want(), hdpL.At(0), "Expected the points to be equal")
+		})
+	}
+}
+
+func TestMetricFamily_addExponentialHistogramSeriesTypeMismatch(t *testing.T) {
+	mp := newMetricFamily("counter", mc, zap.NewNop())
+	ls := labels.FromStrings("a", "A")
+	sRef, _ := getSeriesRef(nil, ls, mp.mtype)
+	require.Error(t, mp.addExponentialHistogramSeries(sRef, "counter", ls, 11, &histogram.FloatHistogram{Count: 1}))
+}
+
 func TestMetricGroupData_toSummaryUnitTest(t *testing.T) {
 	type scrape struct {
 		at     int64
@@ -39,6 +39,7 @@ var (
 	sum1       = "sum1"
 	gauge1     = "gauge1"
 	histogram1 = "histogram1"
+	expHist1   = "exphistogram1"
 	summary1   = "summary1"
 
 	k1v1k2v2 = []*kv{
@@ -223,6 +224,46 @@ func TestHistogram(t *testing.T) {
 	runScript(t, NewInitialPointAdjuster(zap.NewNop(), time.Minute, true), "job", "0", script)
 }
 
+func TestExponentialHistogram(t *testing.T) {
+	script := []*metricsAdjusterTest{
+		{
+			description: "Exponential Histogram: round 1 - initial instance, start time is established",
+			metrics:     metrics(exponentialHistogramMetric(expHist1, exponentialHistogramPoint(k1v1k2v2, t1, t1, 3, 1, []uint64{4, 2, 3, 7}))),
+			adjusted:    metrics(exponentialHistogramMetric(expHist1, exponentialHistogramPoint(k1v1k2v2, t1, t1, 3, 1, []uint64{4, 2, 3, 7}))),
+		}, {
+			description: "Exponential Histogram: round 2 - instance adjusted based on round 1",
+			metrics:     metrics(exponentialHistogramMetric(expHist1, exponentialHistogramPoint(k1v1k2v2, t2, t2, 3, 1, []uint64{6, 3, 4, 8}))),
+			adjusted:    metrics(exponentialHistogramMetric(expHist1, exponentialHistogramPoint(k1v1k2v2, t1, t2, 3, 1, []uint64{6, 3, 4, 8}))),
+		}, {
+			description: "Exponential Histogram: round 3 - instance reset (value less than previous value), start time is reset",
+			metrics:     metrics(exponentialHistogramMetric(expHist1, exponentialHistogramPoint(k1v1k2v2, t3, t3, 3, 1, []uint64{5, 3, 2, 7}))),
+			adjusted:    metrics(exponentialHistogramMetric(expHist1, exponentialHistogramPoint(k1v1k2v2, t3, t3, 3, 1, []uint64{5, 3, 2, 7}))),
+		}, {
+			description: "Exponential Histogram: round 4 - instance adjusted based on round 3",
+			metrics:     metrics(exponentialHistogramMetric(expHist1, exponentialHistogramPoint(k1v1k2v2, t4, t4, 3, 1, []uint64{7, 4, 2, 12}))),
+			adjusted:    metrics(exponentialHistogramMetric(expHist1, exponentialHistogramPoint(k1v1k2v2, t3, t4, 3, 1, []uint64{7, 4, 2, 12}))),
+		},
+	}
+	runScript(t, NewInitialPointAdjuster(zap.NewNop(), time.Minute, true), "job", "0", script)
+}
+
+func TestExponentialHistogramFlagNoRecordedValue(t *testing.T) {
+	script := []*metricsAdjusterTest{
+		{
+			description: "Exponential Histogram: round 1 - initial instance, start time is established",
+			metrics:     metrics(exponentialHistogramMetric(expHist1, exponentialHistogramPoint(k1v1k2v2, t1, t1, 0, 2, []uint64{7, 4, 2, 12}))),
+			adjusted:    metrics(exponentialHistogramMetric(expHist1, exponentialHistogramPoint(k1v1k2v2, t1, t1, 0, 2, []uint64{7, 4, 2, 12}))),
+		},
+		{
+			description: "Exponential Histogram: round 2 - instance adjusted based on round 1",
+			metrics:     metrics(exponentialHistogramMetric(expHist1, exponentialHistogramPointNoValue(k1v1k2v2, tUnknown, t2))),
+			adjusted:    metrics(exponentialHistogramMetric(expHist1, exponentialHistogramPointNoValue(k1v1k2v2, t1, t2))),
+		},
+	}
+
+	runScript(t, NewInitialPointAdjuster(zap.NewNop(), time.Minute, true), "job", "0", script)
+}
+
 func TestHistogramFlagNoRecordedValue(t *testing.T) {
 	script := []*metricsAdjusterTest{
 		{
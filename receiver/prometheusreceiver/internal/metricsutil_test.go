@@ -15,6 +15,8 @@
 package internal
 
 import (
+	"math"
+
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
@@ -89,6 +91,59 @@ func histogramMetric(name string, points ...pmetric.HistogramDataPoint) pmetric.
 	return metric
 }
 
+func exponentialHistogramPointRaw(attributes []*kv, startTimestamp, timestamp pcommon.Timestamp) pmetric.ExponentialHistogramDataPoint {
+	hdp := pmetric.NewExponentialHistogramDataPoint()
+	hdp.SetStartTimestamp(startTimestamp)
+	hdp.SetTimestamp(timestamp)
+
+	attrs := hdp.Attributes()
+	for _, kv := range attributes {
+		attrs.PutStr(kv.Key, kv.Value)
+	}
+
+	return hdp
+}
+
+func exponentialHistogramPoint(attributes []*kv, startTimestamp, timestamp pcommon.Timestamp, zeroCount uint64, offset int32, counts []uint64) pmetric.ExponentialHistogramDataPoint {
+	hdp := exponentialHistogramPointRaw(attributes, startTimestamp, timestamp)
+	hdp.SetZeroCount(zeroCount)
+	hdp.Positive().SetOffset(offset)
+	hdp.Positive().BucketCounts().FromRaw(counts)
+
+	count := zeroCount
+	var sum float64
+	for i, bcount := range counts {
+		count += bcount
+		sum += float64(bcount) * math.Pow(2, float64(offset)+float64(i)+1)
+	}
+	hdp.SetCount(count)
+	hdp.SetSum(sum)
+
+	return hdp
+}
+
+func exponentialHistogramPointNoValue(attributes []*kv, startTimestamp, timestamp pcommon.Timestamp) pmetric.ExponentialHistogramDataPoint {
+	hdp := exponentialHistogramPointRaw(attributes, startTimestamp, timestamp)
+	hdp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+
+	return hdp
+}
+
+func exponentialHistogramMetric(name string, points ...pmetric.ExponentialHistogramDataPoint) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	histogram := metric.SetEmptyExponentialHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+	destPointL := histogram.DataPoints()
+	for _, point := range points {
+		destPoint := destPointL.AppendEmpty()
+		point.CopyTo(destPoint)
+	}
+
+	return metric
+}
+
 func doublePointRaw(attributes []*kv, startTimestamp, timestamp pcommon.Timestamp) pmetric.NumberDataPoint {
 	ndp := pmetric.NewNumberDataPoint()
 	ndp.SetStartTimestamp(startTimestamp)
@@ -79,6 +79,13 @@ func (stma *startTimeMetricAdjuster) AdjustMetrics(metrics pmetric.Metrics) erro
 						dp.SetStartTimestamp(startTimeTs)
 					}
 
+				case pmetric.MetricTypeExponentialHistogram:
+					dataPoints := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dataPoints.Len(); l++ {
+						dp := dataPoints.At(l)
+						dp.SetStartTimestamp(startTimeTs)
+					}
+
 				default:
 					stma.logger.Warn("Unknown metric type", zap.String("type", metric.Type().String()))
 				}
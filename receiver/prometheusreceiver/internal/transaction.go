@@ -190,9 +190,50 @@ func (t *transaction) AppendExemplar(ref storage.SeriesRef, l labels.Labels, e e
 	return 0, nil
 }
 
-func (t *transaction) AppendHistogram(ref storage.SeriesRef, l labels.Labels, atMs int64, h *histogram.Histogram, fh *histogram.FloatHistogram) (storage.SeriesRef, error) {
-	//TODO: implement this func
-	return 0, nil
+// AppendHistogram converts native histograms, only scraped when protobuf negotiation is enabled,
+// into exponential histograms. Always returns 0 to disable label caching.
+func (t *transaction) AppendHistogram(ref storage.SeriesRef, ls labels.Labels, atMs int64, h *histogram.Histogram, fh *histogram.FloatHistogram) (storage.SeriesRef, error) {
+	select {
+	case <-t.ctx.Done():
+		return 0, errTransactionAborted
+	default:
+	}
+
+	if len(t.externalLabels) != 0 {
+		ls = append(ls, t.externalLabels...)
+		sort.Sort(ls)
+	}
+
+	if t.isNew {
+		if err := t.initTransaction(ls); err != nil {
+			return 0, err
+		}
+	}
+
+	if dupLabel, hasDup := ls.HasDuplicateLabelNames(); hasDup {
+		return 0, fmt.Errorf("invalid sample: non-unique label names: %q", dupLabel)
+	}
+
+	metricName := ls.Get(model.MetricNameLabel)
+	if metricName == "" {
+		return 0, errMetricNameNotFound
+	}
+
+	if fh == nil {
+		if h == nil {
+			return 0, nil
+		}
+		fh = h.ToFloat()
+	}
+
+	// dropping support for gauge histograms, as for classic histograms.
+	if fh.CounterResetHint == histogram.GaugeType {
+		return 0, nil
+	}
+
+	curMF := t.getOrCreateMetricFamily(metricName)
+
+	return 0, curMF.addExponentialHistogramSeries(t.getSeriesRef(ls, pmetric.MetricTypeExponentialHistogram), metricName, ls, atMs, fh)
 }
 
 func (t *transaction) getSeriesRef(ls labels.Labels, mtype pmetric.MetricType) uint64 {
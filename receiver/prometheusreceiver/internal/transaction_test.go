@@ -22,6 +22,7 @@ import (
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/metadata"
 	"github.com/prometheus/prometheus/scrape"
@@ -213,6 +214,67 @@ func TestTransactionAppendSummaryNoQuantile(t *testing.T) {
 	require.ErrorIs(t, err, errEmptyQuantileLabel)
 }
 
+func TestTransactionAppendNativeHistogram(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), featuregate.GlobalRegistry())
+
+	ls := labels.FromStrings(
+		model.InstanceLabel, "localhost:8080",
+		model.JobLabel, "test",
+		model.MetricNameLabel, "hist_test",
+		"foo", "bar",
+	)
+	h := &histogram.Histogram{
+		Schema:          0,
+		ZeroCount:       1,
+		Count:           6,
+		Sum:             11,
+		PositiveSpans:   []histogram.Span{{Offset: 1, Length: 2}},
+		PositiveBuckets: []int64{2, 1}, // delta encoded: 2, 3
+	}
+
+	_, err := tr.AppendHistogram(0, ls, ts, h, nil)
+	require.NoError(t, err)
+	require.NoError(t, tr.Commit())
+
+	mds := sink.AllMetrics()
+	require.Len(t, mds, 1)
+	metrics := mds[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	metric := metrics.At(0)
+	assert.Equal(t, "hist_test", metric.Name())
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, metric.Type())
+	require.Equal(t, 1, metric.ExponentialHistogram().DataPoints().Len())
+
+	point := metric.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, tsNanos, point.Timestamp())
+	assert.Equal(t, int32(0), point.Scale())
+	assert.Equal(t, uint64(6), point.Count())
+	assert.Equal(t, 11.0, point.Sum())
+	assert.Equal(t, uint64(1), point.ZeroCount())
+	assert.Equal(t, int32(0), point.Positive().Offset())
+	assert.Equal(t, []uint64{2, 3}, point.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, 0, point.Negative().BucketCounts().Len())
+	assert.Equal(t, map[string]any{"foo": "bar"}, point.Attributes().AsRaw())
+}
+
+func TestTransactionAppendGaugeNativeHistogram(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), featuregate.GlobalRegistry())
+
+	ls := labels.FromStrings(
+		model.InstanceLabel, "localhost:8080",
+		model.JobLabel, "test",
+		model.MetricNameLabel, "hist_test",
+	)
+	fh := &histogram.FloatHistogram{CounterResetHint: histogram.GaugeType, Count: 1, ZeroCount: 1}
+
+	_, err := tr.AppendHistogram(0, ls, ts, nil, fh)
+	require.NoError(t, err)
+	assert.ErrorIs(t, tr.Commit(), errNoDataToBuild)
+	assert.Empty(t, sink.AllMetrics())
+}
+
 func TestAppendExemplarWithNoMetricName(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
 	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, receivertest.NewNopCreateSettings(), nopObsRecv(t), featuregate.GlobalRegistry())
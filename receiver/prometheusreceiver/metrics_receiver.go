@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"reflect"
 	"regexp"
 	"sync"
 	"time"
@@ -61,6 +62,13 @@ type pReceiver struct {
 	registry         *featuregate.Registry
 	scrapeManager    *scrape.Manager
 	discoveryManager *discovery.Manager
+
+	shardIndex int
+	shardCount int
+
+	// configMu serializes the updates of the scrape configuration
+	configMu             sync.Mutex
+	appliedScrapeConfigs []*config.ScrapeConfig
 }
 
 // New creates a new prometheus.Receiver reference.
@@ -87,13 +95,24 @@ func (r *pReceiver) Start(_ context.Context, host component.Host) error {
 	// add scrape configs defined by the collector configs
 	baseCfg := r.cfg.PrometheusConfig
 
+	if r.cfg.Sharding != nil {
+		var err error
+		r.shardIndex, r.shardCount, err = r.cfg.Sharding.resolve()
+		if err != nil {
+			return err
+		}
+		r.settings.Logger.Info("Scraping the targets of a shard", zap.Int("shardIndex", r.shardIndex), zap.Int("shardCount", r.shardCount))
+	}
+
 	err := r.initPrometheusComponents(discoveryCtx, host, logger)
 	if err != nil {
 		r.settings.Logger.Error("Failed to initPrometheusComponents Prometheus components", zap.Error(err))
 		return err
 	}
 
+	r.configMu.Lock()
 	err = r.applyCfg(baseCfg)
+	r.configMu.Unlock()
 	if err != nil {
 		r.settings.Logger.Error("Failed to apply new scrape configuration", zap.Error(err))
 		return err
@@ -107,6 +126,10 @@ func (r *pReceiver) Start(_ context.Context, host component.Host) error {
 		}
 	}
 
+	if r.cfg.ScrapeConfigFilesReloadInterval > 0 && len(baseCfg.ScrapeConfigFiles) != 0 {
+		r.startScrapeConfigFilesReload(discoveryCtx, baseCfg)
+	}
+
 	r.loadConfigOnce.Do(func() {
 		close(r.configLoaded)
 	})
@@ -142,6 +165,29 @@ func (r *pReceiver) startTargetAllocator(allocConf *targetAllocator, baseCfg *co
 	return nil
 }
 
+// startScrapeConfigFilesReload periodically reloads the scrape_config_files of baseCfg until ctx is done.
+func (r *pReceiver) startScrapeConfigFilesReload(ctx context.Context, baseCfg *config.Config) {
+	r.settings.Logger.Info("Starting scrape config files reload", zap.Duration("interval", r.cfg.ScrapeConfigFilesReloadInterval))
+	go func() {
+		reloadIntervalTicker := time.NewTicker(r.cfg.ScrapeConfigFilesReloadInterval)
+		defer reloadIntervalTicker.Stop()
+		for {
+			select {
+			case <-reloadIntervalTicker.C:
+				r.configMu.Lock()
+				err := r.applyCfg(baseCfg)
+				r.configMu.Unlock()
+				if err != nil {
+					r.settings.Logger.Error("Failed to reload scrape config files", zap.Error(err))
+				}
+			case <-ctx.Done():
+				r.settings.Logger.Info("Stopping scrape config files reload")
+				return
+			}
+		}
+	}()
+}
+
 // syncTargetAllocator request jobs from targetAllocator and update underlying receiver, if the response does not match the provided compareHash.
 // baseDiscoveryCfg can be used to provide additional ScrapeConfigs which will be added to the retrieved jobs.
 func (r *pReceiver) syncTargetAllocator(compareHash uint64, allocConf *targetAllocator, baseCfg *config.Config) (uint64, error) {
@@ -162,6 +208,9 @@ func (r *pReceiver) syncTargetAllocator(compareHash uint64, allocConf *targetAll
 		return hash, nil
 	}
 
+	r.configMu.Lock()
+	defer r.configMu.Unlock()
+
 	// Clear out the current configurations
 	baseCfg.ScrapeConfigs = []*config.ScrapeConfig{}
 
@@ -232,19 +281,45 @@ func (r *pReceiver) getScrapeConfigsResponse(baseURL string) (map[string]*config
 	return jobToScrapeConfig, nil
 }
 
+// applyCfg updates the scrape and discovery managers with the scrape configs of cfg, including the ones of its
+// scrape_config_files. The managers only restart the jobs whose configuration changed, and keep the previously
+// applied jobs if a loaded job is invalid. It must be called with configMu held.
 func (r *pReceiver) applyCfg(cfg *config.Config) error {
-	if err := r.scrapeManager.ApplyConfig(cfg); err != nil {
+	scrapeConfigs, err := cfg.GetScrapeConfigs()
+	if err != nil {
+		return err
+	}
+	// The jobs of the scrape_config_files follow the inline ones, which were validated with the receiver config.
+	for _, scrapeConfig := range scrapeConfigs[len(cfg.ScrapeConfigs):] {
+		if err = validateScrapeConfig(scrapeConfig); err != nil {
+			return fmt.Errorf("invalid scrape config file: %w", err)
+		}
+	}
+	if r.cfg.Sharding != nil {
+		scrapeConfigs = shardScrapeConfigs(scrapeConfigs, r.shardIndex, r.shardCount)
+	}
+	if r.appliedScrapeConfigs != nil && reflect.DeepEqual(r.appliedScrapeConfigs, scrapeConfigs) {
+		// no update needed
+		return nil
+	}
+
+	// The scrape config files are already loaded, so the managers are given the same jobs.
+	appliedCfg := *cfg
+	appliedCfg.ScrapeConfigs = scrapeConfigs
+	appliedCfg.ScrapeConfigFiles = nil
+	if err = r.scrapeManager.ApplyConfig(&appliedCfg); err != nil {
 		return err
 	}
 
 	discoveryCfg := make(map[string]discovery.Configs)
-	for _, scrapeConfig := range cfg.ScrapeConfigs {
+	for _, scrapeConfig := range scrapeConfigs {
 		discoveryCfg[scrapeConfig.JobName] = scrapeConfig.ServiceDiscoveryConfigs
 		r.settings.Logger.Info("Scrape job added", zap.String("jobName", scrapeConfig.JobName))
 	}
-	if err := r.discoveryManager.ApplyConfig(discoveryCfg); err != nil {
+	if err = r.discoveryManager.ApplyConfig(discoveryCfg); err != nil {
 		return err
 	}
+	r.appliedScrapeConfigs = scrapeConfigs
 	return nil
 }
 
@@ -281,7 +356,10 @@ func (r *pReceiver) initPrometheusComponents(ctx context.Context, host component
 	if err != nil {
 		return err
 	}
-	r.scrapeManager = scrape.NewManager(&scrape.Options{PassMetadataInContext: true}, logger, store)
+	r.scrapeManager = scrape.NewManager(&scrape.Options{
+		PassMetadataInContext:     true,
+		EnableProtobufNegotiation: enableNativeHistogramsGate.IsEnabled(),
+	}, logger, store)
 
 	go func() {
 		// The scrape manager needs to wait for the configuration to be loaded before beginning
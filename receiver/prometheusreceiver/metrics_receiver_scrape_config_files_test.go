@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	promConfig "github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const job1ScrapeConfig = `
+scrape_configs:
+  - job_name: job1
+    scrape_interval: 1h
+    static_configs:
+      - targets: ['localhost:1']
+`
+
+const job2ScrapeConfig = `
+scrape_configs:
+  - job_name: job2
+    scrape_interval: 1h
+    static_configs:
+      - targets: ['localhost:2']
+`
+
+const renamingScrapeConfig = `
+scrape_configs:
+  - job_name: renaming
+    scrape_interval: 1h
+    static_configs:
+      - targets: ['localhost:3']
+    metric_relabel_configs:
+      - source_labels: [__name__]
+        target_label: __name__
+        replacement: renamed
+`
+
+func TestScrapeConfigFilesReload(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "job1.yaml"), []byte(job1ScrapeConfig), 0600))
+
+	cfg := &Config{
+		PrometheusConfig: &promConfig.Config{
+			GlobalConfig:      promConfig.DefaultGlobalConfig,
+			ScrapeConfigFiles: []string{filepath.Join(dir, "*.yaml")},
+		},
+		ScrapeConfigFilesReloadInterval: 50 * time.Millisecond,
+	}
+	receiver := newPrometheusReceiver(receivertest.NewNopCreateSettings(), cfg, new(consumertest.MetricsSink), featuregate.GlobalRegistry())
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	})
+
+	job1Provider := provider(receiver, "localhost:1")
+	require.NotNil(t, job1Provider)
+
+	// adding a job keeps the discovery of the unchanged ones
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "job2.yaml"), []byte(job2ScrapeConfig), 0600))
+	assert.Eventually(t, func() bool {
+		return provider(receiver, "localhost:2") != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Same(t, job1Provider, provider(receiver, "localhost:1"))
+
+	// removing a job stops its discovery
+	require.NoError(t, os.Remove(filepath.Join(dir, "job1.yaml")))
+	assert.Eventually(t, func() bool {
+		return provider(receiver, "localhost:1") == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NotNil(t, provider(receiver, "localhost:2"))
+}
+
+func TestApplyCfgUnchanged(t *testing.T) {
+	cfg := &Config{
+		PrometheusConfig: &promConfig.Config{
+			GlobalConfig:      promConfig.DefaultGlobalConfig,
+			ScrapeConfigFiles: []string{filepath.Join("testdata", "scrape_configs", "*.yaml")},
+		},
+	}
+	receiver := newPrometheusReceiver(receivertest.NewNopCreateSettings(), cfg, new(consumertest.MetricsSink), featuregate.GlobalRegistry())
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	})
+
+	receiver.configMu.Lock()
+	defer receiver.configMu.Unlock()
+	applied := receiver.appliedScrapeConfigs
+	require.Len(t, applied, 1)
+	assert.Equal(t, "demo", applied[0].JobName)
+
+	// the scrape configs loaded again from the unchanged files are not applied
+	require.NoError(t, receiver.applyCfg(cfg.PrometheusConfig))
+	assert.Same(t, applied[0], receiver.appliedScrapeConfigs[0])
+}
+
+func TestApplyCfgRejectsInvalidScrapeConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "job1.yaml"), []byte(job1ScrapeConfig), 0600))
+
+	cfg := &Config{
+		PrometheusConfig: &promConfig.Config{
+			GlobalConfig:      promConfig.DefaultGlobalConfig,
+			ScrapeConfigFiles: []string{filepath.Join(dir, "*.yaml")},
+		},
+	}
+	receiver := newPrometheusReceiver(receivertest.NewNopCreateSettings(), cfg, new(consumertest.MetricsSink), featuregate.GlobalRegistry())
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	})
+	job1Provider := provider(receiver, "localhost:1")
+	require.NotNil(t, job1Provider)
+
+	// a loaded job renaming metrics is rejected like an inline one, and the applied jobs are kept
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "renaming.yaml"), []byte(renamingScrapeConfig), 0600))
+	receiver.configMu.Lock()
+	applied := receiver.appliedScrapeConfigs
+	err := receiver.applyCfg(cfg.PrometheusConfig)
+	assert.ErrorIs(t, err, errRenamingDisallowed)
+	assert.Equal(t, applied, receiver.appliedScrapeConfigs)
+	receiver.configMu.Unlock()
+	assert.Same(t, job1Provider, provider(receiver, "localhost:1"))
+	assert.Nil(t, provider(receiver, "localhost:3"))
+
+	// the receiver doesn't start with an invalid scrape config file
+	invalid := newPrometheusReceiver(receivertest.NewNopCreateSettings(), cfg, new(consumertest.MetricsSink), featuregate.GlobalRegistry())
+	assert.ErrorIs(t, invalid.Start(context.Background(), componenttest.NewNopHost()), errRenamingDisallowed)
+	require.NoError(t, invalid.Shutdown(context.Background()))
+}
+
+// provider returns the discovery provider of the static config holding target.
+func provider(receiver *pReceiver, target string) *discovery.Provider {
+	// the providers are updated while holding configMu
+	receiver.configMu.Lock()
+	defer receiver.configMu.Unlock()
+	for _, p := range receiver.discoveryManager.Providers() {
+		staticConfig, ok := p.Config().(discovery.StaticConfig)
+		if !ok {
+			continue
+		}
+		for _, group := range staticConfig {
+			for _, labelSet := range group.Targets {
+				if string(labelSet[model.AddressLabel]) == target {
+					return p
+				}
+			}
+		}
+	}
+	return nil
+}
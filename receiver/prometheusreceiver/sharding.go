@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/model/relabel"
+)
+
+const (
+	shardEnvVar      = "SHARD"
+	shardCountEnvVar = "SHARD_COUNT"
+
+	// shardHashLabel is the temporary label holding the hash of the target address.
+	shardHashLabel = "__tmp_shard_hash"
+)
+
+// resolve returns the shard index and count, reading the environment variables for the ones not configured.
+func (s *sharding) resolve() (index int, count int, err error) {
+	count = s.ShardCount
+	if count == 0 {
+		count, err = strconv.Atoi(os.Getenv(shardCountEnvVar))
+		if err != nil {
+			return 0, 0, fmt.Errorf("shard_count is not set and %s environment variable is not valid: %w", shardCountEnvVar, err)
+		}
+	}
+	if count <= 0 {
+		return 0, 0, fmt.Errorf("shard count must be positive: %d", count)
+	}
+
+	if s.ShardIndex != nil {
+		index = *s.ShardIndex
+	} else {
+		index, err = parseShardIndex(os.Getenv(shardEnvVar))
+		if err != nil {
+			return 0, 0, fmt.Errorf("shard_index is not set and %s environment variable is not valid: %w", shardEnvVar, err)
+		}
+	}
+	if index < 0 || index >= count {
+		return 0, 0, fmt.Errorf("shard index %d must be between 0 and %d", index, count-1)
+	}
+	return index, count, nil
+}
+
+// parseShardIndex parses a shard index, or the ordinal ending the name of a StatefulSet pod.
+func parseShardIndex(shard string) (int, error) {
+	if i := strings.LastIndex(shard, "-"); i >= 0 {
+		shard = shard[i+1:]
+	}
+	return strconv.Atoi(shard)
+}
+
+// shardScrapeConfigs returns copies of the scrape configs only keeping the targets of the shard,
+// the hash of the address of the targets modulo the shard count being the shard index.
+func shardScrapeConfigs(scrapeConfigs []*config.ScrapeConfig, index int, count int) []*config.ScrapeConfig {
+	hashmod := relabel.DefaultRelabelConfig
+	hashmod.SourceLabels = model.LabelNames{model.AddressLabel}
+	hashmod.Modulus = uint64(count)
+	hashmod.TargetLabel = shardHashLabel
+	hashmod.Action = relabel.HashMod
+
+	keep := relabel.DefaultRelabelConfig
+	keep.SourceLabels = model.LabelNames{shardHashLabel}
+	keep.Regex = relabel.MustNewRegexp(strconv.Itoa(index))
+	keep.Action = relabel.Keep
+
+	shardedConfigs := make([]*config.ScrapeConfig, 0, len(scrapeConfigs))
+	for _, scrapeConfig := range scrapeConfigs {
+		shardedConfig := *scrapeConfig
+		// the relabel configs are copied, not to modify the ones of the receiver configuration
+		shardedConfig.RelabelConfigs = make([]*relabel.Config, 0, len(scrapeConfig.RelabelConfigs)+2)
+		shardedConfig.RelabelConfigs = append(shardedConfig.RelabelConfigs, scrapeConfig.RelabelConfigs...)
+		shardedConfig.RelabelConfigs = append(shardedConfig.RelabelConfigs, &hashmod, &keep)
+		shardedConfigs = append(shardedConfigs, &shardedConfig)
+	}
+	return shardedConfigs
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	promConfig "github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardingResolve(t *testing.T) {
+	index := 2
+	for _, tc := range []struct {
+		desc      string
+		sharding  sharding
+		env       map[string]string
+		wantIndex int
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			desc:      "configured",
+			sharding:  sharding{ShardCount: 3, ShardIndex: &index},
+			wantIndex: 2,
+			wantCount: 3,
+		},
+		{
+			desc:      "environment",
+			env:       map[string]string{"SHARD": "1", "SHARD_COUNT": "4"},
+			wantIndex: 1,
+			wantCount: 4,
+		},
+		{
+			desc:      "statefulset pod name",
+			sharding:  sharding{ShardCount: 3},
+			env:       map[string]string{"SHARD": "otel-collector-2"},
+			wantIndex: 2,
+			wantCount: 3,
+		},
+		{
+			desc:     "missing shard count",
+			sharding: sharding{ShardIndex: &index},
+			wantErr:  true,
+		},
+		{
+			desc:     "invalid shard",
+			sharding: sharding{ShardCount: 3},
+			env:      map[string]string{"SHARD": "otel-collector"},
+			wantErr:  true,
+		},
+		{
+			desc:     "shard out of range",
+			sharding: sharding{ShardCount: 2},
+			env:      map[string]string{"SHARD": "2"},
+			wantErr:  true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Setenv("SHARD", "")
+			t.Setenv("SHARD_COUNT", "")
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			gotIndex, gotCount, err := tc.sharding.resolve()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantIndex, gotIndex)
+			assert.Equal(t, tc.wantCount, gotCount)
+		})
+	}
+}
+
+func TestShardScrapeConfigs(t *testing.T) {
+	userRelabelConfig := &relabel.Config{
+		SourceLabels: model.LabelNames{"__meta_kubernetes_pod_name"},
+		Separator:    ";",
+		Regex:        relabel.MustNewRegexp("(.*)"),
+		TargetLabel:  "pod",
+		Replacement:  "$1",
+		Action:       relabel.Replace,
+	}
+	scrapeConfigs := []*promConfig.ScrapeConfig{
+		{JobName: "job1", RelabelConfigs: []*relabel.Config{userRelabelConfig}},
+		{JobName: "job2"},
+	}
+
+	const shardCount = 3
+	kept := map[string]int{}
+	for index := 0; index < shardCount; index++ {
+		sharded := shardScrapeConfigs(scrapeConfigs, index, shardCount)
+		require.Len(t, sharded, 2)
+		assert.Equal(t, userRelabelConfig, sharded[0].RelabelConfigs[0])
+		assert.Len(t, sharded[0].RelabelConfigs, 3)
+		assert.Len(t, sharded[1].RelabelConfigs, 2)
+
+		for i := 0; i < 100; i++ {
+			address := fmt.Sprintf("10.0.0.%d:9100", i)
+			if _, keep := relabel.Process(labels.FromStrings(model.AddressLabel, address), sharded[1].RelabelConfigs...); keep {
+				kept[address]++
+			}
+		}
+	}
+
+	// each target is scraped by exactly one shard
+	assert.Len(t, kept, 100)
+	for address, count := range kept {
+		assert.Equal(t, 1, count, address)
+	}
+
+	// the configurations of the receiver are left untouched
+	assert.Len(t, scrapeConfigs[0].RelabelConfigs, 1)
+	assert.Empty(t, scrapeConfigs[1].RelabelConfigs)
+}
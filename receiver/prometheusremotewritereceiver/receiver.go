@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+const (
+	writePath      = "/api/v1/write"
+	snappyEncoding = "snappy"
+	transport      = "http"
+	dataFormat     = "protobuf"
+
+	// maxCompressedBodySize and maxDecodedBodySize bound the memory used by a single write request,
+	// well above the size of the batches sent by Prometheus.
+	maxCompressedBodySize = 32 << 20
+	maxDecodedBodySize    = 128 << 20
+)
+
+var errRequestTooLarge = errors.New("request body too large")
+
+type remoteWriteReceiver struct {
+	config       *Config
+	settings     receiver.CreateSettings
+	nextConsumer consumer.Metrics
+	obsrecv      *obsreport.Receiver
+
+	server     *http.Server
+	shutdownWG sync.WaitGroup
+}
+
+func newRemoteWriteReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Metrics) (*remoteWriteReceiver, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             settings.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteWriteReceiver{
+		config:       config,
+		settings:     settings,
+		nextConsumer: nextConsumer,
+		obsrecv:      obsrecv,
+	}, nil
+}
+
+func (r *remoteWriteReceiver) Start(_ context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(writePath, r.handleWrite)
+
+	var err error
+	r.server, err = r.config.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return fmt.Errorf("failed to create http server: %w", err)
+	}
+
+	r.settings.Logger.Info("Starting HTTP server", zap.String("endpoint", r.config.HTTPServerSettings.Endpoint))
+	listener, err := r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.HTTPServerSettings.Endpoint, err)
+	}
+
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		if errHTTP := r.server.Serve(listener); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (r *remoteWriteReceiver) Shutdown(ctx context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Shutdown(ctx)
+	r.shutdownWG.Wait()
+	return err
+}
+
+func (r *remoteWriteReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if encoding := req.Header.Get("Content-Encoding"); encoding != snappyEncoding {
+		http.Error(w, fmt.Sprintf("unsupported content encoding %q, expected %q", encoding, snappyEncoding), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	writeRequest, err := decodeWriteRequest(req.Body)
+	if err != nil {
+		r.settings.Logger.Debug("failed to decode remote write request", zap.Error(err))
+		status := http.StatusBadRequest
+		if errors.Is(err, errRequestTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(req.Context())
+	md, err := writeRequestToMetrics(writeRequest, r.settings.BuildInfo)
+	if err != nil {
+		r.obsrecv.EndMetricsOp(ctx, dataFormat, 0, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dataPointCount := md.DataPointCount()
+	if dataPointCount == 0 {
+		r.obsrecv.EndMetricsOp(ctx, dataFormat, 0, nil)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, dataFormat, dataPointCount, err)
+	if err != nil {
+		// Prometheus retries requests failing with a 5xx status code only.
+		status := http.StatusInternalServerError
+		if consumererror.IsPermanent(err) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeWriteRequest(body io.Reader) (*prompb.WriteRequest, error) {
+	// read one more byte than allowed to tell an oversized body from one of exactly the maximum size.
+	compressed, err := io.ReadAll(io.LimitReader(body, maxCompressedBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(compressed) > maxCompressedBodySize {
+		return nil, fmt.Errorf("%w: more than %d compressed bytes", errRequestTooLarge, maxCompressedBodySize)
+	}
+
+	decodedLen, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+	if decodedLen > maxDecodedBodySize {
+		return nil, fmt.Errorf("%w: %d decompressed bytes, more than %d", errRequestTooLarge, decodedLen, maxDecodedBodySize)
+	}
+
+	buf, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+
+	writeRequest := &prompb.WriteRequest{}
+	if err = writeRequest.Unmarshal(buf); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal write request: %w", err)
+	}
+	return writeRequest, nil
+}
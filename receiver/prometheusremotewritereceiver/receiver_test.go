@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func TestRemoteWrite(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	addr := startReceiver(t, sink)
+
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "http_requests_total"},
+					{Name: "instance", Value: "localhost:8080"},
+					{Name: "job", Value: "app"},
+					{Name: "method", Value: "GET"},
+				},
+				Samples: []prompb.Sample{{Value: 42, Timestamp: 1680000000000}},
+			},
+		},
+	}
+	resp := postWriteRequest(t, addr, req, "snappy")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.DataPointCount())
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "http_requests_total", metric.Name())
+	assert.Equal(t, 42.0, metric.Sum().DataPoints().At(0).DoubleValue())
+}
+
+func TestRemoteWriteErrors(t *testing.T) {
+	validRequest := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "up"}},
+			Samples: []prompb.Sample{{Value: 1, Timestamp: 1680000000000}},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		consumer   consumer.Metrics
+		method     string
+		encoding   string
+		body       []byte
+		wantStatus int
+	}{
+		{
+			name:       "wrong method",
+			consumer:   consumertest.NewNop(),
+			method:     http.MethodGet,
+			encoding:   "snappy",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "unsupported encoding",
+			consumer:   consumertest.NewNop(),
+			method:     http.MethodPost,
+			encoding:   "br",
+			body:       encodeWriteRequest(t, validRequest),
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "not compressed",
+			consumer:   consumertest.NewNop(),
+			method:     http.MethodPost,
+			encoding:   "snappy",
+			body:       []byte("not snappy"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "decompressed body too large",
+			consumer:   consumertest.NewNop(),
+			method:     http.MethodPost,
+			encoding:   "snappy",
+			body:       binary.AppendUvarint(nil, maxDecodedBodySize+1),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "series without name",
+			consumer: consumertest.NewNop(),
+			method:   http.MethodPost,
+			encoding: "snappy",
+			body: encodeWriteRequest(t, &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{{Samples: []prompb.Sample{{Value: 1}}}},
+			}),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "retryable consumer error",
+			consumer:   consumertest.NewErr(errors.New("queue is full")),
+			method:     http.MethodPost,
+			encoding:   "snappy",
+			body:       encodeWriteRequest(t, validRequest),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "permanent consumer error",
+			consumer:   consumertest.NewErr(consumererror.NewPermanent(errors.New("invalid data"))),
+			method:     http.MethodPost,
+			encoding:   "snappy",
+			body:       encodeWriteRequest(t, validRequest),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startReceiver(t, tt.consumer)
+
+			req, err := http.NewRequest(tt.method, "http://"+addr+writePath, bytes.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Encoding", tt.encoding)
+			req.Header.Set("Content-Type", "application/x-protobuf")
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestDecodeWriteRequestTooLarge(t *testing.T) {
+	_, err := decodeWriteRequest(bytes.NewReader(make([]byte, maxCompressedBodySize+1)))
+	assert.ErrorIs(t, err, errRequestTooLarge)
+
+	_, err = decodeWriteRequest(bytes.NewReader(binary.AppendUvarint(nil, maxDecodedBodySize+1)))
+	assert.ErrorIs(t, err, errRequestTooLarge)
+}
+
+func startReceiver(t *testing.T, nextConsumer consumer.Metrics) string {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: addr,
+		},
+	}
+
+	receiver, err := NewFactory().CreateMetricsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, nextConsumer)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, receiver.Shutdown(context.Background())) })
+	return addr
+}
+
+func encodeWriteRequest(t *testing.T, req *prompb.WriteRequest) []byte {
+	buf, err := req.Marshal()
+	require.NoError(t, err)
+	return snappy.Encode(nil, buf)
+}
+
+func postWriteRequest(t *testing.T, addr string, writeRequest *prompb.WriteRequest, encoding string) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, "http://"+addr+writePath, bytes.NewReader(encodeWriteRequest(t, writeRequest)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Encoding", encoding)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
@@ -0,0 +1,676 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+const (
+	scopeName        = "otelcol/prometheusremotewritereceiver"
+	targetInfoMetric = "target_info"
+
+	metricSuffixTotal  = "_total"
+	metricSuffixBucket = "_bucket"
+	metricSuffixCount  = "_count"
+	metricSuffixSum    = "_sum"
+
+	traceIDKey = "trace_id"
+	spanIDKey  = "span_id"
+)
+
+var errMissingMetricName = errors.New("time series without metric name")
+
+// seriesKind is the part of a metric family a series holds.
+type seriesKind int
+
+const (
+	seriesValue seriesKind = iota
+	seriesBucket
+	seriesCount
+	seriesSum
+	seriesQuantile
+)
+
+var partSuffixes = []struct {
+	suffix string
+	kind   seriesKind
+}{
+	{metricSuffixBucket, seriesBucket},
+	{metricSuffixCount, seriesCount},
+	{metricSuffixSum, seriesSum},
+}
+
+// familyShape records which parts of a family are present in a request,
+// to infer the type of the families without metadata.
+type familyShape struct {
+	hasBucket   bool
+	hasQuantile bool
+	hasCount    bool
+	hasSum      bool
+}
+
+// writeRequestToMetrics converts a remote write request to metrics. Series are grouped into
+// resources by their job and instance labels, and into metrics by family. The type of a family
+// is taken from the metadata of the request when available, and inferred from the names and
+// labels of its series otherwise.
+func writeRequestToMetrics(req *prompb.WriteRequest, buildInfo component.BuildInfo) (pmetric.Metrics, error) {
+	metadata := make(map[string]prompb.MetricMetadata, len(req.Metadata))
+	for _, md := range req.Metadata {
+		metadata[md.MetricFamilyName] = md
+	}
+
+	shapes := make(map[string]familyShape)
+	for _, ts := range req.Timeseries {
+		name := getLabel(ts.Labels, model.MetricNameLabel)
+		if name == "" {
+			return pmetric.Metrics{}, errMissingMetricName
+		}
+		if hasLabel(ts.Labels, model.QuantileLabel) {
+			shape := shapes[name]
+			shape.hasQuantile = true
+			shapes[name] = shape
+		}
+		for _, part := range partSuffixes {
+			base := strings.TrimSuffix(name, part.suffix)
+			if base == name {
+				continue
+			}
+			shape := shapes[base]
+			switch part.kind {
+			case seriesBucket:
+				shape.hasBucket = shape.hasBucket || hasLabel(ts.Labels, model.BucketLabel)
+			case seriesCount:
+				shape.hasCount = true
+			case seriesSum:
+				shape.hasSum = true
+			}
+			shapes[base] = shape
+		}
+	}
+
+	b := newMetricsBuilder()
+	// target_info series hold the attributes of the resources, they are added first
+	// so that the resources are complete whatever the order of the series.
+	for _, ts := range req.Timeseries {
+		if getLabel(ts.Labels, model.MetricNameLabel) == targetInfoMetric {
+			b.addTargetInfo(ts.Labels)
+		}
+	}
+
+	for i := range req.Timeseries {
+		ts := &req.Timeseries[i]
+		name := getLabel(ts.Labels, model.MetricNameLabel)
+		if name == targetInfoMetric {
+			continue
+		}
+		rb := b.resource(ts.Labels)
+		// exemplars are attached to the latest point of their series.
+		var last *dataPoint
+
+		if len(ts.Samples) > 0 {
+			family, typ, kind := resolveFamily(name, ts.Labels, metadata, shapes)
+			fb := rb.family(family, typ, lookupMetadata(family, typ, metadata))
+			for _, sample := range ts.Samples {
+				dp := fb.point(ts.Labels, kind, sample.Timestamp)
+				if err := dp.add(kind, ts.Labels, sample.Value); err != nil {
+					return pmetric.Metrics{}, fmt.Errorf("invalid series %q: %w", name, err)
+				}
+				last = dp
+			}
+		}
+
+		if len(ts.Histograms) > 0 {
+			fb := rb.nativeHistogramFamily(name, metadata[name])
+			for j := range ts.Histograms {
+				h := &ts.Histograms[j]
+				// gauge histograms are not supported by OTLP, they are dropped as the Prometheus receiver does.
+				if h.ResetHint == prompb.Histogram_GAUGE {
+					continue
+				}
+				last = fb.point(ts.Labels, seriesValue, h.Timestamp)
+				last.histogram = h
+			}
+		}
+
+		if last != nil {
+			last.exemplars = append(last.exemplars, ts.Exemplars...)
+		}
+	}
+
+	return b.metrics(buildInfo), nil
+}
+
+// resolveFamily returns the family a series belongs to, the type of this family
+// and the part of the family held by the series.
+func resolveFamily(name string, labels []prompb.Label, metadata map[string]prompb.MetricMetadata, shapes map[string]familyShape) (string, prompb.MetricMetadata_MetricType, seriesKind) {
+	if hasLabel(labels, model.QuantileLabel) && familyType(name, metadata, shapes) == prompb.MetricMetadata_SUMMARY {
+		return name, prompb.MetricMetadata_SUMMARY, seriesQuantile
+	}
+
+	for _, part := range partSuffixes {
+		base := strings.TrimSuffix(name, part.suffix)
+		if base == name {
+			continue
+		}
+		switch typ := familyType(base, metadata, shapes); typ {
+		case prompb.MetricMetadata_HISTOGRAM:
+			if part.kind != seriesBucket || hasLabel(labels, model.BucketLabel) {
+				return base, typ, part.kind
+			}
+		case prompb.MetricMetadata_SUMMARY:
+			if part.kind != seriesBucket {
+				return base, typ, part.kind
+			}
+		}
+	}
+
+	typ := familyType(name, metadata, shapes)
+	switch typ {
+	case prompb.MetricMetadata_HISTOGRAM, prompb.MetricMetadata_SUMMARY:
+		// the series is named after a histogram or a summary without being one of its parts.
+		typ = prompb.MetricMetadata_UNKNOWN
+	case prompb.MetricMetadata_UNKNOWN:
+		if md, ok := metadata[strings.TrimSuffix(name, metricSuffixTotal)]; ok && md.Type == prompb.MetricMetadata_COUNTER {
+			// OpenMetrics counters are named after their family with the _total suffix.
+			typ = prompb.MetricMetadata_COUNTER
+		} else if _, ok := metadata[name]; !ok && (strings.HasSuffix(name, metricSuffixTotal) || strings.HasSuffix(name, metricSuffixCount)) {
+			typ = prompb.MetricMetadata_COUNTER
+		}
+	}
+	return name, typ, seriesValue
+}
+
+// familyType returns the type of a family from the metadata, or inferred from its shape.
+func familyType(family string, metadata map[string]prompb.MetricMetadata, shapes map[string]familyShape) prompb.MetricMetadata_MetricType {
+	if md, ok := metadata[family]; ok {
+		return md.Type
+	}
+	shape := shapes[family]
+	switch {
+	case shape.hasBucket:
+		return prompb.MetricMetadata_HISTOGRAM
+	case shape.hasQuantile, shape.hasCount && shape.hasSum:
+		return prompb.MetricMetadata_SUMMARY
+	}
+	return prompb.MetricMetadata_UNKNOWN
+}
+
+func lookupMetadata(family string, typ prompb.MetricMetadata_MetricType, metadata map[string]prompb.MetricMetadata) prompb.MetricMetadata {
+	if md, ok := metadata[family]; ok {
+		return md
+	}
+	if typ == prompb.MetricMetadata_COUNTER {
+		return metadata[strings.TrimSuffix(family, metricSuffixTotal)]
+	}
+	return prompb.MetricMetadata{}
+}
+
+type metricsBuilder struct {
+	resources     map[string]*resourceBuilder
+	resourceOrder []*resourceBuilder
+}
+
+func newMetricsBuilder() *metricsBuilder {
+	return &metricsBuilder{resources: make(map[string]*resourceBuilder)}
+}
+
+// resource returns the builder of the resource identified by the job and instance labels.
+func (b *metricsBuilder) resource(labels []prompb.Label) *resourceBuilder {
+	job := getLabel(labels, model.JobLabel)
+	instance := getLabel(labels, model.InstanceLabel)
+	key := job + "\xff" + instance
+	rb, ok := b.resources[key]
+	if !ok {
+		rb = &resourceBuilder{
+			job:      job,
+			instance: instance,
+			families: make(map[familyKey]*familyBuilder),
+		}
+		b.resources[key] = rb
+		b.resourceOrder = append(b.resourceOrder, rb)
+	}
+	return rb
+}
+
+func (b *metricsBuilder) addTargetInfo(labels []prompb.Label) {
+	rb := b.resource(labels)
+	for _, l := range labels {
+		switch l.Name {
+		case model.MetricNameLabel, model.JobLabel, model.InstanceLabel:
+		default:
+			rb.attributes = append(rb.attributes, l)
+		}
+	}
+}
+
+func (b *metricsBuilder) metrics(buildInfo component.BuildInfo) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, rb := range b.resourceOrder {
+		if len(rb.familyOrder) == 0 {
+			continue
+		}
+		rm := md.ResourceMetrics().AppendEmpty()
+		rb.fillResource(rm.Resource())
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName(scopeName)
+		sm.Scope().SetVersion(buildInfo.Version)
+		for _, fb := range rb.familyOrder {
+			fb.appendMetric(sm.Metrics())
+		}
+	}
+	return md
+}
+
+type familyKey struct {
+	name  string
+	mtype pmetric.MetricType
+}
+
+type resourceBuilder struct {
+	job        string
+	instance   string
+	attributes []prompb.Label
+
+	families    map[familyKey]*familyBuilder
+	familyOrder []*familyBuilder
+}
+
+// fillResource sets the attributes of the resource, reversing the mapping of the service
+// attributes to the job and instance labels done by the Prometheus exporters.
+func (rb *resourceBuilder) fillResource(resource pcommon.Resource) {
+	attrs := resource.Attributes()
+	if rb.job != "" {
+		if namespace, name, found := strings.Cut(rb.job, "/"); found {
+			attrs.PutStr(conventions.AttributeServiceNamespace, namespace)
+			attrs.PutStr(conventions.AttributeServiceName, name)
+		} else {
+			attrs.PutStr(conventions.AttributeServiceName, rb.job)
+		}
+	}
+	if rb.instance != "" {
+		attrs.PutStr(conventions.AttributeServiceInstanceID, rb.instance)
+	}
+	for _, l := range rb.attributes {
+		attrs.PutStr(l.Name, l.Value)
+	}
+}
+
+func (rb *resourceBuilder) family(name string, typ prompb.MetricMetadata_MetricType, md prompb.MetricMetadata) *familyBuilder {
+	var mtype pmetric.MetricType
+	switch typ {
+	case prompb.MetricMetadata_COUNTER:
+		mtype = pmetric.MetricTypeSum
+	case prompb.MetricMetadata_HISTOGRAM:
+		mtype = pmetric.MetricTypeHistogram
+	case prompb.MetricMetadata_SUMMARY:
+		mtype = pmetric.MetricTypeSummary
+	default:
+		mtype = pmetric.MetricTypeGauge
+	}
+	return rb.getOrCreateFamily(name, mtype, md)
+}
+
+func (rb *resourceBuilder) nativeHistogramFamily(name string, md prompb.MetricMetadata) *familyBuilder {
+	return rb.getOrCreateFamily(name, pmetric.MetricTypeExponentialHistogram, md)
+}
+
+func (rb *resourceBuilder) getOrCreateFamily(name string, mtype pmetric.MetricType, md prompb.MetricMetadata) *familyBuilder {
+	key := familyKey{name: name, mtype: mtype}
+	fb, ok := rb.families[key]
+	if !ok {
+		fb = &familyBuilder{
+			name:     name,
+			mtype:    mtype,
+			metadata: md,
+			points:   make(map[string]*dataPoint),
+		}
+		rb.families[key] = fb
+		rb.familyOrder = append(rb.familyOrder, fb)
+	}
+	return fb
+}
+
+type familyBuilder struct {
+	name     string
+	mtype    pmetric.MetricType
+	metadata prompb.MetricMetadata
+
+	points     map[string]*dataPoint
+	pointOrder []*dataPoint
+}
+
+// point returns the data point of the series with the given labels at the given timestamp.
+// The labels identifying the family and its parts are not attributes of the data point.
+func (fb *familyBuilder) point(labels []prompb.Label, kind seriesKind, timestamp int64) *dataPoint {
+	attributes := make([]prompb.Label, 0, len(labels))
+	for _, l := range labels {
+		switch {
+		case l.Name == model.MetricNameLabel, l.Name == model.JobLabel, l.Name == model.InstanceLabel:
+		case l.Name == model.BucketLabel && kind == seriesBucket:
+		case l.Name == model.QuantileLabel && kind == seriesQuantile:
+		default:
+			attributes = append(attributes, l)
+		}
+	}
+	sort.Slice(attributes, func(i, j int) bool { return attributes[i].Name < attributes[j].Name })
+
+	var sb strings.Builder
+	for _, l := range attributes {
+		sb.WriteString(l.Name)
+		sb.WriteByte('\xff')
+		sb.WriteString(l.Value)
+		sb.WriteByte('\xff')
+	}
+	sb.WriteString(strconv.FormatInt(timestamp, 10))
+	key := sb.String()
+
+	dp, ok := fb.points[key]
+	if !ok {
+		dp = &dataPoint{attributes: attributes, timestamp: timestamp}
+		fb.points[key] = dp
+		fb.pointOrder = append(fb.pointOrder, dp)
+	}
+	return dp
+}
+
+func (fb *familyBuilder) appendMetric(metrics pmetric.MetricSlice) {
+	metric := metrics.AppendEmpty()
+	metric.SetName(fb.name)
+	metric.SetDescription(fb.metadata.Help)
+	metric.SetUnit(fb.metadata.Unit)
+
+	switch fb.mtype {
+	case pmetric.MetricTypeGauge:
+		dps := metric.SetEmptyGauge().DataPoints()
+		for _, dp := range fb.pointOrder {
+			dp.toNumberDataPoint(dps.AppendEmpty())
+		}
+	case pmetric.MetricTypeSum:
+		sum := metric.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for _, dp := range fb.pointOrder {
+			dp.toNumberDataPoint(sum.DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricTypeHistogram:
+		histogram := metric.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for _, dp := range fb.pointOrder {
+			dp.toHistogramDataPoint(histogram.DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := metric.SetEmptyExponentialHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for _, dp := range fb.pointOrder {
+			dp.toExponentialHistogramDataPoint(histogram.DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricTypeSummary:
+		summary := metric.SetEmptySummary()
+		for _, dp := range fb.pointOrder {
+			dp.toSummaryDataPoint(summary.DataPoints().AppendEmpty())
+		}
+	}
+}
+
+type bucket struct {
+	bound float64
+	count float64
+}
+
+type dataPoint struct {
+	attributes []prompb.Label
+	timestamp  int64
+	stale      bool
+
+	value     float64
+	count     float64
+	hasCount  bool
+	sum       float64
+	hasSum    bool
+	buckets   []bucket
+	quantiles []bucket
+	histogram *prompb.Histogram
+	exemplars []prompb.Exemplar
+}
+
+func (dp *dataPoint) add(kind seriesKind, labels []prompb.Label, v float64) error {
+	if value.IsStaleNaN(v) {
+		dp.stale = true
+		return nil
+	}
+
+	switch kind {
+	case seriesValue:
+		dp.value = v
+	case seriesCount:
+		dp.count = v
+		dp.hasCount = true
+	case seriesSum:
+		dp.sum = v
+		dp.hasSum = true
+	case seriesBucket:
+		bound, err := parseBound(labels, model.BucketLabel)
+		if err != nil {
+			return err
+		}
+		dp.buckets = append(dp.buckets, bucket{bound: bound, count: v})
+	case seriesQuantile:
+		quantile, err := parseBound(labels, model.QuantileLabel)
+		if err != nil {
+			return err
+		}
+		dp.quantiles = append(dp.quantiles, bucket{bound: quantile, count: v})
+	}
+	return nil
+}
+
+func parseBound(labels []prompb.Label, name string) (float64, error) {
+	bound, err := strconv.ParseFloat(getLabel(labels, name), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %q label: %w", name, err)
+	}
+	return bound, nil
+}
+
+func (dp *dataPoint) toNumberDataPoint(dest pmetric.NumberDataPoint) {
+	dp.setCommon(dest.Attributes(), dest.SetTimestamp, dest.SetFlags)
+	if !dp.stale {
+		dest.SetDoubleValue(dp.value)
+	}
+	dp.setExemplars(dest.Exemplars())
+}
+
+func (dp *dataPoint) toHistogramDataPoint(dest pmetric.HistogramDataPoint) {
+	dp.setCommon(dest.Attributes(), dest.SetTimestamp, dest.SetFlags)
+	dp.setExemplars(dest.Exemplars())
+	if dp.stale {
+		return
+	}
+
+	sort.Slice(dp.buckets, func(i, j int) bool { return dp.buckets[i].bound < dp.buckets[j].bound })
+	bounds := make([]float64, 0, len(dp.buckets))
+	counts := make([]uint64, 0, len(dp.buckets)+1)
+	var previous float64
+	for _, b := range dp.buckets {
+		counts = append(counts, cumulativeDelta(b.count, previous))
+		previous = b.count
+		if !math.IsInf(b.bound, 1) {
+			bounds = append(bounds, b.bound)
+		}
+	}
+
+	total := previous
+	if dp.hasCount {
+		total = dp.count
+	}
+	if len(dp.buckets) == 0 || !math.IsInf(dp.buckets[len(dp.buckets)-1].bound, 1) {
+		// the +Inf bucket is missing, it holds the observations not counted in the other buckets.
+		counts = append(counts, cumulativeDelta(total, previous))
+	}
+
+	dest.ExplicitBounds().FromRaw(bounds)
+	dest.BucketCounts().FromRaw(counts)
+	dest.SetCount(uint64(total))
+	if dp.hasSum {
+		dest.SetSum(dp.sum)
+	}
+}
+
+func cumulativeDelta(current, previous float64) uint64 {
+	if current < previous {
+		return 0
+	}
+	return uint64(current - previous)
+}
+
+func (dp *dataPoint) toSummaryDataPoint(dest pmetric.SummaryDataPoint) {
+	dp.setCommon(dest.Attributes(), dest.SetTimestamp, dest.SetFlags)
+	if dp.stale {
+		return
+	}
+
+	dest.SetCount(uint64(dp.count))
+	dest.SetSum(dp.sum)
+	sort.Slice(dp.quantiles, func(i, j int) bool { return dp.quantiles[i].bound < dp.quantiles[j].bound })
+	for _, q := range dp.quantiles {
+		qv := dest.QuantileValues().AppendEmpty()
+		qv.SetQuantile(q.bound)
+		qv.SetValue(q.count)
+	}
+}
+
+// toExponentialHistogramDataPoint converts a native histogram. The schema of the native histogram
+// is the scale of the exponential histogram, and the bucket indexes are shifted by one as the
+// Prometheus bucket of index i covers (base^(i-1), base^i] while the OTLP one covers (base^i, base^(i+1)].
+func (dp *dataPoint) toExponentialHistogramDataPoint(dest pmetric.ExponentialHistogramDataPoint) {
+	dp.setCommon(dest.Attributes(), dest.SetTimestamp, dest.SetFlags)
+	dp.setExemplars(dest.Exemplars())
+	h := dp.histogram
+	if value.IsStaleNaN(h.Sum) {
+		dest.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	dest.SetScale(h.Schema)
+	dest.SetSum(h.Sum)
+	switch count := h.Count.(type) {
+	case *prompb.Histogram_CountInt:
+		dest.SetCount(count.CountInt)
+	case *prompb.Histogram_CountFloat:
+		dest.SetCount(uint64(count.CountFloat))
+	}
+	// OTLP has no zero threshold, observations within the threshold are counted as zeros.
+	switch zeroCount := h.ZeroCount.(type) {
+	case *prompb.Histogram_ZeroCountInt:
+		dest.SetZeroCount(zeroCount.ZeroCountInt)
+	case *prompb.Histogram_ZeroCountFloat:
+		dest.SetZeroCount(uint64(zeroCount.ZeroCountFloat))
+	}
+	convertNativeHistogramBuckets(h.PositiveSpans, h.PositiveDeltas, h.PositiveCounts, dest.Positive())
+	convertNativeHistogramBuckets(h.NegativeSpans, h.NegativeDeltas, h.NegativeCounts, dest.Negative())
+}
+
+// convertNativeHistogramBuckets expands the sparse buckets of a native histogram, whose counts are
+// either delta encoded integers or absolute floats, into the dense buckets of an exponential histogram.
+func convertNativeHistogramBuckets(spans []prompb.BucketSpan, deltas []int64, counts []float64, dest pmetric.ExponentialHistogramDataPointBuckets) {
+	bucketSpans := make([]prometheus.BucketSpan, len(spans))
+	for i, span := range spans {
+		bucketSpans[i] = prometheus.BucketSpan{Offset: span.Offset, Length: span.Length}
+	}
+	if len(deltas) > 0 {
+		counts = make([]float64, len(deltas))
+		var current int64
+		for i, delta := range deltas {
+			current += delta
+			counts[i] = float64(current)
+		}
+	}
+	prometheus.ConvertNativeHistogramBuckets(bucketSpans, counts, dest)
+}
+
+func (dp *dataPoint) setCommon(attributes pcommon.Map, setTimestamp func(pcommon.Timestamp), setFlags func(pmetric.DataPointFlags)) {
+	for _, l := range dp.attributes {
+		attributes.PutStr(l.Name, l.Value)
+	}
+	setTimestamp(timestampFromMs(dp.timestamp))
+	if dp.stale {
+		setFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	}
+}
+
+func (dp *dataPoint) setExemplars(dest pmetric.ExemplarSlice) {
+	for _, e := range dp.exemplars {
+		exemplar := dest.AppendEmpty()
+		exemplar.SetTimestamp(timestampFromMs(e.Timestamp))
+		exemplar.SetDoubleValue(e.Value)
+		for _, l := range e.Labels {
+			switch {
+			case l.Name == traceIDKey && len(l.Value) == 32:
+				var traceID pcommon.TraceID
+				if _, err := hex.Decode(traceID[:], []byte(l.Value)); err == nil {
+					exemplar.SetTraceID(traceID)
+					continue
+				}
+			case l.Name == spanIDKey && len(l.Value) == 16:
+				var spanID pcommon.SpanID
+				if _, err := hex.Decode(spanID[:], []byte(l.Value)); err == nil {
+					exemplar.SetSpanID(spanID)
+					continue
+				}
+			}
+			exemplar.FilteredAttributes().PutStr(l.Name, l.Value)
+		}
+	}
+}
+
+func timestampFromMs(ms int64) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(time.UnixMilli(ms))
+}
+
+func getLabel(labels []prompb.Label, name string) string {
+	for _, l := range labels {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func hasLabel(labels []prompb.Label, name string) bool {
+	for _, l := range labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,358 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const testTimestamp = int64(1680000000000)
+
+func series(name string, v float64, labels ...string) prompb.TimeSeries {
+	ls := []prompb.Label{{Name: "__name__", Value: name}, {Name: "instance", Value: "localhost:8080"}, {Name: "job", Value: "app"}}
+	for i := 0; i < len(labels); i += 2 {
+		ls = append(ls, prompb.Label{Name: labels[i], Value: labels[i+1]})
+	}
+	return prompb.TimeSeries{
+		Labels:  ls,
+		Samples: []prompb.Sample{{Value: v, Timestamp: testTimestamp}},
+	}
+}
+
+func expectedMetrics(fill func(metrics pmetric.MetricSlice)) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "app")
+	rm.Resource().Attributes().PutStr("service.instance.id", "localhost:8080")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+	sm.Scope().SetVersion("latest")
+	fill(sm.Metrics())
+	return md
+}
+
+func TestWriteRequestToMetrics(t *testing.T) {
+	ts := pcommon.NewTimestampFromTime(timestampFromMs(testTimestamp).AsTime())
+
+	tests := []struct {
+		name string
+		req  *prompb.WriteRequest
+		want pmetric.Metrics
+	}{
+		{
+			name: "gauge without metadata",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series("temperature", 21.5, "room", "kitchen")},
+			},
+			want: expectedMetrics(func(metrics pmetric.MetricSlice) {
+				m := metrics.AppendEmpty()
+				m.SetName("temperature")
+				dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.Attributes().PutStr("room", "kitchen")
+				dp.SetTimestamp(ts)
+				dp.SetDoubleValue(21.5)
+			}),
+		},
+		{
+			name: "counter inferred from suffix",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series("requests_total", 10)},
+			},
+			want: expectedMetrics(func(metrics pmetric.MetricSlice) {
+				m := metrics.AppendEmpty()
+				m.SetName("requests_total")
+				sum := m.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetDoubleValue(10)
+			}),
+		},
+		{
+			name: "counter from OpenMetrics metadata",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series("requests_total", 10)},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests", Help: "Number of requests.", Unit: "1"},
+				},
+			},
+			want: expectedMetrics(func(metrics pmetric.MetricSlice) {
+				m := metrics.AppendEmpty()
+				m.SetName("requests_total")
+				m.SetDescription("Number of requests.")
+				m.SetUnit("1")
+				sum := m.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetDoubleValue(10)
+			}),
+		},
+		{
+			name: "gauge from metadata despite suffix",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series("queue_count", 3)},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "queue_count", Help: "Number of queued items."},
+				},
+			},
+			want: expectedMetrics(func(metrics pmetric.MetricSlice) {
+				m := metrics.AppendEmpty()
+				m.SetName("queue_count")
+				m.SetDescription("Number of queued items.")
+				dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetDoubleValue(3)
+			}),
+		},
+		{
+			name: "histogram inferred from buckets",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series("latency_bucket", 2, "le", "0.1", "path", "/"),
+					series("latency_bucket", 5, "le", "1", "path", "/"),
+					series("latency_bucket", 6, "le", "+Inf", "path", "/"),
+					series("latency_count", 6, "path", "/"),
+					series("latency_sum", 3.2, "path", "/"),
+				},
+			},
+			want: expectedMetrics(func(metrics pmetric.MetricSlice) {
+				m := metrics.AppendEmpty()
+				m.SetName("latency")
+				histogram := m.SetEmptyHistogram()
+				histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := histogram.DataPoints().AppendEmpty()
+				dp.Attributes().PutStr("path", "/")
+				dp.SetTimestamp(ts)
+				dp.SetCount(6)
+				dp.SetSum(3.2)
+				dp.ExplicitBounds().FromRaw([]float64{0.1, 1})
+				dp.BucketCounts().FromRaw([]uint64{2, 3, 1})
+			}),
+		},
+		{
+			name: "histogram without +Inf bucket",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series("latency_bucket", 2, "le", "0.1"),
+					series("latency_count", 4),
+				},
+				Metadata: []prompb.MetricMetadata{{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency"}},
+			},
+			want: expectedMetrics(func(metrics pmetric.MetricSlice) {
+				m := metrics.AppendEmpty()
+				m.SetName("latency")
+				histogram := m.SetEmptyHistogram()
+				histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := histogram.DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetCount(4)
+				dp.ExplicitBounds().FromRaw([]float64{0.1})
+				dp.BucketCounts().FromRaw([]uint64{2, 2})
+			}),
+		},
+		{
+			name: "summary inferred from quantiles",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series("rpc_duration", 0.5, "quantile", "0.9"),
+					series("rpc_duration", 0.1, "quantile", "0.5"),
+					series("rpc_duration_count", 10),
+					series("rpc_duration_sum", 2),
+				},
+			},
+			want: expectedMetrics(func(metrics pmetric.MetricSlice) {
+				m := metrics.AppendEmpty()
+				m.SetName("rpc_duration")
+				dp := m.SetEmptySummary().DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetCount(10)
+				dp.SetSum(2)
+				q := dp.QuantileValues().AppendEmpty()
+				q.SetQuantile(0.5)
+				q.SetValue(0.1)
+				q = dp.QuantileValues().AppendEmpty()
+				q.SetQuantile(0.9)
+				q.SetValue(0.5)
+			}),
+		},
+		{
+			name: "stale gauge",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series("temperature", math.Float64frombits(value.StaleNaN))},
+			},
+			want: expectedMetrics(func(metrics pmetric.MetricSlice) {
+				m := metrics.AppendEmpty()
+				m.SetName("temperature")
+				dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			}),
+		},
+		{
+			name: "native histogram",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{{
+					Labels: []prompb.Label{{Name: "__name__", Value: "latency"}, {Name: "instance", Value: "localhost:8080"}, {Name: "job", Value: "app"}},
+					Histograms: []prompb.Histogram{{
+						Count:          &prompb.Histogram_CountInt{CountInt: 12},
+						Sum:            42.5,
+						Schema:         1,
+						ZeroThreshold:  0.001,
+						ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 2},
+						PositiveSpans:  []prompb.BucketSpan{{Offset: -1, Length: 2}, {Offset: 2, Length: 1}},
+						PositiveDeltas: []int64{1, 1, 1},
+						NegativeSpans:  []prompb.BucketSpan{{Offset: 0, Length: 1}},
+						NegativeDeltas: []int64{4},
+						Timestamp:      testTimestamp,
+					}},
+				}},
+			},
+			want: expectedMetrics(func(metrics pmetric.MetricSlice) {
+				m := metrics.AppendEmpty()
+				m.SetName("latency")
+				histogram := m.SetEmptyExponentialHistogram()
+				histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := histogram.DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetScale(1)
+				dp.SetCount(12)
+				dp.SetSum(42.5)
+				dp.SetZeroCount(2)
+				dp.Positive().SetOffset(-2)
+				dp.Positive().BucketCounts().FromRaw([]uint64{1, 2, 0, 0, 3})
+				dp.Negative().SetOffset(-1)
+				dp.Negative().BucketCounts().FromRaw([]uint64{4})
+			}),
+		},
+		{
+			name: "exemplars",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{{
+					Labels:  []prompb.Label{{Name: "__name__", Value: "requests_total"}, {Name: "instance", Value: "localhost:8080"}, {Name: "job", Value: "app"}},
+					Samples: []prompb.Sample{{Value: 10, Timestamp: testTimestamp}},
+					Exemplars: []prompb.Exemplar{{
+						Labels: []prompb.Label{
+							{Name: "trace_id", Value: "0102030405060708090a0b0c0d0e0f10"},
+							{Name: "span_id", Value: "0102030405060708"},
+							{Name: "user", Value: "alice"},
+						},
+						Value:     1,
+						Timestamp: testTimestamp,
+					}},
+				}},
+			},
+			want: expectedMetrics(func(metrics pmetric.MetricSlice) {
+				m := metrics.AppendEmpty()
+				m.SetName("requests_total")
+				sum := m.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(ts)
+				dp.SetDoubleValue(10)
+				e := dp.Exemplars().AppendEmpty()
+				e.SetTimestamp(ts)
+				e.SetDoubleValue(1)
+				e.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+				e.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+				e.FilteredAttributes().PutStr("user", "alice")
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := writeRequestToMetrics(tt.req, component.NewDefaultBuildInfo())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteRequestToMetricsResources(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "up"}, {Name: "instance", Value: "a:80"}, {Name: "job", Value: "shop/cart"}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: testTimestamp}},
+			},
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "up"}, {Name: "instance", Value: "b:80"}, {Name: "job", Value: "checkout"}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: testTimestamp}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "target_info"},
+					{Name: "instance", Value: "a:80"},
+					{Name: "job", Value: "shop/cart"},
+					{Name: "k8s_pod_name", Value: "cart-0"},
+				},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: testTimestamp}},
+			},
+		},
+	}
+
+	md, err := writeRequestToMetrics(req, component.NewDefaultBuildInfo())
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	assert.Equal(t, map[string]any{
+		"service.namespace":   "shop",
+		"service.name":        "cart",
+		"service.instance.id": "a:80",
+		"k8s_pod_name":        "cart-0",
+	}, md.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{
+		"service.name":        "checkout",
+		"service.instance.id": "b:80",
+	}, md.ResourceMetrics().At(1).Resource().Attributes().AsRaw())
+	assert.Equal(t, 2, md.DataPointCount(), "target_info is not converted to a metric")
+}
+
+func TestWriteRequestToMetricsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *prompb.WriteRequest
+	}{
+		{
+			name: "missing metric name",
+			req:  &prompb.WriteRequest{Timeseries: []prompb.TimeSeries{{Samples: []prompb.Sample{{Value: 1}}}}},
+		},
+		{
+			name: "invalid bucket bound",
+			req: &prompb.WriteRequest{Timeseries: []prompb.TimeSeries{
+				series("latency_bucket", 1, "le", "fast"),
+				series("latency_bucket", 1, "le", "+Inf"),
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := writeRequestToMetrics(tt.req, component.NewDefaultBuildInfo())
+			assert.Error(t, err)
+		})
+	}
+}
@@ -41,6 +41,9 @@ type receiverConfig struct {
 	// the "config" section. The keys and values are arbitrarily configured by the user.
 	config     userConfigMap
 	endpointID observer.EndpointID
+	// discovered is set for receivers defined by discovery hints, whose config is validated before
+	// the receiver is created.
+	discovered bool
 }
 
 // userConfigMap is an arbitrary map of string keys to arbitrary values as specified by the user
@@ -89,6 +92,8 @@ type Config struct {
 	// ResourceAttributes is a map of default resource attributes to add to each resource
 	// object received by this receiver from dynamically created receivers.
 	ResourceAttributes resourceAttributes `mapstructure:"resource_attributes"`
+	// Discovery configures starting receivers from endpoint annotations or labels.
+	Discovery DiscoveryConfig `mapstructure:"discovery"`
 }
 
 func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
@@ -103,7 +108,7 @@ func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 
 	for endpointType := range cfg.ResourceAttributes {
 		switch endpointType {
-		case observer.ContainerType, observer.HostPortType, observer.K8sNodeType, observer.PodType, observer.PortType:
+		case observer.ContainerType, observer.HostPortType, observer.K8sNodeType, observer.PodType, observer.PortType, observer.K8sServiceType, observer.K8sIngressType:
 		default:
 			return fmt.Errorf("resource attributes for unsupported endpoint type %q", endpointType)
 		}
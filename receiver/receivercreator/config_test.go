@@ -16,6 +16,7 @@ package receivercreator
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
 	"testing"
 
@@ -30,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 type mockHostFactories struct {
@@ -113,11 +115,30 @@ func TestLoadConfig(t *testing.T) {
 					component.NewIDWithName("mock_observer", "with_name"),
 				},
 				ResourceAttributes: map[observer.EndpointType]map[string]string{
-					observer.ContainerType: {"container.key": "container.value"},
-					observer.PodType:       {"pod.key": "pod.value"},
-					observer.PortType:      {"port.key": "port.value"},
-					observer.HostPortType:  {"hostport.key": "hostport.value"},
-					observer.K8sNodeType:   {"k8s.node.key": "k8s.node.value"},
+					observer.ContainerType:  {"container.key": "container.value"},
+					observer.PodType:        {"pod.key": "pod.value"},
+					observer.PortType:       {"port.key": "port.value"},
+					observer.HostPortType:   {"hostport.key": "hostport.value"},
+					observer.K8sNodeType:    {"k8s.node.key": "k8s.node.value"},
+					observer.K8sServiceType: {"k8s.service.key": "k8s.service.value"},
+					observer.K8sIngressType: {"k8s.ingress.key": "k8s.ingress.value"},
+				},
+				Discovery: createDefaultConfig().(*Config).Discovery,
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "discovery"),
+			expected: &Config{
+				receiverTemplates:  map[string]receiverTemplate{},
+				WatchObservers:     []component.ID{component.NewID("mock_observer")},
+				ResourceAttributes: createDefaultConfig().(*Config).ResourceAttributes,
+				Discovery: DiscoveryConfig{
+					Enabled:          true,
+					AllowedReceivers: []component.Type{"redis", "nop"},
+					Events: DiscoveryEventsConfig{
+						AuthType: k8sconfig.AuthTypeKubeConfig,
+						Enabled:  true,
+					},
 				},
 			},
 		},
@@ -151,6 +172,31 @@ func TestInvalidResourceAttributeEndpointType(t *testing.T) {
 	require.Nil(t, cfg)
 }
 
+func TestInvalidDiscoveryConfig(t *testing.T) {
+	factories, err := otelcoltest.NopFactories()
+	require.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	_, err = otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", "invalid-discovery.yaml"), factories)
+	require.ErrorContains(t, err, "discovery::allowed_receivers must not be empty when discovery is enabled")
+}
+
+func TestDisabledDiscoveryConfig(t *testing.T) {
+	factories, err := otelcoltest.NopFactories()
+	require.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", "disabled-discovery.yaml"), factories)
+	require.NoError(t, err)
+
+	discovery := cfg.Receivers[component.NewID(typeStr)].(*Config).Discovery
+	discovery.Enabled = true
+	discovery.AllowedReceivers = []component.Type{"redis"}
+	require.ErrorContains(t, discovery.Validate(), "invalid authType for kubernetes: invalid")
+}
+
 func TestInvalidReceiverResourceAttributeValueType(t *testing.T) {
 	factories, err := otelcoltest.NopFactories()
 	require.Nil(t, err)
@@ -169,6 +215,13 @@ type nopWithEndpointConfig struct {
 	IntField int    `mapstructure:"int_field"`
 }
 
+func (cfg *nopWithEndpointConfig) Validate() error {
+	if cfg.IntField < 0 {
+		return errors.New("int_field must not be negative")
+	}
+	return nil
+}
+
 type nopWithEndpointFactory struct {
 	rcvr.Factory
 }
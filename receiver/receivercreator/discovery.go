@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/receivercreator"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"gopkg.in/yaml.v3"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+const (
+	// discoveryHintPrefix prefixes the pod annotations and container labels defining discovered receivers.
+	// A hint is either scoped to the whole pod or container (io.opentelemetry.discovery.metrics/<hint>)
+	// or to a single port (io.opentelemetry.discovery.metrics.<port>/<hint>).
+	discoveryHintPrefix = "io.opentelemetry.discovery.metrics"
+	// receiverHint is the hint holding the receiver type and optional name (ie <receiver type>/<name>).
+	receiverHint = "receiver"
+	// configHint is the hint holding the YAML configuration of the receiver.
+	configHint = "config"
+)
+
+// Reasons of the events reported for discovery hints that can't be turned into a running receiver.
+const (
+	reasonInvalidReceiver     = "InvalidReceiver"
+	reasonReceiverNotAllowed  = "ReceiverNotAllowed"
+	reasonInvalidConfig       = "InvalidConfig"
+	reasonReceiverStartFailed = "ReceiverStartFailed"
+)
+
+// discoveryError is a failure to start a receiver from discovery hints along with the reason it is reported with.
+type discoveryError struct {
+	reason string
+	err    error
+}
+
+func (e *discoveryError) Error() string {
+	return fmt.Sprintf("%s: %v", e.reason, e.err)
+}
+
+func (e *discoveryError) Unwrap() error {
+	return e.err
+}
+
+// DiscoveryConfig configures the starting of receivers defined by endpoint annotations or labels
+// instead of receiver templates.
+type DiscoveryConfig struct {
+	// Enabled turns on starting receivers from io.opentelemetry.discovery.metrics pod annotations
+	// and container labels.
+	Enabled bool `mapstructure:"enabled"`
+	// AllowedReceivers is the list of receiver types that may be started from discovery hints.
+	// It must not be empty when discovery is enabled.
+	AllowedReceivers []component.Type `mapstructure:"allowed_receivers"`
+	// Events configures the Kubernetes events reported on the pods whose annotations can't be turned
+	// into a running receiver.
+	Events DiscoveryEventsConfig `mapstructure:"events"`
+}
+
+// DiscoveryEventsConfig configures the reporting of discovery failures as Kubernetes events.
+type DiscoveryEventsConfig struct {
+	// AuthType configures how to authenticate to the Kubernetes API server to create the events.
+	AuthType k8sconfig.AuthType `mapstructure:"auth_type"`
+	// Enabled turns on creating a Warning event on the pod for every discovery failure of a pod or port endpoint.
+	Enabled bool `mapstructure:"enabled"`
+
+	// For mocking
+	makeClient func(apiConf k8sconfig.APIConfig) (k8s.Interface, error)
+}
+
+// Validate checks if the discovery configuration is valid.
+func (cfg *DiscoveryConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if len(cfg.AllowedReceivers) == 0 {
+		return errors.New("discovery::allowed_receivers must not be empty when discovery is enabled")
+	}
+	if cfg.Events.Enabled {
+		return cfg.Events.apiConfig().Validate()
+	}
+	return nil
+}
+
+func (cfg *DiscoveryEventsConfig) getK8sClient() (k8s.Interface, error) {
+	if cfg.makeClient == nil {
+		cfg.makeClient = k8sconfig.MakeClient
+	}
+	return cfg.makeClient(cfg.apiConfig())
+}
+
+// apiConfig returns the settings of the Kubernetes API client creating the events.
+func (cfg *DiscoveryEventsConfig) apiConfig() k8sconfig.APIConfig {
+	return k8sconfig.APIConfig{AuthType: cfg.AuthType}
+}
+
+func (cfg *DiscoveryConfig) isAllowed(receiverType component.Type) bool {
+	for _, allowed := range cfg.AllowedReceivers {
+		if allowed == receiverType {
+			return true
+		}
+	}
+	return false
+}
+
+// hintKey returns the annotation or label key of a hint, scoped to a port if port isn't 0.
+func hintKey(hint string, port uint16) string {
+	if port == 0 {
+		return fmt.Sprintf("%s/%s", discoveryHintPrefix, hint)
+	}
+	return fmt.Sprintf("%s.%d/%s", discoveryHintPrefix, port, hint)
+}
+
+// endpointHints returns the hints applying to an endpoint and the port they are scoped to:
+// pods use pod-scoped annotations, ports use the port-scoped annotations of their pod, and
+// containers use port-scoped labels, falling back to container-scoped ones.
+func endpointHints(e observer.Endpoint) (map[string]string, uint16, bool) {
+	switch details := e.Details.(type) {
+	case *observer.Pod:
+		return details.Annotations, 0, true
+	case *observer.Port:
+		return details.Pod.Annotations, details.Port, true
+	case *observer.Container:
+		if _, ok := details.Labels[hintKey(receiverHint, details.Port)]; ok {
+			return details.Labels, details.Port, true
+		}
+		return details.Labels, 0, true
+	default:
+		return nil, 0, false
+	}
+}
+
+// receiverTemplateFromHints builds the receiver template defined by the discovery hints of an endpoint.
+// It returns false if the endpoint has no hints and a *discoveryError if the hints are invalid
+// or define a receiver that isn't allowed.
+func (cfg *DiscoveryConfig) receiverTemplateFromHints(e observer.Endpoint) (receiverTemplate, bool, error) {
+	hints, port, ok := endpointHints(e)
+	if !ok {
+		return receiverTemplate{}, false, nil
+	}
+	receiverID, ok := hints[hintKey(receiverHint, port)]
+	if !ok {
+		return receiverTemplate{}, false, nil
+	}
+
+	id := component.ID{}
+	if err := id.UnmarshalText([]byte(receiverID)); err != nil {
+		return receiverTemplate{}, true, &discoveryError{reason: reasonInvalidReceiver, err: err}
+	}
+	if !cfg.isAllowed(id.Type()) {
+		return receiverTemplate{}, true, &discoveryError{
+			reason: reasonReceiverNotAllowed,
+			err:    fmt.Errorf("receiver type %q is not in discovery::allowed_receivers", id.Type()),
+		}
+	}
+
+	receiverCfg := userConfigMap{}
+	if rawCfg, ok := hints[hintKey(configHint, port)]; ok {
+		if err := yaml.Unmarshal([]byte(rawCfg), &receiverCfg); err != nil {
+			return receiverTemplate{}, true, &discoveryError{
+				reason: reasonInvalidConfig,
+				err:    fmt.Errorf("failed to parse %q: %w", hintKey(configHint, port), err),
+			}
+		}
+	}
+
+	return receiverTemplate{
+		receiverConfig: receiverConfig{
+			id:         id,
+			config:     receiverCfg,
+			endpointID: e.ID,
+			discovered: true,
+		},
+	}, true, nil
+}
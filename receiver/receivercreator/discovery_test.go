@@ -0,0 +1,322 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func podEndpointWithAnnotations(annotations map[string]string) observer.Endpoint {
+	p := pod
+	p.Annotations = annotations
+	return observer.Endpoint{ID: "pod-1", Target: "localhost", Details: &p}
+}
+
+func portEndpointWithAnnotations(annotations map[string]string) observer.Endpoint {
+	p := pod
+	p.Annotations = annotations
+	return observer.Endpoint{
+		ID:      "port-1",
+		Target:  "localhost:1234",
+		Details: &observer.Port{Name: "http", Pod: p, Port: 1234, Transport: observer.ProtocolTCP},
+	}
+}
+
+func containerEndpointWithLabels(labels map[string]string) observer.Endpoint {
+	c := container
+	c.Labels = labels
+	return observer.Endpoint{ID: "container-1", Target: "localhost:8080", Details: &c}
+}
+
+func TestReceiverTemplateFromHints(t *testing.T) {
+	cfg := DiscoveryConfig{Enabled: true, AllowedReceivers: []component.Type{"redis"}}
+
+	tests := []struct {
+		name           string
+		endpoint       observer.Endpoint
+		expectedFound  bool
+		expectedID     component.ID
+		expectedConfig userConfigMap
+		expectedReason string
+	}{
+		{
+			name:     "no hints",
+			endpoint: podEndpointWithAnnotations(map[string]string{"scrape": "true"}),
+		},
+		{
+			name:     "unsupported endpoint",
+			endpoint: hostportEndpoint,
+		},
+		{
+			name: "pod hints",
+			endpoint: podEndpointWithAnnotations(map[string]string{
+				"io.opentelemetry.discovery.metrics/receiver": "redis/cache",
+				"io.opentelemetry.discovery.metrics/config":   "endpoint: '`endpoint`:6379'\npassword: secret\n",
+			}),
+			expectedFound:  true,
+			expectedID:     component.NewIDWithName("redis", "cache"),
+			expectedConfig: userConfigMap{"endpoint": "`endpoint`:6379", "password": "secret"},
+		},
+		{
+			name: "port hints",
+			endpoint: portEndpointWithAnnotations(map[string]string{
+				"io.opentelemetry.discovery.metrics/receiver":      "redis/pod",
+				"io.opentelemetry.discovery.metrics.1234/receiver": "redis",
+			}),
+			expectedFound:  true,
+			expectedID:     component.NewID("redis"),
+			expectedConfig: userConfigMap{},
+		},
+		{
+			name: "pod hints ignored for ports",
+			endpoint: portEndpointWithAnnotations(map[string]string{
+				"io.opentelemetry.discovery.metrics/receiver": "redis",
+			}),
+		},
+		{
+			name: "container hints",
+			endpoint: containerEndpointWithLabels(map[string]string{
+				"io.opentelemetry.discovery.metrics/receiver": "redis",
+				"io.opentelemetry.discovery.metrics/config":   "collection_interval: 30s",
+			}),
+			expectedFound:  true,
+			expectedID:     component.NewID("redis"),
+			expectedConfig: userConfigMap{"collection_interval": "30s"},
+		},
+		{
+			name: "container port hints take precedence",
+			endpoint: containerEndpointWithLabels(map[string]string{
+				"io.opentelemetry.discovery.metrics/receiver":      "redis/container",
+				"io.opentelemetry.discovery.metrics.8080/receiver": "redis/port",
+			}),
+			expectedFound:  true,
+			expectedID:     component.NewIDWithName("redis", "port"),
+			expectedConfig: userConfigMap{},
+		},
+		{
+			name: "invalid receiver",
+			endpoint: podEndpointWithAnnotations(map[string]string{
+				"io.opentelemetry.discovery.metrics/receiver": "/",
+			}),
+			expectedFound:  true,
+			expectedReason: reasonInvalidReceiver,
+		},
+		{
+			name: "receiver not allowed",
+			endpoint: podEndpointWithAnnotations(map[string]string{
+				"io.opentelemetry.discovery.metrics/receiver": "hostmetrics",
+			}),
+			expectedFound:  true,
+			expectedReason: reasonReceiverNotAllowed,
+		},
+		{
+			name: "invalid config",
+			endpoint: podEndpointWithAnnotations(map[string]string{
+				"io.opentelemetry.discovery.metrics/receiver": "redis",
+				"io.opentelemetry.discovery.metrics/config":   "not a map",
+			}),
+			expectedFound:  true,
+			expectedReason: reasonInvalidConfig,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, found, err := cfg.receiverTemplateFromHints(tt.endpoint)
+			assert.Equal(t, tt.expectedFound, found)
+			if tt.expectedReason != "" {
+				var discoveryErr *discoveryError
+				require.ErrorAs(t, err, &discoveryErr)
+				assert.Equal(t, tt.expectedReason, discoveryErr.reason)
+				return
+			}
+			require.NoError(t, err)
+			if !tt.expectedFound {
+				return
+			}
+			assert.Equal(t, tt.expectedID, template.id)
+			assert.Equal(t, tt.expectedConfig, template.config)
+			assert.Equal(t, tt.endpoint.ID, template.endpointID)
+		})
+	}
+}
+
+func TestOnAddDiscovery(t *testing.T) {
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		expectedConfig *nopWithEndpointConfig
+		expectedReason string
+	}{
+		{
+			name: "receiver started",
+			annotations: map[string]string{
+				"io.opentelemetry.discovery.metrics.1234/receiver": "with.endpoint",
+				"io.opentelemetry.discovery.metrics.1234/config":   "int_field: 42",
+			},
+			expectedConfig: &nopWithEndpointConfig{Endpoint: "localhost:1234", IntField: 42},
+		},
+		{
+			name: "receiver not allowed",
+			annotations: map[string]string{
+				"io.opentelemetry.discovery.metrics.1234/receiver": "without.endpoint",
+			},
+			expectedReason: reasonReceiverNotAllowed,
+		},
+		{
+			name: "config fails validation",
+			annotations: map[string]string{
+				"io.opentelemetry.discovery.metrics.1234/receiver": "with.endpoint",
+				"io.opentelemetry.discovery.metrics.1234/config":   "int_field: -1",
+			},
+			expectedReason: reasonInvalidConfig,
+		},
+		{
+			name: "config fails loading",
+			annotations: map[string]string{
+				"io.opentelemetry.discovery.metrics.1234/receiver": "with.endpoint",
+				"io.opentelemetry.discovery.metrics.1234/config":   "unknown_field: true",
+			},
+			expectedReason: reasonInvalidConfig,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Discovery = DiscoveryConfig{Enabled: true, AllowedReceivers: []component.Type{"with.endpoint"}}
+
+			core, logs := zapobserver.New(zap.WarnLevel)
+			client := fake.NewSimpleClientset()
+			handler, mr := newObserverHandler(t, cfg)
+			handler.params = receivertest.NewNopCreateSettings()
+			handler.params.Logger = zap.New(core)
+			handler.events = &discoveryEventRecorder{logger: handler.params.Logger, source: typeStr, client: client}
+			handler.OnAdd([]observer.Endpoint{portEndpointWithAnnotations(tt.annotations)})
+
+			events, err := client.CoreV1().Events(pod.Namespace).List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err)
+
+			if tt.expectedReason != "" {
+				assert.Equal(t, 0, handler.receiversByEndpointID.Size())
+				entries := logs.FilterMessage("discovery event").All()
+				require.Len(t, entries, 1)
+				assert.Equal(t, tt.expectedReason, entries[0].ContextMap()["reason"])
+				assert.Equal(t, "port-1", entries[0].ContextMap()["endpoint_id"])
+
+				require.Len(t, events.Items, 1)
+				event := events.Items[0]
+				assert.Equal(t, tt.expectedReason, event.Reason)
+				assert.Equal(t, corev1.EventTypeWarning, event.Type)
+				assert.Equal(t, typeStr, event.Source.Component)
+				assert.Equal(t, corev1.ObjectReference{
+					APIVersion: "v1",
+					Kind:       "Pod",
+					Name:       pod.Name,
+					Namespace:  pod.Namespace,
+					UID:        "uid-1",
+				}, event.InvolvedObject)
+				return
+			}
+
+			assert.Equal(t, 1, handler.receiversByEndpointID.Size())
+			assert.Equal(t, 0, logs.Len())
+			assert.Empty(t, events.Items)
+			require.NoError(t, mr.lastError)
+			rcvr, ok := mr.startedComponent.(*nopWithEndpointReceiver)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedConfig, rcvr.cfg)
+		})
+	}
+}
+
+func TestRecordDiscoveryEvent(t *testing.T) {
+	tests := []struct {
+		name             string
+		endpoint         observer.Endpoint
+		receiverID       component.ID
+		expectedReceiver bool
+		expectedEvent    bool
+		expectedMessage  string
+	}{
+		{
+			name:             "pod with receiver",
+			endpoint:         podEndpoint,
+			receiverID:       component.NewIDWithName("redis", "1"),
+			expectedReceiver: true,
+			expectedEvent:    true,
+			expectedMessage:  `receiver "redis/1": some error`,
+		},
+		{
+			name:            "pod without receiver",
+			endpoint:        podEndpoint,
+			expectedEvent:   true,
+			expectedMessage: "some error",
+		},
+		{
+			name:             "container",
+			endpoint:         containerEndpoint,
+			receiverID:       component.NewID("redis"),
+			expectedReceiver: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := zapobserver.New(zap.WarnLevel)
+			client := fake.NewSimpleClientset()
+			recorder := &discoveryEventRecorder{logger: zap.New(core), source: typeStr, client: client}
+			recorder.record(tt.endpoint, tt.receiverID, &discoveryError{reason: reasonInvalidReceiver, err: errors.New("some error")})
+
+			entries := logs.FilterMessage("discovery event").All()
+			require.Len(t, entries, 1)
+			fields := entries[0].ContextMap()
+			assert.Equal(t, string(tt.endpoint.ID), fields["endpoint_id"])
+			_, hasReceiver := fields["receiver"]
+			assert.Equal(t, tt.expectedReceiver, hasReceiver)
+
+			events, err := client.CoreV1().Events(pod.Namespace).List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err)
+			if !tt.expectedEvent {
+				assert.Empty(t, events.Items)
+				return
+			}
+			require.Len(t, events.Items, 1)
+			assert.Equal(t, reasonInvalidReceiver, events.Items[0].Reason)
+			assert.Equal(t, tt.expectedMessage, events.Items[0].Message)
+		})
+	}
+}
+
+func TestOnAddDiscoveryDisabled(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	handler, mr := newObserverHandler(t, cfg)
+	handler.OnAdd([]observer.Endpoint{portEndpointWithAnnotations(map[string]string{
+		"io.opentelemetry.discovery.metrics.1234/receiver": "with.endpoint",
+	})})
+	assert.Equal(t, 0, handler.receiversByEndpointID.Size())
+	assert.Nil(t, mr.startedComponent)
+}
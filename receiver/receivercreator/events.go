@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/receivercreator"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// eventTimeout bounds the creation of a Kubernetes event, which happens while handling endpoint notifications.
+const eventTimeout = 5 * time.Second
+
+// discoveryEventRecorder reports the discovery hints that can't be turned into a running receiver.
+// Failures are always logged, and reported as Kubernetes events on the pod defining the hints when
+// a client is set.
+type discoveryEventRecorder struct {
+	logger *zap.Logger
+	// source is the component reported as creating the Kubernetes events.
+	source string
+	client k8s.Interface
+}
+
+// record reports a discovery failure of an endpoint. receiverID is empty if the hints didn't
+// define a valid receiver.
+func (r *discoveryEventRecorder) record(e observer.Endpoint, receiverID component.ID, discoveryErr *discoveryError) {
+	fields := []zap.Field{
+		zap.String("reason", discoveryErr.reason),
+		zap.String("endpoint_id", string(e.ID)),
+	}
+	if receiverID != (component.ID{}) {
+		fields = append(fields, zap.String("receiver", receiverID.String()))
+	}
+	r.logger.Warn("discovery event", append(fields, zap.Error(discoveryErr.err))...)
+
+	if r.client == nil {
+		return
+	}
+	var pod *observer.Pod
+	switch details := e.Details.(type) {
+	case *observer.Pod:
+		pod = details
+	case *observer.Port:
+		pod = &details.Pod
+	default:
+		return
+	}
+	if err := r.createEvent(pod, receiverID, discoveryErr); err != nil {
+		r.logger.Error("failed to create discovery event",
+			zap.String("endpoint_id", string(e.ID)),
+			zap.Error(err))
+	}
+}
+
+func (r *discoveryEventRecorder) createEvent(pod *observer.Pod, receiverID component.ID, discoveryErr *discoveryError) error {
+	message := discoveryErr.err.Error()
+	if receiverID != (component.ID{}) {
+		message = fmt.Sprintf("receiver %q: %s", receiverID.String(), message)
+	}
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: pod.Name + ".",
+			Namespace:    pod.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Name:       pod.Name,
+			Namespace:  pod.Namespace,
+			UID:        types.UID(pod.UID),
+		},
+		Reason:         discoveryErr.reason,
+		Message:        message,
+		Type:           corev1.EventTypeWarning,
+		Source:         corev1.EventSource{Component: r.source},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), eventTimeout)
+	defer cancel()
+	_, err := r.client.CoreV1().Events(pod.Namespace).Create(ctx, event, metav1.CreateOptions{})
+	return err
+}
@@ -23,6 +23,7 @@ import (
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 // This file implements factory for receiver_creator. A receiver_creator can create other receivers at runtime.
@@ -61,8 +62,19 @@ func createDefaultConfig() component.Config {
 				conventions.AttributeK8SNodeName: "`name`",
 				conventions.AttributeK8SNodeUID:  "`uid`",
 			},
+			observer.K8sServiceType: map[string]string{
+				conventions.AttributeK8SNamespaceName: "`namespace`",
+			},
+			observer.K8sIngressType: map[string]string{
+				conventions.AttributeK8SNamespaceName: "`namespace`",
+			},
 		},
 		receiverTemplates: map[string]receiverTemplate{},
+		Discovery: DiscoveryConfig{
+			Events: DiscoveryEventsConfig{
+				AuthType: k8sconfig.AuthTypeServiceAccount,
+			},
+		},
 	}
 }
 
@@ -103,6 +103,40 @@ var k8sNodeEndpoint = observer.Endpoint{
 	},
 }
 
+var k8sServiceEndpoint = observer.Endpoint{
+	ID:     "k8s.service-1",
+	Target: "service-1.default.svc:8080",
+	Details: &observer.K8sService{
+		Name:      "service-1",
+		Namespace: "default",
+		UID:       "service-1-UID",
+		Labels: map[string]string{
+			"app": "web",
+		},
+		Annotations: map[string]string{
+			"prometheus.io/scrape": "true",
+		},
+		ServiceType: "ClusterIP",
+		ClusterIP:   "10.0.0.1",
+		Port:        8080,
+		PortName:    "http",
+		Protocol:    "TCP",
+	},
+}
+
+var k8sIngressEndpoint = observer.Endpoint{
+	ID:     "k8s.ingress-1",
+	Target: "https://example.com/api",
+	Details: &observer.K8sIngress{
+		Name:      "ingress-1",
+		Namespace: "default",
+		UID:       "ingress-1-UID",
+		Scheme:    "https",
+		Host:      "example.com",
+		Path:      "/api",
+	},
+}
+
 var unsupportedEndpoint = observer.Endpoint{
 	ID:      "endpoint-1",
 	Target:  "localhost:1234",
@@ -15,6 +15,7 @@
 package receivercreator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/receivercreator"
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 
@@ -48,6 +49,8 @@ type observerHandler struct {
 	nextConsumer consumer.Metrics
 	// runner starts and stops receiver instances.
 	runner runner
+	// events reports the discovery hints that can't be turned into a running receiver.
+	events *discoveryEventRecorder
 }
 
 // shutdown all receivers started at runtime.
@@ -98,76 +101,106 @@ func (obs *observerHandler) OnAdd(added []observer.Endpoint) {
 				continue
 			}
 
-			obs.params.TelemetrySettings.Logger.Info("starting receiver",
-				zap.String("name", template.id.String()),
-				zap.String("endpoint", e.Target),
-				zap.String("endpoint_id", string(e.ID)))
-
-			resolvedConfig, err := expandConfig(template.config, env)
-			if err != nil {
-				obs.params.TelemetrySettings.Logger.Error("unable to resolve template config", zap.String("receiver", template.id.String()), zap.Error(err))
-				continue
+			if err = obs.startReceiver(template, e, env); err != nil {
+				obs.params.TelemetrySettings.Logger.Error("failed to start receiver", zap.String("receiver", template.id.String()), zap.Error(err))
 			}
+		}
 
-			discoveredCfg := userConfigMap{}
-			// If user didn't set endpoint set to default value as well as
-			// flag indicating we've done this for later validation.
-			if _, ok := resolvedConfig[endpointConfigKey]; !ok {
-				discoveredCfg[endpointConfigKey] = e.Target
-				discoveredCfg[tmpSetEndpointConfigKey] = struct{}{}
-			}
+		if obs.config.Discovery.Enabled {
+			obs.startDiscoveredReceiver(e, env)
+		}
+	}
+}
 
-			// Though not necessary with contrib provided observers, nothing is stopping custom
-			// ones from using expr in their Target values.
-			discoveredConfig, err := expandConfig(discoveredCfg, env)
-			if err != nil {
-				obs.params.TelemetrySettings.Logger.Error("unable to resolve discovered config", zap.String("receiver", template.id.String()), zap.Error(err))
-				continue
+// startDiscoveredReceiver starts the receiver defined by the discovery hints of an endpoint, if any.
+// Hints that can't be turned into a running receiver are reported as discovery events.
+func (obs *observerHandler) startDiscoveredReceiver(e observer.Endpoint, env observer.EndpointEnv) {
+	template, found, err := obs.config.Discovery.receiverTemplateFromHints(e)
+	if !found {
+		return
+	}
+	if err == nil {
+		if err = obs.startReceiver(template, e, env); err != nil {
+			reason := reasonReceiverStartFailed
+			var cfgErr *configError
+			if errors.As(err, &cfgErr) {
+				reason = reasonInvalidConfig
 			}
+			err = &discoveryError{reason: reason, err: err}
+		}
+	}
+	var discoveryErr *discoveryError
+	if errors.As(err, &discoveryErr) {
+		obs.events.record(e, template.id, discoveryErr)
+	}
+}
 
-			resAttrs := map[string]string{}
-			for k, v := range template.ResourceAttributes {
-				strVal, ok := v.(string)
-				if !ok {
-					obs.params.TelemetrySettings.Logger.Info(fmt.Sprintf("ignoring unsupported `resource_attributes` %q value %v", k, v))
-					continue
-				}
-				resAttrs[k] = strVal
-			}
+// startReceiver starts a receiver instance from a template for a matching endpoint.
+func (obs *observerHandler) startReceiver(template receiverTemplate, e observer.Endpoint, env observer.EndpointEnv) error {
+	obs.params.TelemetrySettings.Logger.Info("starting receiver",
+		zap.String("name", template.id.String()),
+		zap.String("endpoint", e.Target),
+		zap.String("endpoint_id", string(e.ID)))
 
-			// Adds default and/or configured resource attributes (e.g. k8s.pod.uid) to resources
-			// as telemetry is emitted.
-			resourceEnhancer, err := newResourceEnhancer(
-				obs.config.ResourceAttributes,
-				resAttrs,
-				env,
-				e,
-				obs.nextConsumer,
-			)
-
-			if err != nil {
-				obs.params.TelemetrySettings.Logger.Error("failed creating resource enhancer", zap.String("receiver", template.id.String()), zap.Error(err))
-				continue
-			}
+	resolvedConfig, err := expandConfig(template.config, env)
+	if err != nil {
+		return &configError{fmt.Errorf("unable to resolve template config: %w", err)}
+	}
 
-			rcvr, err := obs.runner.start(
-				receiverConfig{
-					id:         template.id,
-					config:     resolvedConfig,
-					endpointID: e.ID,
-				},
-				discoveredConfig,
-				resourceEnhancer,
-			)
-
-			if err != nil {
-				obs.params.TelemetrySettings.Logger.Error("failed to start receiver", zap.String("receiver", template.id.String()), zap.Error(err))
-				continue
-			}
+	discoveredCfg := userConfigMap{}
+	// If user didn't set endpoint set to default value as well as
+	// flag indicating we've done this for later validation.
+	if _, ok := resolvedConfig[endpointConfigKey]; !ok {
+		discoveredCfg[endpointConfigKey] = e.Target
+		discoveredCfg[tmpSetEndpointConfigKey] = struct{}{}
+	}
+
+	// Though not necessary with contrib provided observers, nothing is stopping custom
+	// ones from using expr in their Target values.
+	discoveredConfig, err := expandConfig(discoveredCfg, env)
+	if err != nil {
+		return &configError{fmt.Errorf("unable to resolve discovered config: %w", err)}
+	}
 
-			obs.receiversByEndpointID.Put(e.ID, rcvr)
+	resAttrs := map[string]string{}
+	for k, v := range template.ResourceAttributes {
+		strVal, ok := v.(string)
+		if !ok {
+			obs.params.TelemetrySettings.Logger.Info(fmt.Sprintf("ignoring unsupported `resource_attributes` %q value %v", k, v))
+			continue
 		}
+		resAttrs[k] = strVal
+	}
+
+	// Adds default and/or configured resource attributes (e.g. k8s.pod.uid) to resources
+	// as telemetry is emitted.
+	resourceEnhancer, err := newResourceEnhancer(
+		obs.config.ResourceAttributes,
+		resAttrs,
+		env,
+		e,
+		obs.nextConsumer,
+	)
+	if err != nil {
+		return fmt.Errorf("failed creating resource enhancer: %w", err)
 	}
+
+	rcvr, err := obs.runner.start(
+		receiverConfig{
+			id:         template.id,
+			config:     resolvedConfig,
+			endpointID: e.ID,
+			discovered: template.discovered,
+		},
+		discoveredConfig,
+		resourceEnhancer,
+	)
+	if err != nil {
+		return err
+	}
+
+	obs.receiversByEndpointID.Put(e.ID, rcvr)
+	return nil
 }
 
 // OnRemove responds to endpoint removal notifications.
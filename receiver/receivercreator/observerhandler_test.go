@@ -263,5 +263,6 @@ func newObserverHandler(t *testing.T, config *Config) (*observerHandler, *mockRu
 		config:                config,
 		receiversByEndpointID: receiverMap{},
 		runner:                mr,
+		events:                &discoveryEventRecorder{logger: set.Logger, source: set.ID.String()},
 	}, mr
 }
@@ -66,11 +66,21 @@ var _ component.Host = (*loggingHost)(nil)
 
 // Start receiver_creator.
 func (rc *receiverCreator) Start(_ context.Context, host component.Host) error {
+	events := &discoveryEventRecorder{logger: rc.params.Logger, source: rc.params.ID.String()}
+	if rc.cfg.Discovery.Enabled && rc.cfg.Discovery.Events.Enabled {
+		client, err := rc.cfg.Discovery.Events.getK8sClient()
+		if err != nil {
+			return fmt.Errorf("failed to create the Kubernetes client for discovery events: %w", err)
+		}
+		events.client = client
+	}
+
 	rc.observerHandler = &observerHandler{
 		config:                rc.cfg,
 		params:                rc.params,
 		receiversByEndpointID: receiverMap{},
 		nextConsumer:          rc.nextConsumer,
+		events:                events,
 		runner: &receiverRunner{
 			params:      rc.params,
 			idNamespace: rc.params.ID,
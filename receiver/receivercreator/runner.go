@@ -26,6 +26,15 @@ import (
 	"go.uber.org/zap"
 )
 
+// configError is returned for receiver configurations that can't be loaded or are invalid.
+type configError struct {
+	error
+}
+
+func (e *configError) Unwrap() error {
+	return e.error
+}
+
 // runner starts and stops receiver instances.
 type runner interface {
 	// start a receiver instance from its static config and discovered config.
@@ -92,12 +101,19 @@ func (run *receiverRunner) loadRuntimeReceiverConfig(
 	// remove dynamically added "endpoint" field if not supported by receiver
 	mergedConfig, targetEndpoint, err := mergeTemplatedAndDiscoveredConfigs(factory, receiver.config, discoveredConfig)
 	if err != nil {
-		return nil, targetEndpoint, fmt.Errorf("failed to merge constituent template configs: %w", err)
+		return nil, targetEndpoint, &configError{fmt.Errorf("failed to merge constituent template configs: %w", err)}
 	}
 
 	receiverCfg := factory.CreateDefaultConfig()
 	if err := component.UnmarshalConfig(mergedConfig, receiverCfg); err != nil {
-		return nil, "", fmt.Errorf("failed to load %q template config: %w", receiver.id.String(), err)
+		return nil, "", &configError{fmt.Errorf("failed to load %q template config: %w", receiver.id.String(), err)}
+	}
+	// Unlike receiver templates, the configs of discovered receivers come from endpoint hints
+	// and haven't been checked by the Collector.
+	if receiver.discovered {
+		if err := component.ValidateConfig(receiverCfg); err != nil {
+			return nil, "", &configError{fmt.Errorf("invalid %q config: %w", receiver.id.String(), err)}
+		}
 	}
 	return receiverCfg, targetEndpoint, nil
 }
@@ -127,3 +127,23 @@ func TestValidateSetEndpointFromConfig(t *testing.T) {
 	require.Equal(t, "an.endpoint", inheritedEndpoint)
 	require.NoError(t, inheritedErr)
 }
+
+func TestLoadRuntimeReceiverConfigValidation(t *testing.T) {
+	run := &receiverRunner{params: receivertest.NewNopCreateSettings(), idNamespace: component.NewIDWithName(typeStr, "1")}
+	receiverCfg := receiverConfig{
+		id:         component.NewIDWithName("with.endpoint", "1"),
+		config:     userConfigMap{"int_field": -1},
+		endpointID: "endpoint.id",
+	}
+
+	// Receiver templates are validated along with the Collector configuration.
+	loadedConfig, _, err := run.loadRuntimeReceiverConfig(&nopWithEndpointFactory{}, receiverCfg, userConfigMap{})
+	require.NoError(t, err)
+	assert.Equal(t, -1, loadedConfig.(*nopWithEndpointConfig).IntField)
+
+	receiverCfg.discovered = true
+	_, _, err = run.loadRuntimeReceiverConfig(&nopWithEndpointFactory{}, receiverCfg, userConfigMap{})
+	var cfgErr *configError
+	require.ErrorAs(t, err, &cfgErr)
+	assert.ErrorContains(t, err, "int_field must not be negative")
+}